@@ -17,9 +17,12 @@ limitations under the License.
 
 import (
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/rest"
 	cruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -27,6 +30,7 @@ import (
 	"github.com/crunchydata/postgres-operator/internal/controller/postgrescluster"
 	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
 	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 var versionString string
@@ -86,11 +90,64 @@ func main() {
 // addControllersToManager adds all PostgreSQL Operator controllers to the provided controller
 // runtime manager.
 func addControllersToManager(mgr manager.Manager) error {
+	selectorLabels, err := labels.ConvertSelectorToLabelsMap(
+		os.Getenv("PGO_PGBACKREST_SELECTOR_LABELS"))
+	if err != nil {
+		return err
+	}
+
 	r := &postgrescluster.Reconciler{
-		Client:   mgr.GetClient(),
-		Owner:    postgrescluster.ControllerName,
-		Recorder: mgr.GetEventRecorderFor(postgrescluster.ControllerName),
-		Tracer:   otel.Tracer(postgrescluster.ControllerName),
+		Client:      mgr.GetClient(),
+		Owner:       postgrescluster.ControllerName,
+		Recorder:    mgr.GetEventRecorderFor(postgrescluster.ControllerName),
+		Tracer:      otel.Tracer(postgrescluster.ControllerName),
+		RepoWorkers: envInt("PGO_PGBACKREST_REPO_WORKERS"),
+		Workers:     envInt("PGO_WORKERS"),
+
+		ConfigHashMismatchInterval: envDuration("PGO_PGBACKREST_CONFIG_HASH_MISMATCH_INTERVAL"),
+		DefaultBackupSchedules:     envBackupSchedules(),
+		PGBackRestSelectorLabels:   selectorLabels,
 	}
 	return r.SetupWithManager(mgr)
 }
+
+// envInt returns the integer value of the environment variable named by key. Zero is returned
+// when the variable is unset or cannot be parsed, allowing the default to apply.
+func envInt(key string) int {
+	i, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return 0
+	}
+	return i
+}
+
+// envDuration returns the duration value (e.g. "30s") of the environment variable named by key.
+// Zero is returned when the variable is unset or cannot be parsed, allowing the default to apply.
+func envDuration(key string) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// envBackupSchedules returns the default pgBackRest backup schedules defined using the
+// environment, or nil when no default schedules are defined.
+func envBackupSchedules() *v1beta1.PGBackRestBackupSchedules {
+	schedule := func(key string) *string {
+		if value := os.Getenv(key); value != "" {
+			return &value
+		}
+		return nil
+	}
+
+	schedules := &v1beta1.PGBackRestBackupSchedules{
+		Full:         schedule("PGO_BACKUP_SCHEDULE_FULL"),
+		Differential: schedule("PGO_BACKUP_SCHEDULE_DIFF"),
+		Incremental:  schedule("PGO_BACKUP_SCHEDULE_INCR"),
+	}
+	if schedules.Full == nil && schedules.Differential == nil && schedules.Incremental == nil {
+		return nil
+	}
+	return schedules
+}
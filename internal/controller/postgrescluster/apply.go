@@ -19,6 +19,7 @@ import (
 	"context"
 	"reflect"
 
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -56,6 +57,14 @@ func (r *Reconciler) apply(ctx context.Context, object client.Object) error {
 		if !equality.Semantic.DeepEqual(actual.Spec.Selector, selector) {
 			patch.Replace("spec", "selector")(selector)
 		}
+	case *batchv1beta1.CronJob:
+		// Fields that are not part of the intent are left in place when they are managed by
+		// another field manager, e.g. an older version of the operator. Remove the starting
+		// deadline so that existing CronJobs converge to the intent, e.g. after an upgrade.
+		deadline := intent.(*batchv1beta1.CronJob).Spec.StartingDeadlineSeconds
+		if deadline == nil && actual.Spec.StartingDeadlineSeconds != nil {
+			patch.Remove("spec", "startingDeadlineSeconds")
+		}
 	}
 
 	// Send the json-patch when necessary.
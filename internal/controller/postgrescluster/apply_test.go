@@ -27,6 +27,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"gotest.tools/v3/assert"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -261,4 +262,38 @@ func TestServerSideApply(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("CronJob", func(t *testing.T) {
+		reconciler := Reconciler{Client: cc, Owner: client.FieldOwner(t.Name())}
+		constructor := func() *batchv1beta1.CronJob {
+			var cronJob batchv1beta1.CronJob
+			cronJob.SetGroupVersionKind(batchv1beta1.SchemeGroupVersion.WithKind("CronJob"))
+			cronJob.Namespace, cronJob.Name = ns.Name, "cronjob"
+			cronJob.Spec.Schedule = "0 1 * * *"
+			cronJob.Spec.ConcurrencyPolicy = batchv1beta1.AllowConcurrent
+			cronJob.Spec.JobTemplate.Spec.Template.Spec = v1.PodSpec{
+				Containers:    []v1.Container{{Name: "test", Image: "test"}},
+				RestartPolicy: v1.RestartPolicyNever,
+			}
+			return &cronJob
+		}
+
+		// Create the CronJob using another field manager, e.g. an older operator.
+		before := constructor()
+		before.Spec.Schedule = "0 2 * * *"
+		before.Spec.ConcurrencyPolicy = batchv1beta1.ForbidConcurrent
+		before.Spec.StartingDeadlineSeconds = new(int64)
+		*before.Spec.StartingDeadlineSeconds = 60
+		assert.NilError(t,
+			cc.Patch(ctx, before, client.Apply, client.ForceOwnership, client.FieldOwner("old")))
+
+		// Our apply method converges the CronJob to the intent.
+		intent := constructor()
+		again := intent.DeepCopy()
+		assert.NilError(t, reconciler.apply(ctx, again))
+		assert.Equal(t, again.Spec.Schedule, intent.Spec.Schedule)
+		assert.Equal(t, again.Spec.ConcurrencyPolicy, intent.Spec.ConcurrencyPolicy)
+		assert.Assert(t, again.Spec.StartingDeadlineSeconds == nil,
+			"expected no deadline, got %v", *again.Spec.StartingDeadlineSeconds)
+	})
 }
@@ -275,6 +275,14 @@ func (r *Reconciler) reconcileDataSource(ctx context.Context,
 	// restore Job exists, determine if the config has changed
 	configs := []string{dataSource.ClusterName, dataSource.RepoName}
 	configs = append(configs, dataSource.Options...)
+	// the backup set and recovery target are only included when configured, so that the hash
+	// is unchanged for data sources that do not set them
+	if dataSource.Set != "" {
+		configs = append(configs, "--set="+dataSource.Set)
+	}
+	if dataSource.Type != "" || dataSource.Target != "" {
+		configs = append(configs, "--type="+dataSource.Type, "--target="+dataSource.Target)
+	}
 	configHash, err := hashFunc(configs)
 	if err != nil {
 		return false, errors.WithStack(err)
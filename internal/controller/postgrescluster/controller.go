@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel/trace"
@@ -29,6 +30,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -52,8 +54,29 @@ const (
 	// ControllerName is the name of the PostgresCluster controller
 	ControllerName = "postgrescluster-controller"
 
-	// workerCount defines the number of worker queues for the PostgresCluster controller
-	workerCount = 2
+	// ConditionSupplementalGroupsValid is the type used in a condition to indicate that one of
+	// the supplemental groups defined for the PostgresCluster is not a valid group ID
+	ConditionSupplementalGroupsValid = "SupplementalGroupsValid"
+
+	// defaultWorkers defines the default number of worker queues for the PostgresCluster
+	// controller
+	defaultWorkers = 2
+
+	// defaultRepoWorkers defines the default number of pgBackRest repositories that are
+	// reconciled concurrently for a single PostgresCluster
+	defaultRepoWorkers = 2
+
+	// defaultConfigHashMismatchInterval defines how long to wait before reattempting stanza
+	// creation when pgBackRest configuration has not yet propagated to the container
+	defaultConfigHashMismatchInterval = 10 * time.Second
+
+	// restoreProgressInterval defines how often restore progress is observed while a restore
+	// Job is running
+	restoreProgressInterval = 30 * time.Second
+
+	// repoHostCheckInterval defines how often the pgBackRest "check" command runs within the
+	// dedicated repository host when it is required for readiness
+	repoHostCheckInterval = 5 * time.Minute
 )
 
 // Reconciler holds resources for the PostgresCluster reconciler
@@ -67,6 +90,28 @@ type Reconciler struct {
 		namespace, pod, container string,
 		stdin io.Reader, stdout, stderr io.Writer, command ...string,
 	) error
+
+	// RepoWorkers is the maximum number of pgBackRest repositories that are reconciled
+	// concurrently for a single PostgresCluster. When zero, defaultRepoWorkers is used.
+	RepoWorkers int
+
+	// ConfigHashMismatchInterval is how long to wait before reattempting stanza creation when
+	// pgBackRest configuration has not yet propagated to the container, e.g. when ConfigMap
+	// updates are slow to reach Pods. When zero, defaultConfigHashMismatchInterval is used.
+	ConfigHashMismatchInterval time.Duration
+
+	// Workers is the maximum number of PostgresClusters that are reconciled concurrently.
+	// When zero, defaultWorkers is used.
+	Workers int
+
+	// DefaultBackupSchedules are the pgBackRest backup schedules used for any repository that
+	// does not define its own schedules.
+	DefaultBackupSchedules *v1beta1.PGBackRestBackupSchedules
+
+	// PGBackRestSelectorLabels are additional labels that pgBackRest resources must have to be
+	// collected for a PostgresCluster, e.g. to avoid collisions with the resources of other
+	// tenants in a shared namespace.
+	PGBackRestSelectorLabels map[string]string
 }
 
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
@@ -177,6 +222,27 @@ func (r *Reconciler) Reconcile(
 		return result, err
 	}
 
+	// Supplemental groups must be valid, non-root group IDs.  These are also validated by the
+	// CRD, but are checked here in case validation was bypassed, e.g. by an older CRD.
+	if group, invalid := invalidSupplementalGroup(cluster); invalid {
+		message := fmt.Sprintf("supplemental group %d is not allowed: must be between 1 and %d",
+			group, maxGroupID)
+		log.Info("supplemental group not allowed", "group", group)
+		r.Recorder.Event(cluster, v1.EventTypeWarning, "InvalidSupplementalGroup", message)
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			ObservedGeneration: cluster.GetGeneration(),
+			Type:               ConditionSupplementalGroupsValid,
+			Status:             metav1.ConditionFalse,
+			Reason:             "InvalidSupplementalGroup",
+			Message:            message,
+		})
+		return patchClusterStatus()
+	}
+	// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+	if len(cluster.Status.Conditions) > 0 {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionSupplementalGroupsValid)
+	}
+
 	pgHBAs := postgres.NewHBAs()
 	pgmonitor.PostgreSQLHBAs(cluster, &pgHBAs)
 	pgbouncer.PostgreSQL(cluster, &pgHBAs)
@@ -214,6 +280,11 @@ func (r *Reconciler) Reconcile(
 		// can proceed normally.
 		var returnEarly bool
 		returnEarly, err = r.reconcileDataSource(ctx, cluster, instances)
+		// periodically requeue while a restore is running in order to report its progress
+		if err == nil && restoreInProgress(cluster) {
+			result = updateReconcileResult(result,
+				reconcile.Result{RequeueAfter: restoreProgressInterval})
+		}
 		if err != nil || returnEarly {
 			return patchClusterStatus()
 		}
@@ -343,6 +414,14 @@ func (r *Reconciler) setOwnerReference(
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch
 // +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch
 
+// workers returns the maximum number of PostgresClusters that can be reconciled concurrently
+func (r *Reconciler) workers() int {
+	if r.Workers > 0 {
+		return r.Workers
+	}
+	return defaultWorkers
+}
+
 // SetupWithManager adds the PostgresCluster controller to the provided runtime manager
 func (r *Reconciler) SetupWithManager(mgr manager.Manager) error {
 	if r.PodExec == nil {
@@ -356,7 +435,7 @@ func (r *Reconciler) SetupWithManager(mgr manager.Manager) error {
 	return builder.ControllerManagedBy(mgr).
 		For(&v1beta1.PostgresCluster{}).
 		WithOptions(controller.Options{
-			MaxConcurrentReconciles: workerCount,
+			MaxConcurrentReconciles: r.workers(),
 		}).
 		Owns(&v1.ConfigMap{}).
 		Owns(&v1.Endpoints{}).
@@ -365,7 +444,7 @@ func (r *Reconciler) SetupWithManager(mgr manager.Manager) error {
 		Owns(&v1.Service{}).
 		Owns(&v1.ServiceAccount{}).
 		Owns(&appsv1.Deployment{}).
-		Owns(&appsv1.StatefulSet{}).
+		Owns(&appsv1.StatefulSet{}). // e.g. deleting the repo host StatefulSet triggers a reconcile
 		Owns(&batchv1.Job{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
@@ -556,3 +556,21 @@ spec:
 		})
 	})
 })
+
+func TestWorkers(t *testing.T) {
+
+	t.Run("default", func(t *testing.T) {
+		r := &Reconciler{}
+		assert.Equal(t, r.workers(), defaultWorkers)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		r := &Reconciler{Workers: -1}
+		assert.Equal(t, r.workers(), defaultWorkers)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		r := &Reconciler{Workers: 10}
+		assert.Equal(t, r.workers(), 10)
+	})
+}
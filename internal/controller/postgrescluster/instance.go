@@ -1053,7 +1053,7 @@ func (r *Reconciler) reconcileInstance(
 	// add an emptyDir volume to the PodTemplateSpec and an associated '/tmp' volume mount to
 	// all containers included within that spec
 	if err == nil {
-		addTMPEmptyDir(&instance.Spec.Template)
+		addTMPEmptyDir(&instance.Spec.Template, cluster.Spec.Backups.PGBackRest.TmpVolume)
 	}
 
 	if err == nil {
@@ -1159,11 +1159,8 @@ func generateInstanceStatefulSetIntent(_ context.Context,
 	sts.Spec.Template.Spec.ServiceAccountName = instanceServiceAccountName
 
 	podSecurityContext := initialize.RestrictedPodSecurityContext()
-	podSecurityContext.SupplementalGroups = []int64{65534}
-	// set fsGroups if not OpenShift
-	if cluster.Spec.OpenShift == nil || !*cluster.Spec.OpenShift {
-		podSecurityContext.FSGroup = initialize.Int64(26)
-	}
+	podSecurityContext.SupplementalGroups = supplementalGroups(cluster)
+	podSecurityContext.FSGroup = fsGroup(cluster)
 	sts.Spec.Template.Spec.SecurityContext = podSecurityContext
 
 	// Set the image pull secrets, if any exist.
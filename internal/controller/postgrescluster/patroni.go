@@ -221,9 +221,25 @@ func (r *Reconciler) reconcilePatroniStatus(
 	if err == nil {
 		if dcs.Annotations["initialize"] != "" {
 			// After bootstrap, Patroni writes the cluster system identifier to DCS.
-			cluster.Status.Patroni = &v1beta1.PatroniStatus{
+			status := &v1beta1.PatroniStatus{
 				SystemIdentifier: dcs.Annotations["initialize"],
 			}
+
+			// Keep the time the cluster was first observed to be bootstrapped with this system
+			// identifier.  Clusters bootstrapped before this time was recorded fall back to the
+			// time the PostgresCluster was created.
+			if previous := cluster.Status.Patroni; previous != nil &&
+				previous.SystemIdentifier == status.SystemIdentifier {
+				status.BootstrappedAt = previous.BootstrappedAt
+				if status.BootstrappedAt == nil {
+					created := cluster.GetCreationTimestamp()
+					status.BootstrappedAt = &created
+				}
+			} else {
+				now := metav1.Now()
+				status.BootstrappedAt = &now
+			}
+			cluster.Status.Patroni = status
 		} else if readyInstance {
 			// While we typically expect a value for the initialize key to be present in the
 			// Endpoints above by the time the StatefulSet for any instance indicates "ready"
@@ -278,6 +278,16 @@ func TestReconcilePatroniStatus(t *testing.T) {
 				assert.NilError(t, err)
 				assert.DeepEqual(t, result, reconcile.Result{})
 			}
+			if tc.writeAnnotation {
+				assert.Assert(t, postgresCluster.Status.Patroni != nil)
+				bootstrapped := postgresCluster.Status.Patroni.BootstrappedAt
+				assert.Assert(t, bootstrapped != nil)
+
+				// the bootstrap time is kept for the same system identifier
+				_, err := r.reconcilePatroniStatus(ctx, postgresCluster, observedInstances)
+				assert.NilError(t, err)
+				assert.Equal(t, postgresCluster.Status.Patroni.BootstrappedAt, bootstrapped)
+			}
 		})
 	}
 }
@@ -21,7 +21,9 @@ import (
 	"io"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -38,6 +40,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -60,6 +63,14 @@ const (
 	// the manual backup for the current backup ID (as provided via annotation) was successful
 	ConditionManualBackupSuccessful = "PGBackRestManualBackupSuccessful"
 
+	// ConditionFinalBackupSuccessful is the type used in a condition to indicate whether or not
+	// the final backup for the current backup ID (as provided via annotation) was successful
+	ConditionFinalBackupSuccessful = "PGBackRestFinalBackupSuccessful"
+
+	// ConditionExpireSuccessful is the type used in a condition to indicate whether or not the
+	// expire Job for the current expire ID (as provided via annotation) was successful
+	ConditionExpireSuccessful = "PGBackRestExpireSuccessful"
+
 	// ConditionReplicaCreate is the type used in a condition to indicate whether or not
 	// pgBackRest can be utilized for replica creation
 	ConditionReplicaCreate = "PGBackRestReplicaCreate"
@@ -76,6 +87,61 @@ const (
 	// and in-place pgBackRest restore is in progress
 	ConditionPGBackRestRestoreProgressing = "PGBackRestoreProgressing"
 
+	// ConditionConfigHashMismatch is the type used in a condition to indicate that the current
+	// pgBackRest configuration has not yet propagated to the Pod used to create stanzas
+	ConditionConfigHashMismatch = "PGBackRestConfigHashMismatch"
+
+	// ConditionDryRun is the type used in a condition to report the pgBackRest resources that
+	// cleanup would delete, archive or retain while the PostgresCluster is annotated for a dry
+	// run.  Resources that would be created or updated are not reported.
+	ConditionDryRun = "PGBackRestDryRun"
+
+	// ConditionRepoDiskFull is the type used in a condition to indicate that the most recent
+	// backup to a pgBackRest repository failed because the repository ran out of space
+	ConditionRepoDiskFull = "PGBackRestRepoDiskFull"
+
+	// ConditionRepoVolumeUnowned is the type used in a condition to indicate that an existing
+	// PersistentVolumeClaim for a pgBackRest repository is not owned by the PostgresCluster
+	ConditionRepoVolumeUnowned = "PGBackRestRepoVolumeUnowned"
+
+	// ConditionRepoVolumeRemovalBlocked is the type used in a condition to indicate that the
+	// PersistentVolumeClaims of pgBackRest repositories removed from the spec are kept, since no
+	// repository remaining in the spec contains a stanza
+	ConditionRepoVolumeRemovalBlocked = "PGBackRestRepoVolumeRemovalBlocked"
+
+	// ConditionHostNamespaces is the type used in a condition to indicate that pgBackRest Pods
+	// are running within the network, PID or IPC namespace of their node
+	ConditionHostNamespaces = "PGBackRestHostNamespaces"
+
+	// ConditionReposMissing is the type used in a condition to indicate that no pgBackRest
+	// repositories are defined in the spec, and pgBackRest therefore cannot be reconciled
+	ConditionReposMissing = "PGBackRestReposMissing"
+
+	// ConditionReposAccessible is the type used in a condition to indicate whether or not the
+	// buckets or containers of all Azure, GCS and S3 repositories exist and can be accessed
+	ConditionReposAccessible = "PGBackRestReposAccessible"
+
+	// ConditionSchedulesReady is the type used in a condition to indicate whether or not a
+	// CronJob exists with the proper schedule for every backup schedule defined for the cluster
+	ConditionSchedulesReady = "PGBackRestSchedulesReady"
+
+	// ConditionPGBackRestCheck is the type used in a condition to indicate whether or not the
+	// most recent scheduled pgBackRest "check" command was successful
+	ConditionPGBackRestCheck = "PGBackRestCheck"
+
+	// ConditionVersionMismatch is the type used in a condition to indicate whether or not the
+	// version of pgBackRest installed within the repo host differs from the version installed
+	// within the instances
+	ConditionVersionMismatch = "PGBackRestVersionMismatch"
+
+	// ConditionFullScheduleMissing is the type used in a condition to indicate that differential
+	// or incremental backups are scheduled for a repo without also scheduling full backups
+	ConditionFullScheduleMissing = "PGBackRestFullScheduleMissing"
+
+	// EventConfigNotPropagating is the event reason utilized when the pgBackRest configuration
+	// has not propagated to the Pod used to create stanzas within the expected amount of time
+	EventConfigNotPropagating = "ConfigNotPropagating"
+
 	// EventRepoHostNotFound is used to indicate that a pgBackRest repository was not
 	// found when reconciling
 	EventRepoHostNotFound = "RepoDeploymentNotFound"
@@ -84,6 +150,14 @@ const (
 	// created
 	EventRepoHostCreated = "RepoHostCreated"
 
+	// EventRepoHostCheckFailed is the event reason utilized when the pgBackRest "check" command
+	// fails while determining the readiness of a pgBackRest repository host
+	EventRepoHostCheckFailed = "RepoHostCheckFailed"
+
+	// EventRepoHostRolloutDeferred is the event reason utilized when the rollout of changes to a
+	// pgBackRest repository host is deferred until a running backup completes
+	EventRepoHostRolloutDeferred = "RepoHostRolloutDeferred"
+
 	// EventUnableToCreateStanzas is the event reason utilized when pgBackRest is unable to create
 	// stanzas for the repositories in a PostgreSQL cluster
 	EventUnableToCreateStanzas = "UnableToCreateStanzas"
@@ -92,14 +166,123 @@ const (
 	// completes successfully
 	EventStanzasCreated = "StanzasCreated"
 
+	// EventStanzasAdopted is the event reason utilized when existing pgBackRest stanzas are
+	// adopted instead of being created
+	EventStanzasAdopted = "StanzasAdopted"
+
 	// EventUnableToCreatePGBackRestCronJob is the event reason utilized when a pgBackRest backup
 	// CronJob fails to create successfully
 	EventUnableToCreatePGBackRestCronJob = "UnableToCreatePGBackRestCronJob"
 
+	// EventPGBackRestCheckSucceeded is the event reason utilized when a scheduled pgBackRest
+	// check Job completes successfully
+	EventPGBackRestCheckSucceeded = "PGBackRestCheckSucceeded"
+
+	// EventPGBackRestCheckFailed is the event reason utilized when a scheduled pgBackRest check
+	// Job fails
+	EventPGBackRestCheckFailed = "PGBackRestCheckFailed"
+
+	// EventBackupStarted is the event reason utilized when a pgBackRest backup Job starts
+	EventBackupStarted = "BackupStarted"
+
+	// EventBackupCompleted is the event reason utilized when a pgBackRest backup Job completes
+	// successfully
+	EventBackupCompleted = "BackupCompleted"
+
+	// EventBackupFailed is the event reason utilized when a pgBackRest backup Job fails
+	EventBackupFailed = "BackupFailed"
+
+	// EventReplicaCreateRepoChanged is the event reason utilized when the replica creation repo
+	// (i.e. the first repo in the spec) has changed, which results in a new replica creation
+	// backup
+	EventReplicaCreateRepoChanged = "ReplicaCreateRepoChanged"
+
+	// EventInvalidRepoConfig is the event reason utilized when the configuration of an Azure,
+	// GCS or S3 repository is invalid, e.g. because the credentials needed to access the
+	// repository have not been provided
+	EventInvalidRepoConfig = "InvalidRepoConfig"
+
+	// EventInvalidRestoreTarget is the event reason utilized when the recovery target of a
+	// restore is invalid, e.g. because a time target is not formatted as a timestamp
+	EventInvalidRestoreTarget = "InvalidRestoreTarget"
+
+	// EventRepoInaccessible is the event reason utilized when the bucket or container of an
+	// Azure, GCS or S3 repository cannot be accessed, e.g. because it does not exist
+	EventRepoInaccessible = "RepoInaccessible"
+
+	// EventRepoDiskFull is the event reason utilized when a pgBackRest backup Job fails because
+	// the repository ran out of space
+	EventRepoDiskFull = "RepoDiskFull"
+
+	// EventRepoVolumeUnowned is the event reason utilized when an existing PersistentVolumeClaim
+	// for a pgBackRest repository is owned by another resource, and is therefore not used
+	EventRepoVolumeUnowned = "RepoVolumeUnowned"
+
+	// EventInvalidBackupSchedule is the event reason utilized when differential or incremental
+	// backups are scheduled for a repo without also scheduling full backups
+	EventInvalidBackupSchedule = "InvalidBackupSchedule"
+
+	// EventRepoVolumeRetained is the event reason utilized when the PersistentVolumeClaim for a
+	// pgBackRest repository that was removed from the spec is retained rather than deleted
+	EventRepoVolumeRetained = "RepoVolumeRetained"
+
+	// EventRepoVolumeArchived is the event reason utilized when the PersistentVolumeClaim for a
+	// pgBackRest repository that was removed from the spec is archived rather than deleted
+	EventRepoVolumeArchived = "RepoVolumeArchived"
+
+	// EventRepoVolumeRemovalBlocked is the event reason utilized when the PersistentVolumeClaims
+	// of pgBackRest repositories that were removed from the spec are first kept, since no other
+	// repository in the spec contains a stanza
+	EventRepoVolumeRemovalBlocked = "RepoVolumeRemovalBlocked"
+
+	// EventVersionMismatch is the event reason utilized when different versions of pgBackRest
+	// are installed within the containers running pgBackRest for the cluster
+	EventVersionMismatch = "VersionMismatch"
+
+	// EventDryRunChanges is the event reason utilized when the changes that pgBackRest cleanup
+	// would make during a dry run are first reported
+	EventDryRunChanges = "DryRunChanges"
+
+	// EventHostNamespacesNotAllowed is the event reason utilized when pgBackRest Pods that use
+	// the network, PID or IPC namespace of their node are first detected
+	EventHostNamespacesNotAllowed = "HostNamespacesNotAllowed"
+
+	// ReasonBackupSuspended is the reason utilized within ConditionReplicaCreate to indicate
+	// that the replica create backup is intentionally not running, e.g. because the cluster is
+	// shutdown or is a standby cluster.
+	ReasonBackupSuspended = "BackupSuspended"
+
+	// ReasonClusterNotBootstrapped is the reason utilized within ConditionReplicaCreate to
+	// indicate that the replica create backup is waiting for the cluster to be bootstrapped.
+	ReasonClusterNotBootstrapped = "ClusterNotBootstrapped"
+
+	// ReasonRepoNotReady is the reason utilized within ConditionReplicaCreate to indicate that
+	// the replica create backup is waiting for the replica create repo (or the dedicated
+	// repository host) to become ready.
+	ReasonRepoNotReady = "RepoNotReady"
+
+	// ReasonRepoHostNotStable is the reason utilized within ConditionRepoHostReady to indicate
+	// that the dedicated repository host pod is ready, but has not yet been ready for the minimum
+	// number of seconds configured in the spec.
+	ReasonRepoHostNotStable = "RepoHostNotStable"
+
 	// ReasonReadyForRestore is the reason utilized within ConditionPGBackRestRestoreProgressing
 	// to indicate that the restore Job can proceed because the cluster is now ready to be
 	// restored (i.e. it has been properly prepared for a restore).
 	ReasonReadyForRestore = "ReadyForRestore"
+
+	// configHashMismatchThreshold is the amount of time a pgBackRest config hash mismatch can
+	// persist before ConditionConfigHashMismatch indicates the configuration is not propagating
+	configHashMismatchThreshold = 5 * time.Minute
+
+	// backupWindowStartingDeadline is the deadline, in seconds, for starting a scheduled backup
+	// when backup windows are defined.  This ensures a backup scheduled while its CronJob is
+	// suspended outside of a window is skipped, rather than started once the window opens.
+	backupWindowStartingDeadline int64 = 60
+
+	// backupCountsInterval is how often the number of backups within each pgBackRest repo is
+	// refreshed when no backups have completed in the meantime
+	backupCountsInterval = time.Hour
 )
 
 // backup types
@@ -112,12 +295,37 @@ const (
 // regexRepoIndex is the regex used to obtain the repo index from a pgBackRest repo name
 var regexRepoIndex = regexp.MustCompile(`\d+`)
 
+// regexTargetTimeline is the regex used to validate the recovery target timeline of a restore,
+// which is either a positive integer, "current" or "latest"
+var regexTargetTimeline = regexp.MustCompile(`^([1-9][0-9]*|current|latest)$`)
+
+// regexBackupSet is the regex used to validate the label of the backup set to restore, i.e. the
+// label of a full backup, optionally followed by that of a differential or incremental backup
+var regexBackupSet = regexp.MustCompile(`^[0-9]{8}-[0-9]{6}F(_[0-9]{8}-[0-9]{6}[DI])?$`)
+
+// regexTargetTime is the regex used to validate a recovery target of type "time", i.e. a date and
+// time (separated by a space or "T") with optional fractional seconds, followed by an optional
+// time zone offset or abbreviation
+var regexTargetTime = regexp.MustCompile(
+	`^[0-9]{4}-[0-9]{2}-[0-9]{2}[ T][0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]+)?` +
+		`( ?(Z|[+-][0-9]{2}(:?[0-9]{2})?|[A-Za-z]{2,5}))?$`)
+
+// regexTargetLSN is the regex used to validate a recovery target of type "lsn"
+var regexTargetLSN = regexp.MustCompile(`^[0-9A-Fa-f]{1,8}/[0-9A-Fa-f]{1,8}$`)
+
+// regexTargetXID is the regex used to validate a recovery target of type "xid"
+var regexTargetXID = regexp.MustCompile(`^[1-9][0-9]*$`)
+
 // RepoResources is used to store various resources for pgBackRest repositories and
 // repository hosts
 type RepoResources struct {
+	checkJobs               []*batchv1.Job
 	cronjobs                []*batchv1beta1.CronJob
+	expireJobs              []*batchv1.Job
+	finalBackupJobs         []*batchv1.Job
 	manualBackupJobs        []*batchv1.Job
 	replicaCreateBackupJobs []*batchv1.Job
+	scheduledBackupJobs     []*batchv1.Job
 	hosts                   []*appsv1.StatefulSet
 	pvcs                    []*v1.PersistentVolumeClaim
 	sshConfig               *v1.ConfigMap
@@ -131,13 +339,26 @@ type RepoResources struct {
 // rollout of the pgBackRest repository host StatefulSet in accordance with its configured
 // strategy.
 func (r *Reconciler) applyRepoHostIntent(ctx context.Context, postgresCluster *v1beta1.PostgresCluster,
-	repoHostName string) (*appsv1.StatefulSet, error) {
+	repoHostName string, deferRollout bool, unownedRepos map[string]bool) (*appsv1.StatefulSet, error) {
 
-	repo, err := r.generateRepoHostIntent(postgresCluster, repoHostName)
+	repo, err := r.generateRepoHostIntent(postgresCluster, repoHostName, unownedRepos)
 	if err != nil {
 		return nil, err
 	}
 
+	// When deferring a rollout, set the partition of the rolling update to the number of
+	// replicas so that any changes to the Pod template are not yet applied to the repo host Pod.
+	// The partition is removed by the next apply once the rollout is no longer deferred.
+	// - https://docs.k8s.io/concepts/workloads/controllers/statefulset/#partitions
+	if deferRollout {
+		repo.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+				Partition: repo.Spec.Replicas,
+			},
+		}
+	}
+
 	if err := r.apply(ctx, repo); err != nil {
 		return nil, err
 	}
@@ -150,7 +371,9 @@ func (r *Reconciler) applyRepoHostIntent(ctx context.Context, postgresCluster *v
 // applyRepoVolumeIntent ensures the pgBackRest repository host deployment is synchronized with the
 // proper configuration according to the provided PostgresCluster custom resource.  This is done by
 // applying the PostgresCluster controller's fully specified intent for the PersistentVolumeClaim
-// representing a repository.
+// representing a repository.  Since repo volumes are applied concurrently, this function does not
+// modify the PostgresCluster provided, and any errors returned should be handled by the caller
+// using handlePersistentVolumeClaimError.
 func (r *Reconciler) applyRepoVolumeIntent(ctx context.Context,
 	postgresCluster *v1beta1.PostgresCluster, spec *v1.PersistentVolumeClaimSpec,
 	repoName string) (*v1.PersistentVolumeClaim, error) {
@@ -161,8 +384,7 @@ func (r *Reconciler) applyRepoVolumeIntent(ctx context.Context,
 	}
 
 	if err := r.apply(ctx, repo); err != nil {
-		return nil, r.handlePersistentVolumeClaimError(postgresCluster,
-			errors.WithStack(err))
+		return nil, errors.WithStack(err)
 	}
 
 	return repo, nil
@@ -204,7 +426,12 @@ func (r *Reconciler) getPGBackRestResources(ctx context.Context,
 		Kind:    "CronJobList",
 	}}
 
-	selector := naming.PGBackRestSelector(postgresCluster.GetName())
+	// stores the changes that would have been made during a dry run, which are reported once all
+	// resources have been collected
+	dryRun := pgBackRestDryRun(postgresCluster)
+	dryRunChanges := []string{}
+
+	selector := r.pgBackRestSelector(postgresCluster)
 	for _, gvk := range gvks {
 		uList := &unstructured.UnstructuredList{}
 		uList.SetGroupVersionKind(gvk)
@@ -230,24 +457,41 @@ func (r *Reconciler) getPGBackRestResources(ctx context.Context,
 			}
 		}
 
-		owned, err := r.cleanupRepoResources(ctx, postgresCluster, owned)
+		owned, changes, err := r.cleanupRepoResources(ctx, postgresCluster, owned)
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
+		dryRunChanges = append(dryRunChanges, changes...)
 		uList.Items = owned
 		if err := unstructuredToRepoResources(postgresCluster, gvk.Kind,
 			repoResources, uList); err != nil {
 			return nil, errors.WithStack(err)
 		}
 
-		// if the current objects are Jobs, update the status for the Jobs
-		// created by the pgBackRest scheduled backup CronJobs
+		// if the current objects are Jobs, cleanup any stale failed Jobs and then update the
+		// status for the Jobs created by the pgBackRest scheduled backup CronJobs (unless this
+		// is a dry run)
 		if gvk.Kind == "JobList" {
-			r.setScheduledJobStatus(ctx, postgresCluster, other)
+			other, changes, err = r.cleanupScheduledBackupJobs(ctx, postgresCluster, other)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			dryRunChanges = append(dryRunChanges, changes...)
+			if !dryRun {
+				r.setScheduledJobStatus(ctx, postgresCluster, other)
+			}
+			if err := unstructuredToRepoResources(postgresCluster, gvk.Kind, repoResources,
+				&unstructured.UnstructuredList{Items: other}); err != nil {
+				return nil, errors.WithStack(err)
+			}
 		}
 
 	}
 
+	r.setDryRunCondition(postgresCluster, dryRun, dryRunChanges)
+	r.setRepoVolumeRemovalBlockedCondition(postgresCluster, repoResources.pvcs)
+	r.setLatestBackupStatus(postgresCluster, repoResources)
+
 	return repoResources, nil
 }
 
@@ -258,10 +502,18 @@ func (r *Reconciler) getPGBackRestResources(ctx context.Context,
 // cleanupRepoResources cleans up pgBackRest repository resources that should no longer be
 // reconciled by deleting them.  This includes deleting repos (i.e. PersistentVolumeClaims) that
 // are no longer associated with any repository configured within the PostgresCluster spec, or any
-// pgBackRest repository host resources if a repository host is no longer configured.
+// pgBackRest repository host resources if a repository host is no longer configured.  During a dry
+// run these changes are only returned, so that they can be reported (see setDryRunCondition).
 func (r *Reconciler) cleanupRepoResources(ctx context.Context,
 	postgresCluster *v1beta1.PostgresCluster,
-	ownedResources []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	ownedResources []unstructured.Unstructured) ([]unstructured.Unstructured, []string, error) {
+
+	// stores the changes that would have been made during a dry run
+	dryRun := pgBackRestDryRun(postgresCluster)
+	dryRunChanges := []string{}
+	describe := func(action string, owned unstructured.Unstructured) string {
+		return fmt.Sprintf("%s %s %s", action, owned.GetKind(), owned.GetName())
+	}
 
 	// stores the resources that should not be deleted
 	ownedNoDelete := []unstructured.Unstructured{}
@@ -299,8 +551,6 @@ func (r *Reconciler) cleanupRepoResources(ctx context.Context,
 				delete = false
 			}
 		case hasLabel(naming.LabelPGBackRestRepoVolume):
-			// If a volume (PVC) is identified for a repo that no longer exists in the
-			// spec then delete it.  Otherwise add it to the slice and continue.
 			// If a volume (PVC) is identified for a repo that no longer exists in the
 			// spec then delete it.  Otherwise add it to the slice and continue.
 			for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
@@ -312,6 +562,35 @@ func (r *Reconciler) cleanupRepoResources(ctx context.Context,
 					delete = false
 				}
 			}
+			// When configured to archive or retain the volumes of removed repos, release the
+			// volume instead of deleting it.  Once released the volume is no longer owned by
+			// the cluster, so this happens (and is reported) just once.
+			if cleanup := postgresCluster.Spec.Backups.PGBackRest.Cleanup; delete && cleanup != nil {
+				if cleanup.ArchiveRepoVolumes != nil && *cleanup.ArchiveRepoVolumes {
+					if dryRun {
+						dryRunChanges = append(dryRunChanges, describe("archive", owned))
+					} else if err := r.archiveRepoVolume(ctx, postgresCluster,
+						&ownedResources[i], time.Now()); err != nil {
+						return []unstructured.Unstructured{}, nil, err
+					}
+					delete = false
+				} else if cleanup.RetainRepoVolumes != nil && *cleanup.RetainRepoVolumes {
+					if dryRun {
+						dryRunChanges = append(dryRunChanges, describe("retain", owned))
+					} else if err := r.releaseRepoVolume(ctx, postgresCluster,
+						&ownedResources[i]); err != nil {
+						return []unstructured.Unstructured{}, nil, err
+					}
+					delete = false
+				}
+			}
+			// Never delete the volume of a removed repo while no other repo has a stanza, e.g.
+			// when the only repo was renamed by mistake, unless the removal was confirmed.
+			// Such volumes are reported by setRepoVolumeRemovalBlockedCondition.
+			if delete && !repoVolumeRemovalAllowed(postgresCluster) {
+				ownedNoDelete = append(ownedNoDelete, owned)
+				delete = false
+			}
 		case hasLabel(naming.LabelPGBackRestBackup):
 			// If a Job is identified for a repo that no longer exists in the spec then
 			// delete it.  Otherwise add it to the slice and continue.
@@ -324,6 +603,7 @@ func (r *Reconciler) cleanupRepoResources(ctx context.Context,
 		case hasLabel(naming.LabelPGBackRestCronJob):
 			for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
 				if repo.Name == owned.GetLabels()[naming.LabelPGBackRestRepo] {
+					repo.BackupSchedules = r.backupSchedules(repo)
 					if backupScheduleFound(repo,
 						owned.GetLabels()[naming.LabelPGBackRestCronJob]) {
 						delete = false
@@ -332,6 +612,12 @@ func (r *Reconciler) cleanupRepoResources(ctx context.Context,
 					break
 				}
 			}
+		case hasLabel(naming.LabelPGBackRestCheck):
+			// The check CronJob is only kept while a check schedule is defined in the spec
+			if postgresCluster.Spec.Backups.PGBackRest.Check != nil {
+				ownedNoDelete = append(ownedNoDelete, owned)
+				delete = false
+			}
 		case hasLabel(naming.LabelPGBackRestRestore):
 			// When a cluster is prepared for restore, the system identifier is removed from status
 			// and the cluster is therefore no longer bootstrapped.  Only once the restore Job is
@@ -344,16 +630,279 @@ func (r *Reconciler) cleanupRepoResources(ctx context.Context,
 		}
 
 		// If nothing has specified that the resource should not be deleted, then delete
-		if delete {
-			if err := r.Client.Delete(ctx, &ownedResources[i],
-				client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
-				return []unstructured.Unstructured{}, errors.WithStack(err)
+		if delete && dryRun {
+			dryRunChanges = append(dryRunChanges, describe("delete", owned))
+		} else if delete {
+			if err := r.Client.Delete(ctx, &ownedResources[i], client.PropagationPolicy(
+				cleanupPropagationPolicy(postgresCluster, owned.GetLabels()))); err != nil {
+				return []unstructured.Unstructured{}, nil, errors.WithStack(err)
 			}
 		}
 	}
 
 	// return the remaining resources after properly cleaning up any that should no longer exist
-	return ownedNoDelete, nil
+	return ownedNoDelete, dryRunChanges, nil
+}
+
+// pgBackRestDryRun returns whether or not pgBackRest is reconciled in dry-run mode for the
+// PostgresCluster provided, i.e. whether it has the PGBackRestDryRun annotation.
+func pgBackRestDryRun(postgresCluster *v1beta1.PostgresCluster) bool {
+	_, dryRun := postgresCluster.GetAnnotations()[naming.PGBackRestDryRun]
+	return dryRun
+}
+
+// setDryRunCondition sets or removes ConditionDryRun according to whether or not pgBackRest is
+// reconciled in dry-run mode.  The condition's message lists the deletions, archives and retains
+// that cleanup would have made, and a Normal event is recorded each time those changes differ
+// from the ones previously reported.  Only cleanup is previewed: the resources that reconciling
+// the spec would create or update are not reported, since they are not reconciled at all during
+// a dry run.
+func (r *Reconciler) setDryRunCondition(postgresCluster *v1beta1.PostgresCluster,
+	dryRun bool, changes []string) {
+
+	if !dryRun {
+		// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+		if len(postgresCluster.Status.Conditions) > 0 {
+			meta.RemoveStatusCondition(&postgresCluster.Status.Conditions, ConditionDryRun)
+		}
+		return
+	}
+
+	condition := metav1.Condition{
+		ObservedGeneration: postgresCluster.GetGeneration(),
+		Type:               ConditionDryRun,
+		Status:             metav1.ConditionTrue,
+		Reason:             "NoChanges",
+		Message:            "pgBackRest cleanup would not delete, archive or retain any resources",
+	}
+	if len(changes) > 0 {
+		condition.Reason = EventDryRunChanges
+		condition.Message = "pgBackRest cleanup would " + strings.Join(changes, ", ")
+	}
+
+	existing := meta.FindStatusCondition(postgresCluster.Status.Conditions, ConditionDryRun)
+	if len(changes) > 0 && (existing == nil || existing.Message != condition.Message) {
+		r.Recorder.Event(postgresCluster, v1.EventTypeNormal, EventDryRunChanges,
+			condition.Message)
+	}
+
+	meta.SetStatusCondition(&postgresCluster.Status.Conditions, condition)
+}
+
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=patch
+
+// releaseRepoVolume removes the owner references of the repo volume (PVC) provided so that it is
+// retained after its repo has been removed from the spec, and then records an event confirming
+// the volume was retained.
+func (r *Reconciler) releaseRepoVolume(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, volume *unstructured.Unstructured) error {
+
+	before := volume.DeepCopy()
+	volume.SetOwnerReferences(nil)
+	if err := r.patch(ctx, volume, client.MergeFrom(before)); err != nil {
+		return errors.WithStack(client.IgnoreNotFound(err))
+	}
+
+	r.Recorder.Eventf(postgresCluster, v1.EventTypeNormal, EventRepoVolumeRetained,
+		"retained repo volume %s per retention policy", volume.GetName())
+	return nil
+}
+
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=patch
+
+// archiveRepoVolume releases the repo volume (PVC) provided in the same manner as
+// releaseRepoVolume, and also replaces the labels that select it as a pgBackRest resource of the
+// cluster with a label and annotations identifying it as an archived volume.  The volume is
+// therefore no longer managed by the cluster, but is retained for manual recovery and later
+// garbage collection.  An event is then recorded confirming the volume was archived.
+func (r *Reconciler) archiveRepoVolume(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, volume *unstructured.Unstructured,
+	now time.Time) error {
+
+	before := volume.DeepCopy()
+	volume.SetOwnerReferences(nil)
+
+	volumeLabels := volume.GetLabels()
+	if volumeLabels == nil {
+		volumeLabels = map[string]string{}
+	}
+	delete(volumeLabels, naming.LabelCluster)
+	delete(volumeLabels, naming.LabelPGBackRest)
+	delete(volumeLabels, naming.LabelPGBackRestRepoVolume)
+	volumeLabels[naming.LabelPGBackRestArchived] = ""
+	volume.SetLabels(volumeLabels)
+
+	volumeAnnotations := volume.GetAnnotations()
+	if volumeAnnotations == nil {
+		volumeAnnotations = map[string]string{}
+	}
+	volumeAnnotations[naming.PGBackRestArchivedCluster] = postgresCluster.GetName()
+	volumeAnnotations[naming.PGBackRestArchivedTime] = now.UTC().Format(time.RFC3339)
+	volume.SetAnnotations(volumeAnnotations)
+
+	if err := r.patch(ctx, volume, client.MergeFrom(before)); err != nil {
+		return errors.WithStack(client.IgnoreNotFound(err))
+	}
+
+	r.Recorder.Eventf(postgresCluster, v1.EventTypeNormal, EventRepoVolumeArchived,
+		"archived repo volume %s per cleanup policy", volume.GetName())
+	return nil
+}
+
+// repoVolumeRemovalAllowed returns whether or not the volumes of repos removed from the spec of
+// the PostgresCluster provided can be deleted, i.e. whether a repo remaining in the spec already
+// contains a stanza (and therefore backups can still be taken), or the removal was confirmed
+// using the PGBackRestConfirmRepoRemoval annotation.  The volumes are never deleted when no repo
+// remains in the spec, even when confirmed, since the cluster would then have no repo at all.
+func repoVolumeRemovalAllowed(postgresCluster *v1beta1.PostgresCluster) bool {
+	if len(postgresCluster.Spec.Backups.PGBackRest.Repos) == 0 {
+		return false
+	}
+
+	annotations := postgresCluster.GetAnnotations()
+	if _, confirmed := annotations[naming.PGBackRestConfirmRepoRemoval]; confirmed {
+		return true
+	}
+	if postgresCluster.Status.PGBackRest == nil {
+		return false
+	}
+
+	for _, status := range postgresCluster.Status.PGBackRest.Repos {
+		if !status.StanzaCreated {
+			continue
+		}
+		for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+			if repo.Name == status.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// setRepoVolumeRemovalBlockedCondition sets the RepoVolumeRemovalBlocked condition whenever any
+// of the repo volumes (PVCs) provided, i.e. those remaining after cleanup, belongs to a repo
+// that is no longer in the spec, since cleanup then kept the volume per
+// repoVolumeRemovalAllowed.  The condition is removed once no such volumes remain.  An event is
+// recorded whenever the condition changes.
+func (r *Reconciler) setRepoVolumeRemovalBlockedCondition(
+	postgresCluster *v1beta1.PostgresCluster, pvcs []*v1.PersistentVolumeClaim) {
+
+	var blocked []string
+	for _, pvc := range pvcs {
+		if _, ok := pvc.GetLabels()[naming.LabelPGBackRestRepoVolume]; !ok {
+			continue
+		}
+		removed := true
+		for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+			if repo.Volume != nil && repo.Name == pvc.GetLabels()[naming.LabelPGBackRestRepo] {
+				removed = false
+			}
+		}
+		if removed {
+			blocked = append(blocked, pvc.GetName())
+		}
+	}
+
+	if len(blocked) == 0 {
+		// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+		if len(postgresCluster.Status.Conditions) > 0 {
+			meta.RemoveStatusCondition(&postgresCluster.Status.Conditions,
+				ConditionRepoVolumeRemovalBlocked)
+		}
+		return
+	}
+
+	sort.Strings(blocked)
+	message := fmt.Sprintf("pgBackRest repository volume(s) %s were not deleted since no "+
+		"remaining repo has a stanza; annotate with %s to delete them anyway",
+		strings.Join(blocked, ", "), naming.PGBackRestConfirmRepoRemoval)
+
+	condition := meta.FindStatusCondition(postgresCluster.Status.Conditions,
+		ConditionRepoVolumeRemovalBlocked)
+	if condition == nil || condition.Message != message {
+		r.Recorder.Event(postgresCluster, v1.EventTypeWarning, EventRepoVolumeRemovalBlocked,
+			message)
+	}
+	meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
+		ObservedGeneration: postgresCluster.GetGeneration(),
+		Type:               ConditionRepoVolumeRemovalBlocked,
+		Status:             metav1.ConditionTrue,
+		Reason:             EventRepoVolumeRemovalBlocked,
+		Message:            message,
+	})
+}
+
+// cleanupPropagationPolicy returns the propagation policy to use when deleting the pgBackRest
+// resource with the labels provided, as configured for its type of resource in the
+// PostgresCluster spec.  Defaults to "Background".
+func cleanupPropagationPolicy(postgresCluster *v1beta1.PostgresCluster,
+	resourceLabels map[string]string) metav1.DeletionPropagation {
+
+	policy := metav1.DeletePropagationBackground
+	cleanup := postgresCluster.Spec.Backups.PGBackRest.Cleanup
+	if cleanup == nil {
+		return policy
+	}
+
+	hasLabel := func(label string) bool { _, ok := resourceLabels[label]; return ok }
+
+	var configured metav1.DeletionPropagation
+	switch {
+	case hasLabel(naming.LabelPGBackRestDedicated), hasLabel(naming.LabelPGBackRestRepoHost):
+		configured = cleanup.RepoHost
+	case hasLabel(naming.LabelPGBackRestRepoVolume):
+		configured = cleanup.RepoVolumes
+	case hasLabel(naming.LabelPGBackRestBackup), hasLabel(naming.LabelPGBackRestCronJob),
+		hasLabel(naming.LabelPGBackRestCheck), hasLabel(naming.LabelPGBackRestRestore):
+		configured = cleanup.Jobs
+	}
+	if configured != "" {
+		policy = configured
+	}
+
+	return policy
+}
+
+// pgBackRestSelector returns the selector used to collect the pgBackRest resources for the
+// PostgresCluster provided, which includes any additional labels required by the Reconciler.
+func (r *Reconciler) pgBackRestSelector(postgresCluster *v1beta1.PostgresCluster) labels.Selector {
+	return labels.Merge(r.PGBackRestSelectorLabels,
+		naming.PGBackRestLabels(postgresCluster.GetName())).AsSelector()
+}
+
+// backupSchedules returns the backup schedules for the repo provided.  The operator's default
+// backup schedules are returned when the repo does not define any schedules, i.e. defining
+// schedules for a repo (even if empty) overrides the defaults.
+func (r *Reconciler) backupSchedules(
+	repo v1beta1.PGBackRestRepo) *v1beta1.PGBackRestBackupSchedules {
+	if repo.BackupSchedules != nil {
+		return repo.BackupSchedules
+	}
+	return r.DefaultBackupSchedules
+}
+
+// backupJobs returns all of the backup Jobs within the RepoResources provided, regardless of
+// the type of backup.  This includes expire Jobs, since they cannot run alongside a backup.
+func backupJobs(repoResources *RepoResources) []*batchv1.Job {
+	var jobs []*batchv1.Job
+	jobs = append(jobs, repoResources.expireJobs...)
+	jobs = append(jobs, repoResources.finalBackupJobs...)
+	jobs = append(jobs, repoResources.manualBackupJobs...)
+	jobs = append(jobs, repoResources.replicaCreateBackupJobs...)
+	jobs = append(jobs, repoResources.scheduledBackupJobs...)
+	return jobs
+}
+
+// backupInProgress returns true if any of the backup Jobs within the RepoResources provided are
+// currently running, otherwise it returns false.
+func backupInProgress(repoResources *RepoResources) bool {
+	for _, job := range backupJobs(repoResources) {
+		if jobActive(job) > 0 {
+			return true
+		}
+	}
+	return false
 }
 
 // backupScheduleFound returns true if the CronJob in question should be created as
@@ -399,15 +948,34 @@ func unstructuredToRepoResources(postgresCluster *v1beta1.PostgresCluster, kind
 			FromUnstructured(uList.UnstructuredContent(), &jobList); err != nil {
 			return errors.WithStack(err)
 		}
-		// we care about replica create backup jobs and manual backup jobs
+		// we care about replica create backup jobs, manual backup jobs, final backup jobs,
+		// scheduled backup jobs, expire jobs and check jobs
 		for i, job := range jobList.Items {
-			switch job.GetLabels()[naming.LabelPGBackRestBackup] {
+			if _, ok := job.GetLabels()[naming.LabelPGBackRestCheck]; ok {
+				repoResources.checkJobs = append(repoResources.checkJobs, &jobList.Items[i])
+				continue
+			}
+			backupType := job.GetLabels()[naming.LabelPGBackRestBackup]
+			// scheduled backup jobs created prior to the backup label being added to the
+			// CronJob template are identified using the CronJob label instead
+			if backupType == "" && job.GetLabels()[naming.LabelPGBackRestCronJob] != "" {
+				backupType = string(naming.BackupScheduled)
+			}
+			switch backupType {
 			case string(naming.BackupReplicaCreate):
 				repoResources.replicaCreateBackupJobs =
 					append(repoResources.replicaCreateBackupJobs, &jobList.Items[i])
 			case string(naming.BackupManual):
 				repoResources.manualBackupJobs =
 					append(repoResources.manualBackupJobs, &jobList.Items[i])
+			case string(naming.BackupFinal):
+				repoResources.finalBackupJobs =
+					append(repoResources.finalBackupJobs, &jobList.Items[i])
+			case string(naming.BackupScheduled):
+				repoResources.scheduledBackupJobs =
+					append(repoResources.scheduledBackupJobs, &jobList.Items[i])
+			case string(naming.BackupExpire):
+				repoResources.expireJobs = append(repoResources.expireJobs, &jobList.Items[i])
 			}
 		}
 	case "PersistentVolumeClaimList":
@@ -484,14 +1052,16 @@ func (r *Reconciler) setScheduledJobStatus(ctx context.Context,
 		// associated CronJobs
 		sbs := v1beta1.PGBackRestScheduledBackupStatus{}
 		if job.GetLabels()[naming.LabelPGBackRestCronJob] != "" {
-			if len(job.OwnerReferences) > 0 {
+			// backups taken in repos that follow the schedules of another repo are not
+			// created by a CronJob
+			if len(job.OwnerReferences) > 0 && job.OwnerReferences[0].Kind == "CronJob" {
 				sbs.CronJobName = job.OwnerReferences[0].Name
 			}
 			sbs.RepoName = job.GetLabels()[naming.LabelPGBackRestRepo]
 			sbs.Type = job.GetLabels()[naming.LabelPGBackRestCronJob]
 			sbs.StartTime = job.Status.StartTime
 			sbs.CompletionTime = job.Status.CompletionTime
-			sbs.Active = job.Status.Active
+			sbs.Active = jobActive(&job)
 			sbs.Succeeded = job.Status.Succeeded
 			sbs.Failed = job.Status.Failed
 
@@ -506,96 +1076,669 @@ func (r *Reconciler) setScheduledJobStatus(ctx context.Context,
 	postgresCluster.Status.PGBackRest.ScheduledBackups = scheduledStatus
 }
 
-// generateRepoHostIntent creates and populates StatefulSet with the PostgresCluster's full intent
-// as needed to create and reconcile a pgBackRest dedicated repository host within the kubernetes
-// cluster.
-func (r *Reconciler) generateRepoHostIntent(postgresCluster *v1beta1.PostgresCluster,
-	repoHostName string) (*appsv1.StatefulSet, error) {
-
-	annotations := naming.Merge(
-		postgresCluster.Spec.Metadata.GetAnnotationsOrNil(),
-		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil())
-	labels := naming.Merge(
-		postgresCluster.Spec.Metadata.GetLabelsOrNil(),
-		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
-		naming.PGBackRestDedicatedLabels(postgresCluster.GetName()),
-	)
-
-	repo := &appsv1.StatefulSet{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: appsv1.SchemeGroupVersion.String(),
-			Kind:       "StatefulSet",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        repoHostName,
-			Namespace:   postgresCluster.GetNamespace(),
-			Labels:      labels,
-			Annotations: annotations,
-		},
-		Spec: appsv1.StatefulSetSpec{
-			Selector: &metav1.LabelSelector{
-				MatchLabels: naming.PGBackRestDedicatedLabels(postgresCluster.GetName()),
-			},
-			ServiceName: naming.ClusterPodService(postgresCluster).Name,
-			Template: v1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels:      labels,
-					Annotations: annotations,
-				},
-				Spec: v1.PodSpec{
-					Affinity:    postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.Affinity,
-					Tolerations: postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.Tolerations,
-				},
-			},
-		},
+// setLatestBackupStatus updates the pgBackRest status with the most recent backup Job of each
+// type (i.e. manual, replica create, final and scheduled backup Jobs).  Events are recorded as
+// each of these Jobs starts and finishes, providing an audit trail of all backups.
+func (r *Reconciler) setLatestBackupStatus(postgresCluster *v1beta1.PostgresCluster,
+	repoResources *RepoResources) {
+
+	// the previous status for each backup type, used to detect Jobs starting and finishing
+	previous := map[string]v1beta1.PGBackRestLatestBackupStatus{}
+	if postgresCluster.Status.PGBackRest != nil {
+		for _, backup := range postgresCluster.Status.PGBackRest.LatestBackups {
+			previous[backup.Type] = backup
+		}
 	}
 
-	// Set the image pull secrets, if any exist.
-	// This is set here rather than using the service account due to the lack
-	// of propagation to existing pods when the CRD is updated:
-	// https://github.com/kubernetes/kubernetes/issues/88456
-	repo.Spec.Template.Spec.ImagePullSecrets = postgresCluster.Spec.ImagePullSecrets
+	latestBackups := []v1beta1.PGBackRestLatestBackupStatus{}
+	for _, backups := range []struct {
+		backupType naming.BackupJobType
+		jobs       []*batchv1.Job
+	}{
+		{naming.BackupManual, repoResources.manualBackupJobs},
+		{naming.BackupReplicaCreate, repoResources.replicaCreateBackupJobs},
+		{naming.BackupFinal, repoResources.finalBackupJobs},
+		{naming.BackupScheduled, repoResources.scheduledBackupJobs},
+	} {
+		var latest *batchv1.Job
+		for _, job := range backups.jobs {
+			if latest == nil || latest.CreationTimestamp.Before(&job.CreationTimestamp) {
+				latest = job
+			}
+		}
+		if latest == nil {
+			// retain the status of a finished backup once its Job has been removed, e.g. since
+			// the replica create backup Job is deleted once the backup has completed
+			if prev, found := previous[string(backups.backupType)]; found && prev.Finished {
+				latestBackups = append(latestBackups, prev)
+			}
+			continue
+		}
+		backup := v1beta1.PGBackRestLatestBackupStatus{
+			Type:           string(backups.backupType),
+			JobName:        latest.GetName(),
+			RepoName:       latest.GetLabels()[naming.LabelPGBackRestRepo],
+			StartTime:      latest.Status.StartTime,
+			CompletionTime: latest.Status.CompletionTime,
+			Active:         jobActive(latest),
+			Succeeded:      latest.Status.Succeeded,
+			Failed:         latest.Status.Failed,
+			Finished:       jobCompleted(latest) || jobFailed(latest),
+			BackupSet:      latest.GetAnnotations()[naming.PGBackRestBackupSet],
+		}
+		setBackupMetrics(&backup, latest)
+		latestBackups = append(latestBackups, backup)
 
-	podSecurityContext := initialize.RestrictedPodSecurityContext()
-	podSecurityContext.SupplementalGroups = []int64{65534}
+		prev, found := previous[backup.Type]
+		if !found || prev.JobName != backup.JobName {
+			prev = v1beta1.PGBackRestLatestBackupStatus{}
+		}
+		r.recordBackupEvents(postgresCluster, latest, prev, backup)
+	}
 
-	// if the cluster is set to be shutdown, stop repohost pod
-	if postgresCluster.Spec.Shutdown != nil && *postgresCluster.Spec.Shutdown {
-		repo.Spec.Replicas = initialize.Int32(0)
-	} else {
-		// the cluster should not be shutdown, set this value to 1
-		repo.Spec.Replicas = initialize.Int32(1)
+	// if nil, create the pgBackRest status
+	if postgresCluster.Status.PGBackRest == nil {
+		postgresCluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
 	}
+	postgresCluster.Status.PGBackRest.LatestBackups = latestBackups
+}
 
-	// set fsGroups if not OpenShift
-	if postgresCluster.Spec.OpenShift == nil || !*postgresCluster.Spec.OpenShift {
-		podSecurityContext.FSGroup = initialize.Int64(26)
+// setBackupMetrics sets the duration of the successfully completed backup Job provided within the
+// status of its backup.  Once the size of the backup set created by the Job is known, the size
+// and effective throughput of the backup are then set as well.
+func setBackupMetrics(backup *v1beta1.PGBackRestLatestBackupStatus, job *batchv1.Job) {
+	if job.Status.StartTime == nil || job.Status.CompletionTime == nil {
+		return
 	}
-	repo.Spec.Template.Spec.SecurityContext = podSecurityContext
+	duration := job.Status.CompletionTime.Sub(job.Status.StartTime.Time)
+	backup.DurationSeconds = int64(duration.Seconds())
 
-	// add ssh pod info
-	if err := pgbackrest.AddSSHToPod(postgresCluster, &repo.Spec.Template, true,
-		postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.Resources); err != nil {
-		return nil, errors.WithStack(err)
+	size, err := strconv.ParseInt(job.GetAnnotations()[naming.PGBackRestBackupSize], 10, 64)
+	if err != nil || size <= 0 {
+		return
 	}
-	if err := pgbackrest.AddRepoVolumesToPod(postgresCluster, &repo.Spec.Template,
-		naming.PGBackRestRepoContainerName); err != nil {
-		return nil, errors.WithStack(err)
+	backup.BackupSizeBytes = size
+
+	// the times of the Job are only precise to the second, so very short backups are treated
+	// as taking a full second
+	if duration < time.Second {
+		duration = time.Second
 	}
-	// add configs to pod
-	if err := pgbackrest.AddConfigsToPod(postgresCluster, &repo.Spec.Template,
-		pgbackrest.CMRepoKey, naming.PGBackRestRepoContainerName); err != nil {
-		return nil, errors.WithStack(err)
+	backup.ThroughputBytesPerSecond = int64(float64(size) / duration.Seconds())
+}
+
+// setLastBackupStatus updates the status of each repository with the completion time and type of
+// the most recent backup Job that completed successfully for that repository.  The status is only
+// updated when a more recent backup is found, so that it is retained once backup Jobs have been
+// removed (e.g. the replica create backup Job, which is deleted once complete).
+func setLastBackupStatus(postgresCluster *v1beta1.PostgresCluster, repoResources *RepoResources) {
+	if postgresCluster.Status.PGBackRest == nil {
+		return
 	}
 
-	// add nss_wrapper init container and add nss_wrapper env vars to the pgbackrest
-	// container
-	addNSSWrapper(postgresCluster.Spec.Backups.PGBackRest.Image, &repo.Spec.Template)
-	addTMPEmptyDir(&repo.Spec.Template)
+	jobs := []*batchv1.Job{}
+	jobs = append(jobs, repoResources.finalBackupJobs...)
+	jobs = append(jobs, repoResources.manualBackupJobs...)
+	jobs = append(jobs, repoResources.replicaCreateBackupJobs...)
+	jobs = append(jobs, repoResources.scheduledBackupJobs...)
 
-	// set ownership references
-	if err := controllerutil.SetControllerReference(postgresCluster, repo,
-		r.Client.Scheme()); err != nil {
+	for _, job := range jobs {
+		if !jobCompleted(job) || job.Status.CompletionTime == nil {
+			continue
+		}
+		for i := range postgresCluster.Status.PGBackRest.Repos {
+			repoStatus := &postgresCluster.Status.PGBackRest.Repos[i]
+			if repoStatus.Name != job.GetLabels()[naming.LabelPGBackRestRepo] {
+				continue
+			}
+			if repoStatus.LastBackupTime == nil ||
+				repoStatus.LastBackupTime.Before(job.Status.CompletionTime) {
+				repoStatus.LastBackupTime = job.Status.CompletionTime.DeepCopy()
+				repoStatus.LastBackupType = completedBackupType(job)
+			}
+			break
+		}
+	}
+}
+
+// completedBackupType returns the type of backup (i.e. "full", "diff" or "incr") taken by the
+// completed backup Job provided.  The type is determined using the backup type label of the Job,
+// or otherwise using the suffix of the label of the backup set created by the Job.  Nothing is
+// returned when the type cannot be determined.
+func completedBackupType(job *batchv1.Job) string {
+	if backupType := job.GetLabels()[naming.LabelPGBackRestBackupType]; backupType != "" {
+		return backupType
+	}
+	if set := job.GetAnnotations()[naming.PGBackRestBackupSet]; set != "" {
+		switch set[len(set)-1] {
+		case 'F':
+			return full
+		case 'D':
+			return differential
+		case 'I':
+			return incremental
+		}
+	}
+	return ""
+}
+
+// recordBackupEvents records events for a backup Job that has started or finished since its
+// previous status was observed.  Each event includes structured details about the backup,
+// i.e. its ID, type, repository and (once finished) duration.
+func (r *Reconciler) recordBackupEvents(postgresCluster *v1beta1.PostgresCluster,
+	job *batchv1.Job, previous, current v1beta1.PGBackRestLatestBackupStatus) {
+
+	// manual backups are identified using the ID provided via annotation, while all other
+	// backups are identified using the name of the Job
+	id := job.GetAnnotations()[naming.PGBackRestBackup]
+	if id == "" {
+		id = job.GetName()
+	}
+	details := fmt.Sprintf("id=%s type=%s repo=%s job=%s",
+		id, current.Type, current.RepoName, job.GetName())
+
+	if previous.StartTime == nil && current.StartTime != nil {
+		r.Recorder.Eventf(postgresCluster, v1.EventTypeNormal, EventBackupStarted,
+			"pgBackRest backup started: %s", details)
+	}
+	if previous.Finished || !current.Finished {
+		return
+	}
+	if current.StartTime != nil {
+		end := metav1.Now()
+		if current.CompletionTime != nil {
+			end = *current.CompletionTime
+		}
+		details += " duration=" + end.Sub(current.StartTime.Time).Round(time.Second).String()
+	}
+	if jobCompleted(job) {
+		r.Recorder.Eventf(postgresCluster, v1.EventTypeNormal, EventBackupCompleted,
+			"pgBackRest backup completed: %s", details)
+	} else {
+		r.Recorder.Eventf(postgresCluster, v1.EventTypeWarning, EventBackupFailed,
+			"pgBackRest backup failed: %s", details)
+	}
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list
+
+// reconcileHostNamespaces sets the HostNamespaces condition whenever pgBackRest Pods (i.e. the
+// dedicated repository host and any backup or restore Jobs) are running within the network, PID
+// or IPC namespace of their node.  The operator never requests these namespaces (see
+// disableHostNamespaces), so any such Pod was modified by something else, e.g. a mutating
+// admission webhook, and must be addressed there.  The condition is removed once no such Pods
+// remain.  An event is recorded whenever the condition changes.
+func (r *Reconciler) reconcileHostNamespaces(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster) error {
+
+	pods := &v1.PodList{}
+	if err := r.Client.List(ctx, pods, client.InNamespace(postgresCluster.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: naming.PGBackRestSelector(
+			postgresCluster.GetName())}); err != nil {
+		return errors.WithStack(err)
+	}
+
+	var elevated []string
+	for i := range pods.Items {
+		if usesHostNamespaces(&pods.Items[i]) {
+			elevated = append(elevated, pods.Items[i].GetName())
+		}
+	}
+
+	if len(elevated) == 0 {
+		// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+		if len(postgresCluster.Status.Conditions) > 0 {
+			meta.RemoveStatusCondition(&postgresCluster.Status.Conditions,
+				ConditionHostNamespaces)
+		}
+		return nil
+	}
+
+	sort.Strings(elevated)
+	message := fmt.Sprintf("pgBackRest Pod(s) %s use the network, PID or IPC namespace of "+
+		"their node, which is not allowed; remove whatever adds hostNetwork, hostPID or hostIPC "+
+		"to these Pods", strings.Join(elevated, ", "))
+
+	condition := meta.FindStatusCondition(postgresCluster.Status.Conditions,
+		ConditionHostNamespaces)
+	if condition == nil || condition.Message != message {
+		r.Recorder.Event(postgresCluster, v1.EventTypeWarning, EventHostNamespacesNotAllowed,
+			message)
+	}
+	meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
+		ObservedGeneration: postgresCluster.GetGeneration(),
+		Type:               ConditionHostNamespaces,
+		Status:             metav1.ConditionTrue,
+		Reason:             EventHostNamespacesNotAllowed,
+		Message:            message,
+	})
+
+	return nil
+}
+
+// disableHostNamespaces ensures the Pod spec provided never uses the network, PID or IPC
+// namespace of its node, regardless of any other configuration for the Pod.
+func disableHostNamespaces(spec *v1.PodSpec) {
+	spec.HostNetwork = false
+	spec.HostPID = false
+	spec.HostIPC = false
+}
+
+// usesHostNamespaces returns whether or not the Pod provided uses the network, PID or IPC
+// namespace of its node.
+func usesHostNamespaces(pod *v1.Pod) bool {
+	return pod.Spec.HostNetwork || pod.Spec.HostPID || pod.Spec.HostIPC
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list
+
+// reconcileRepoDiskFull sets the RepoDiskFull condition whenever the most recent backup to a
+// pgBackRest repository failed because the repository ran out of space, as determined using
+// the output of the failed backup Job.  The condition is removed once a subsequent backup to
+// each such repository succeeds.  An event is recorded whenever the condition changes.
+func (r *Reconciler) reconcileRepoDiskFull(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster) error {
+
+	latestBackups := postgresCluster.Status.PGBackRest.LatestBackups
+
+	full := map[string]v1beta1.PGBackRestLatestBackupStatus{}
+	for _, backup := range latestBackups {
+		if !backup.Finished || backup.Succeeded > 0 {
+			continue
+		}
+		pods := &v1.PodList{}
+		if err := r.Client.List(ctx, pods, client.InNamespace(postgresCluster.GetNamespace()),
+			client.MatchingLabels{"job-name": backup.JobName}); err != nil {
+			return errors.WithStack(err)
+		}
+		if backupDiskFull(pods.Items) {
+			full[backup.RepoName] = backup
+		}
+	}
+
+	// a repository is no longer full once a subsequent backup to it succeeds
+	for _, backup := range latestBackups {
+		failed, ok := full[backup.RepoName]
+		if ok && backup.Succeeded > 0 && backup.CompletionTime != nil &&
+			failed.StartTime != nil && failed.StartTime.Before(backup.CompletionTime) {
+			delete(full, backup.RepoName)
+		}
+	}
+
+	if len(full) == 0 {
+		// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+		if len(postgresCluster.Status.Conditions) > 0 {
+			meta.RemoveStatusCondition(&postgresCluster.Status.Conditions, ConditionRepoDiskFull)
+		}
+		return nil
+	}
+
+	repoNames := make([]string, 0, len(full))
+	for repoName := range full {
+		repoNames = append(repoNames, repoName)
+	}
+	sort.Strings(repoNames)
+	messages := make([]string, 0, len(repoNames))
+	for _, repoName := range repoNames {
+		messages = append(messages, fmt.Sprintf("repo %s ran out of space during backup Job %s",
+			repoName, full[repoName].JobName))
+	}
+	message := "pgBackRest " + strings.Join(messages, "; ")
+
+	condition := meta.FindStatusCondition(postgresCluster.Status.Conditions,
+		ConditionRepoDiskFull)
+	if condition == nil || condition.Message != message {
+		r.Recorder.Event(postgresCluster, v1.EventTypeWarning, EventRepoDiskFull, message)
+	}
+	meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
+		ObservedGeneration: postgresCluster.GetGeneration(),
+		Type:               ConditionRepoDiskFull,
+		Status:             metav1.ConditionTrue,
+		Reason:             EventRepoDiskFull,
+		Message:            message,
+	})
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get
+
+// reconcileRepoVolumeOwnership sets the RepoVolumeUnowned condition whenever an existing
+// PersistentVolumeClaim for a pgBackRest repository is not owned by the PostgresCluster, e.g.
+// because it was created for another PostgresCluster.  The names of the repos with such a
+// volume are returned, and their volumes must then not be mounted (or adopted) to ensure two
+// clusters never write to the same repository.  The condition is removed once every existing
+// repository volume is owned by the PostgresCluster.  An event is recorded whenever the
+// condition changes.
+func (r *Reconciler) reconcileRepoVolumeOwnership(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster) (map[string]bool, error) {
+
+	unownedRepos := map[string]bool{}
+	var unowned []string
+	for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+		if repo.Volume == nil {
+			continue
+		}
+		pvc := &v1.PersistentVolumeClaim{
+			ObjectMeta: naming.PGBackRestRepoVolume(postgresCluster, repo.Name),
+		}
+		err := r.Client.Get(ctx, client.ObjectKeyFromObject(pvc), pvc)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if !repoVolumeOwned(postgresCluster, pvc) {
+			unownedRepos[repo.Name] = true
+			unowned = append(unowned, pvc.GetName())
+		}
+	}
+
+	if len(unowned) == 0 {
+		// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+		if len(postgresCluster.Status.Conditions) > 0 {
+			meta.RemoveStatusCondition(&postgresCluster.Status.Conditions,
+				ConditionRepoVolumeUnowned)
+		}
+		return unownedRepos, nil
+	}
+
+	message := fmt.Sprintf("pgBackRest repository volume(s) %s are not owned by this "+
+		"PostgresCluster and are not mounted; remove them, or annotate them with %s=%s to "+
+		"adopt them", strings.Join(unowned, ", "), naming.PGBackRestAdoptRepoVolume,
+		postgresCluster.GetName())
+
+	condition := meta.FindStatusCondition(postgresCluster.Status.Conditions,
+		ConditionRepoVolumeUnowned)
+	if condition == nil || condition.Message != message {
+		r.Recorder.Event(postgresCluster, v1.EventTypeWarning, EventRepoVolumeUnowned, message)
+	}
+	meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
+		ObservedGeneration: postgresCluster.GetGeneration(),
+		Type:               ConditionRepoVolumeUnowned,
+		Status:             metav1.ConditionTrue,
+		Reason:             EventRepoVolumeUnowned,
+		Message:            message,
+	})
+
+	return unownedRepos, nil
+}
+
+// repoVolumeOwned determines whether or not the provided repository PersistentVolumeClaim can be
+// used by the PostgresCluster.  This is the case when the PostgresCluster is its controller, or
+// when it has no controller and has been explicitly annotated for adoption by the
+// PostgresCluster.  Labels alone are not enough, since volumes released by a cluster (e.g. those
+// retained when a repo is removed) keep the labels of that cluster.
+func repoVolumeOwned(postgresCluster *v1beta1.PostgresCluster,
+	pvc *v1.PersistentVolumeClaim) bool {
+
+	if controllerRef := metav1.GetControllerOfNoCopy(pvc); controllerRef != nil {
+		return controllerRef.UID == postgresCluster.GetUID()
+	}
+	return pvc.GetAnnotations()[naming.PGBackRestAdoptRepoVolume] == postgresCluster.GetName()
+}
+
+// removeRepoVolumesFromPod removes the volumes (and volume mounts) of the repos provided from
+// the Pod template spec, e.g. to ensure repository volumes not owned by the PostgresCluster are
+// never mounted.
+func removeRepoVolumesFromPod(template *v1.PodTemplateSpec, repoNames map[string]bool) {
+	volumes := []v1.Volume{}
+	for _, volume := range template.Spec.Volumes {
+		if !repoNames[volume.Name] {
+			volumes = append(volumes, volume)
+		}
+	}
+	template.Spec.Volumes = volumes
+
+	for i := range template.Spec.Containers {
+		mounts := []v1.VolumeMount{}
+		for _, mount := range template.Spec.Containers[i].VolumeMounts {
+			if !repoNames[mount.Name] {
+				mounts = append(mounts, mount)
+			}
+		}
+		template.Spec.Containers[i].VolumeMounts = mounts
+	}
+}
+
+// withoutRepos returns a copy of the PostgresCluster provided without the repos provided, e.g. to
+// generate pgBackRest configuration that never writes to repositories not owned by the cluster.
+// The PostgresCluster itself is returned when there are no repos to remove.
+func withoutRepos(postgresCluster *v1beta1.PostgresCluster,
+	repoNames map[string]bool) *v1beta1.PostgresCluster {
+
+	if len(repoNames) == 0 {
+		return postgresCluster
+	}
+
+	cluster := postgresCluster.DeepCopy()
+	repos := []v1beta1.PGBackRestRepo{}
+	for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
+		if !repoNames[repo.Name] {
+			repos = append(repos, repo)
+		}
+	}
+	cluster.Spec.Backups.PGBackRest.Repos = repos
+
+	return cluster
+}
+
+// backupDiskFull determines whether or not any container of the provided backup Job Pods
+// failed because a pgBackRest repository ran out of space, i.e. with an ENOSPC error
+func backupDiskFull(pods []v1.Pod) bool {
+	for _, pod := range pods {
+		statuses := append(append([]v1.ContainerStatus{},
+			pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			for _, terminated := range []*v1.ContainerStateTerminated{
+				status.State.Terminated, status.LastTerminationState.Terminated,
+			} {
+				if terminated != nil && terminated.ExitCode != 0 &&
+					strings.Contains(terminated.Message, "No space left on device") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=delete
+
+// cleanupScheduledBackupJobs deletes any failed Jobs created by the pgBackRest scheduled backup
+// CronJobs that are no longer relevant, i.e. all failed Jobs other than the most recent Job
+// created by each CronJob.  The remaining Jobs are returned.  During a dry run the deletions are
+// only returned as changes that would have been made.
+func (r *Reconciler) cleanupScheduledBackupJobs(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster,
+	items []unstructured.Unstructured) ([]unstructured.Unstructured, []string, error) {
+
+	uList := &unstructured.UnstructuredList{Items: items}
+	var jobList batchv1.JobList
+	if err := runtime.DefaultUnstructuredConverter.
+		FromUnstructured(uList.UnstructuredContent(), &jobList); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	dryRun := pgBackRestDryRun(postgresCluster)
+	dryRunChanges := []string{}
+	deleted := make(map[string]bool)
+	for _, job := range staleScheduledBackupJobs(jobList.Items) {
+		if dryRun {
+			dryRunChanges = append(dryRunChanges, "delete Job "+job.GetName())
+		} else if err := client.IgnoreNotFound(r.Client.Delete(ctx, job,
+			client.PropagationPolicy(metav1.DeletePropagationBackground))); err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		deleted[job.GetName()] = true
+	}
+
+	remaining := []unstructured.Unstructured{}
+	for _, item := range items {
+		if !deleted[item.GetName()] {
+			remaining = append(remaining, item)
+		}
+	}
+	return remaining, dryRunChanges, nil
+}
+
+// staleScheduledBackupJobs returns the failed scheduled backup Jobs that can be deleted, which
+// includes all failed Jobs other than the most recent Job created by each CronJob.  The most
+// recent Job is always kept (whether or not it failed) so that its status can be reported.
+func staleScheduledBackupJobs(jobs []batchv1.Job) []*batchv1.Job {
+
+	// find the most recent Job created by each CronJob
+	newest := make(map[string]*batchv1.Job)
+	for i := range jobs {
+		owner := metav1.GetControllerOf(&jobs[i])
+		if owner == nil || jobs[i].GetLabels()[naming.LabelPGBackRestCronJob] == "" {
+			continue
+		}
+		if current, ok := newest[owner.Name]; !ok ||
+			current.CreationTimestamp.Before(&jobs[i].CreationTimestamp) {
+			newest[owner.Name] = &jobs[i]
+		}
+	}
+
+	stale := []*batchv1.Job{}
+	for i := range jobs {
+		owner := metav1.GetControllerOf(&jobs[i])
+		if owner == nil || newest[owner.Name] == nil || newest[owner.Name] == &jobs[i] {
+			continue
+		}
+		if jobFailed(&jobs[i]) && jobs[i].GetDeletionTimestamp() == nil {
+			stale = append(stale, &jobs[i])
+		}
+	}
+	return stale
+}
+
+// pgBackRestInitImage returns the image for pgBackRest init containers, which is the pgBackRest
+// image unless a distinct init image is configured
+func pgBackRestInitImage(postgresCluster *v1beta1.PostgresCluster) string {
+	if image := postgresCluster.Spec.Backups.PGBackRest.InitImage; image != "" {
+		return image
+	}
+	return postgresCluster.Spec.Backups.PGBackRest.Image
+}
+
+// generateRepoHostIntent creates and populates StatefulSet with the PostgresCluster's full intent
+// as needed to create and reconcile a pgBackRest dedicated repository host within the kubernetes
+// cluster.  The volumes of any unowned repos provided are not mounted.
+func (r *Reconciler) generateRepoHostIntent(postgresCluster *v1beta1.PostgresCluster,
+	repoHostName string, unownedRepos map[string]bool) (*appsv1.StatefulSet, error) {
+
+	annotations := naming.Merge(
+		postgresCluster.Spec.Metadata.GetAnnotationsOrNil(),
+		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil())
+	labels := naming.Merge(
+		postgresCluster.Spec.Metadata.GetLabelsOrNil(),
+		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
+		r.PGBackRestSelectorLabels,
+		naming.PGBackRestDedicatedLabels(postgresCluster.GetName()),
+	)
+
+	repo := &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "StatefulSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        repoHostName,
+			Namespace:   postgresCluster.GetNamespace(),
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: naming.PGBackRestDedicatedLabels(postgresCluster.GetName()),
+			},
+			ServiceName: naming.ClusterPodService(postgresCluster).Name,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: annotations,
+				},
+				Spec: v1.PodSpec{
+					Affinity:    postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.Affinity,
+					Tolerations: postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.Tolerations,
+					// pgBackRest does not make any Kubernetes API calls from the repo host, so
+					// the ServiceAccount token is only mounted as configured
+					AutomountServiceAccountToken: postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.AutomountServiceAccountToken,
+				},
+			},
+		},
+	}
+
+	// Set the image pull secrets, if any exist.
+	// This is set here rather than using the service account due to the lack
+	// of propagation to existing pods when the CRD is updated:
+	// https://github.com/kubernetes/kubernetes/issues/88456
+	repo.Spec.Template.Spec.ImagePullSecrets = postgresCluster.Spec.ImagePullSecrets
+
+	podSecurityContext := initialize.RestrictedPodSecurityContext()
+	podSecurityContext.SupplementalGroups = supplementalGroups(postgresCluster)
+
+	// if the cluster is set to be shutdown, stop repohost pod
+	if postgresCluster.Spec.Shutdown != nil && *postgresCluster.Spec.Shutdown {
+		repo.Spec.Replicas = initialize.Int32(0)
+	} else {
+		// the cluster should not be shutdown, set this value to 1
+		repo.Spec.Replicas = initialize.Int32(1)
+	}
+
+	// set fsGroups as configured (none are set by default on OpenShift)
+	podSecurityContext.FSGroup = fsGroup(postgresCluster)
+	if podSecurityContext.FSGroup != nil {
+		// by default only change the ownership and permissions of the repo volumes when the
+		// root of the volume does not match, avoiding a recursive change of large volumes
+		fsGroupChangePolicy := v1.FSGroupChangeOnRootMismatch
+		if dedicated := postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated; dedicated != nil &&
+			dedicated.FSGroupChangePolicy != nil {
+			fsGroupChangePolicy = *dedicated.FSGroupChangePolicy
+		}
+		podSecurityContext.FSGroupChangePolicy = &fsGroupChangePolicy
+	}
+	repo.Spec.Template.Spec.SecurityContext = podSecurityContext
+
+	// add ssh pod info
+	if err := pgbackrest.AddSSHToPod(postgresCluster, &repo.Spec.Template, true,
+		postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.Resources); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := pgbackrest.AddRepoVolumesToPod(postgresCluster, &repo.Spec.Template,
+		naming.PGBackRestRepoContainerName); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	removeRepoVolumesFromPod(&repo.Spec.Template, unownedRepos)
+	// add configs to pod
+	if err := pgbackrest.AddConfigsToPod(postgresCluster, &repo.Spec.Template,
+		pgbackrest.CMRepoKey, naming.PGBackRestRepoContainerName); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// add nss_wrapper init container and add nss_wrapper env vars to the pgbackrest
+	// container
+	addNSSWrapper(pgBackRestInitImage(postgresCluster), &repo.Spec.Template)
+	addTMPEmptyDir(&repo.Spec.Template, postgresCluster.Spec.Backups.PGBackRest.TmpVolume)
+
+	// add any user-defined sidecar containers to the repo host pod
+	for i := range postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.Sidecars {
+		repo.Spec.Template.Spec.Containers = append(repo.Spec.Template.Spec.Containers,
+			*postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.Sidecars[i].DeepCopy())
+	}
+
+	// wait for any required Secrets to exist before starting the repo host
+	addWaitForSecrets(pgBackRestInitImage(postgresCluster), &repo.Spec.Template,
+		postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.WaitForSecrets)
+
+	// the repo host never runs within the namespaces of its node
+	disableHostNamespaces(&repo.Spec.Template.Spec)
+
+	// set ownership references
+	if err := controllerutil.SetControllerReference(postgresCluster, repo,
+		r.Client.Scheme()); err != nil {
 		return nil, err
 	}
 
@@ -605,12 +1748,23 @@ func (r *Reconciler) generateRepoHostIntent(postgresCluster *v1beta1.PostgresClu
 func (r *Reconciler) generateRepoVolumeIntent(postgresCluster *v1beta1.PostgresCluster,
 	spec *v1.PersistentVolumeClaimSpec, repoName string) (*v1.PersistentVolumeClaim, error) {
 
+	// any metadata defined for the volume of the repo applies to its PVC only
+	var volumeMetadata *v1beta1.Metadata
+	for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+		if repo.Name == repoName && repo.Volume != nil {
+			volumeMetadata = repo.Volume.Metadata
+		}
+	}
+
 	annotations := naming.Merge(
 		postgresCluster.Spec.Metadata.GetAnnotationsOrNil(),
-		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil())
+		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil(),
+		volumeMetadata.GetAnnotationsOrNil())
 	labels := naming.Merge(
 		postgresCluster.Spec.Metadata.GetLabelsOrNil(),
 		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
+		volumeMetadata.GetLabelsOrNil(),
+		r.PGBackRestSelectorLabels,
 		naming.PGBackRestRepoVolumeLabels(postgresCluster.GetName(), repoName),
 	)
 
@@ -637,17 +1791,178 @@ func (r *Reconciler) generateRepoVolumeIntent(postgresCluster *v1beta1.PostgresC
 	return repoVol, nil
 }
 
+// backupAnnotationOpts returns the pgBackRest "--annotation" options for any backup annotations
+// defined on the PostgresCluster, sorted by key.  Since the options are provided to pgBackRest
+// as a space-delimited list, any annotations with values containing whitespace are ignored.
+func backupAnnotationOpts(postgresCluster *v1beta1.PostgresCluster) []string {
+	var keys []string
+	annotations := postgresCluster.GetAnnotations()
+	for key, value := range annotations {
+		if strings.HasPrefix(key, naming.PGBackRestBackupAnnotationPrefix) &&
+			len(key) > len(naming.PGBackRestBackupAnnotationPrefix) &&
+			!strings.ContainsAny(value, " \t\n\r") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	opts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		opts = append(opts, fmt.Sprintf("--annotation=%s=%s",
+			strings.TrimPrefix(key, naming.PGBackRestBackupAnnotationPrefix), annotations[key]))
+	}
+	return opts
+}
+
 // generateBackupJobSpecIntent generates a JobSpec for a pgBackRest backup job
 func generateBackupJobSpecIntent(postgresCluster *v1beta1.PostgresCluster, selector,
 	containerName, repoName, serviceAccountName, configName string,
 	labels, annotations map[string]string, opts ...string) (*batchv1.JobSpec, error) {
 
-	repoIndex := regexRepoIndex.FindString(repoName)
-	cmdOpts := []string{
-		"--stanza=" + pgbackrest.DefaultStanzaName,
-		"--repo=" + repoIndex,
-	}
-	cmdOpts = append(cmdOpts, opts...)
+	repoIndex := regexRepoIndex.FindString(repoName)
+	cmdOpts := []string{
+		"--stanza=" + pgbackrest.DefaultStanzaName,
+		"--repo=" + repoIndex,
+	}
+	cmdOpts = append(cmdOpts, opts...)
+	if jobs := postgresCluster.Spec.Backups.PGBackRest.Jobs; jobs != nil {
+		for _, path := range jobs.Exclude {
+			cmdOpts = append(cmdOpts, "--exclude="+path)
+		}
+		if jobs.ManifestSaveThreshold != "" {
+			cmdOpts = append(cmdOpts, "--manifest-save-threshold="+jobs.ManifestSaveThreshold)
+		}
+	}
+	cmdOpts = append(cmdOpts, backupAnnotationOpts(postgresCluster)...)
+
+	jobSpec, err := generatePGBackRestJobSpecIntent(postgresCluster, "backup", selector,
+		containerName, serviceAccountName, configName, labels, annotations, cmdOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// the resource requirements of the repo being backed up, if any, take precedence over
+	// those defined for all backup Jobs
+	for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+		if repo.Name == repoName && repo.BackupJobResources != nil {
+			jobSpec.Template.Spec.Containers[0].Resources = *repo.BackupJobResources
+		}
+	}
+
+	jobSpec.ActiveDeadlineSeconds = backupJobDeadline(postgresCluster)
+
+	// When verification is enabled for the repo, run the backup within an init container and
+	// then verify the repository (including the backup just created) within the main container.
+	// This ensures the Job only completes successfully if both the backup and verify succeed.
+	var verifyEnabled bool
+	for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+		if repo.Name == repoName && repo.Verify != nil {
+			verifyEnabled = *repo.Verify
+		}
+	}
+	if verifyEnabled {
+		backup := jobSpec.Template.Spec.Containers[0]
+		verify := backup.DeepCopy()
+		verify.Name = naming.PGBackRestVerifyContainerName
+		for i := range verify.Env {
+			switch verify.Env[i].Name {
+			case "COMMAND":
+				verify.Env[i].Value = "verify"
+			case "COMMAND_OPTS":
+				verify.Env[i].Value = strings.Join([]string{
+					"--stanza=" + pgbackrest.DefaultStanzaName,
+					"--repo=" + repoIndex,
+				}, " ")
+			}
+		}
+		jobSpec.Template.Spec.InitContainers = []v1.Container{backup}
+		jobSpec.Template.Spec.Containers = []v1.Container{*verify}
+	}
+
+	return jobSpec, nil
+}
+
+// backupJobDeadline returns the "activeDeadlineSeconds" of backup Jobs as configured for the
+// PostgresCluster provided, i.e. the base number of seconds plus the number of seconds allowed
+// for each GiB of the database.  The size of the database is estimated using the largest backup
+// reported in the status of the latest backups, and is therefore unknown (and treated as zero)
+// until a backup completes.  Returns nil when no deadline is configured.
+func backupJobDeadline(postgresCluster *v1beta1.PostgresCluster) *int64 {
+	jobs := postgresCluster.Spec.Backups.PGBackRest.Jobs
+	if jobs == nil || jobs.ActiveDeadline == nil {
+		return nil
+	}
+
+	var size int64
+	if status := postgresCluster.Status.PGBackRest; status != nil {
+		for _, backup := range status.LatestBackups {
+			if backup.BackupSizeBytes > size {
+				size = backup.BackupSizeBytes
+			}
+		}
+	}
+
+	// round up to the next GiB
+	const gib = 1 << 30
+	deadline := jobs.ActiveDeadline.BaseSeconds +
+		jobs.ActiveDeadline.SecondsPerGiB*((size+gib-1)/gib)
+	return &deadline
+}
+
+// backupTypeLabels returns the labels identifying the type of backup (i.e. "full", "diff" or
+// "incr") taken by a backup Job run using the pgBackRest options provided.  Nothing is returned
+// when the options do not include a valid type, since pgBackRest then determines the type itself.
+func backupTypeLabels(opts []string) map[string]string {
+	var backupType string
+	for _, opt := range opts {
+		if strings.HasPrefix(opt, "--type=") {
+			backupType = strings.TrimPrefix(opt, "--type=")
+		}
+	}
+	switch backupType {
+	case full, differential, incremental:
+		return map[string]string{naming.LabelPGBackRestBackupType: backupType}
+	}
+	return nil
+}
+
+// generateExpireJobSpecIntent generates a JobSpec for a Job that runs the pgBackRest "expire"
+// command, which removes the backups (and archived WAL) of a repo according to its configured
+// retention without taking a new backup, e.g. to reclaim space after lowering retention.
+func generateExpireJobSpecIntent(postgresCluster *v1beta1.PostgresCluster, selector,
+	containerName, repoName, serviceAccountName, configName string,
+	labels, annotations map[string]string, opts ...string) (*batchv1.JobSpec, error) {
+
+	cmdOpts := []string{
+		"--stanza=" + pgbackrest.DefaultStanzaName,
+		"--repo=" + regexRepoIndex.FindString(repoName),
+	}
+	cmdOpts = append(cmdOpts, opts...)
+
+	return generatePGBackRestJobSpecIntent(postgresCluster, "expire", selector, containerName,
+		serviceAccountName, configName, labels, annotations, cmdOpts)
+}
+
+// generateCheckJobSpecIntent generates a JobSpec for a Job that runs the pgBackRest "check"
+// command against all repos, validating the pgBackRest configuration as well as connectivity to
+// PostgreSQL and each repo.
+func generateCheckJobSpecIntent(postgresCluster *v1beta1.PostgresCluster, selector,
+	containerName, serviceAccountName, configName string,
+	labels, annotations map[string]string) (*batchv1.JobSpec, error) {
+
+	cmdOpts := []string{"--stanza=" + pgbackrest.DefaultStanzaName}
+
+	return generatePGBackRestJobSpecIntent(postgresCluster, "check", selector, containerName,
+		serviceAccountName, configName, labels, annotations, cmdOpts)
+}
+
+// generatePGBackRestJobSpecIntent generates a JobSpec for a Job that runs the pgBackRest command
+// provided (e.g. "backup") with the options provided.  The command itself is run within the Pod
+// identified by the selector and container name, i.e. the dedicated repository host or the
+// current primary, using the configuration of the backup Jobs in the spec.
+func generatePGBackRestJobSpecIntent(postgresCluster *v1beta1.PostgresCluster,
+	command, selector, containerName, serviceAccountName, configName string,
+	labels, annotations map[string]string, cmdOpts []string) (*batchv1.JobSpec, error) {
 
 	jobSpec := &batchv1.JobSpec{
 		Template: v1.PodTemplateSpec{
@@ -656,7 +1971,7 @@ func generateBackupJobSpecIntent(postgresCluster *v1beta1.PostgresCluster, selec
 				Containers: []v1.Container{{
 					Command: []string{"/opt/crunchy/bin/pgbackrest"},
 					Env: []v1.EnvVar{
-						{Name: "COMMAND", Value: "backup"},
+						{Name: "COMMAND", Value: command},
 						{Name: "COMMAND_OPTS", Value: strings.Join(cmdOpts, " ")},
 						{Name: "COMPARE_HASH", Value: "true"},
 						{Name: "CONTAINER", Value: containerName},
@@ -666,6 +1981,9 @@ func generateBackupJobSpecIntent(postgresCluster *v1beta1.PostgresCluster, selec
 					Image:           postgresCluster.Spec.Backups.PGBackRest.Image,
 					Name:            naming.PGBackRestRepoContainerName,
 					SecurityContext: initialize.RestrictedSecurityContext(),
+					// Report the end of the output of a failed backup in the status of the Pod,
+					// e.g. as needed to detect a repository that is out of space.
+					TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
 				}},
 				// Set RestartPolicy to "Never" since we want a new Pod to be created by the Job
 				// controller when there is a failure (instead of the container simply restarting).
@@ -677,6 +1995,17 @@ func generateBackupJobSpecIntent(postgresCluster *v1beta1.PostgresCluster, selec
 		},
 	}
 
+	// set the resource requirements for the backup Job container, if any are configured, as
+	// well as whether or not the ServiceAccount token is mounted and the priority of the pod
+	if postgresCluster.Spec.Backups.PGBackRest.Jobs != nil {
+		jobSpec.Template.Spec.Containers[0].Resources =
+			postgresCluster.Spec.Backups.PGBackRest.Jobs.Resources
+		jobSpec.Template.Spec.AutomountServiceAccountToken =
+			postgresCluster.Spec.Backups.PGBackRest.Jobs.AutomountServiceAccountToken
+		jobSpec.Template.Spec.PriorityClassName =
+			postgresCluster.Spec.Backups.PGBackRest.Jobs.PriorityClassName
+	}
+
 	// Set the image pull secrets, if any exist.
 	// This is set here rather than using the service account due to the lack
 	// of propagation to existing pods when the CRD is updated:
@@ -689,6 +2018,9 @@ func generateBackupJobSpecIntent(postgresCluster *v1beta1.PostgresCluster, selec
 		return nil, errors.WithStack(err)
 	}
 
+	// pgBackRest Jobs never run within the namespaces of their node
+	disableHostNamespaces(&jobSpec.Template.Spec)
+
 	return jobSpec, nil
 }
 
@@ -758,9 +2090,19 @@ func (r *Reconciler) observeRestoreEnv(ctx context.Context,
 			cluster.Status.PGBackRest.Restore.CompletionTime = restoreJob.Status.CompletionTime
 			cluster.Status.PGBackRest.Restore.Succeeded = restoreJob.Status.Succeeded
 			cluster.Status.PGBackRest.Restore.Failed = restoreJob.Status.Failed
-			cluster.Status.PGBackRest.Restore.Active = restoreJob.Status.Active
+			cluster.Status.PGBackRest.Restore.Active = jobActive(restoreJob)
 			if completed || failed {
 				cluster.Status.PGBackRest.Restore.Finished = true
+				cluster.Status.PGBackRest.Restore.Progress = ""
+			} else if restoreJob.Status.Active > 0 {
+				// progress is purely informational, so simply log any error and clear the
+				// progress if it cannot be determined
+				progress, err := r.observeRestoreProgress(ctx, restoreJob)
+				if err != nil {
+					logging.FromContext(ctx).V(1).Info("unable to determine restore progress",
+						"error", err.Error())
+				}
+				cluster.Status.PGBackRest.Restore.Progress = progress
 			}
 		}
 
@@ -818,6 +2160,56 @@ func (r *Reconciler) observeRestoreEnv(ctx context.Context,
 	return currentEndpoints, restoreJob, nil
 }
 
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+
+// observeRestoreProgress returns the progress of the restore running within the Pod for the
+// restore Job provided.  An empty string is returned if a running Pod cannot be found for the Job.
+func (r *Reconciler) observeRestoreProgress(ctx context.Context,
+	restoreJob *batchv1.Job) (string, error) {
+
+	selector, err := metav1.LabelSelectorAsSelector(restoreJob.Spec.Selector)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	pods := &v1.PodList{}
+	if err := r.Client.List(ctx, pods, client.InNamespace(restoreJob.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase != v1.PodRunning {
+			continue
+		}
+		pod := &pods.Items[i]
+		exec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			return r.PodExec(pod.GetNamespace(), pod.GetName(),
+				naming.PGBackRestRestoreContainerName, stdin, stdout, stderr, command...)
+		}
+		return pgbackrest.Executor(exec).RestoreProgress(ctx)
+	}
+
+	return "", nil
+}
+
+// restoreInProgress returns true if a restore Job is actively running for the PostgresCluster
+// provided
+func restoreInProgress(cluster *v1beta1.PostgresCluster) bool {
+	return cluster.Status.PGBackRest != nil && cluster.Status.PGBackRest.Restore != nil &&
+		!cluster.Status.PGBackRest.Restore.Finished && cluster.Status.PGBackRest.Restore.Active > 0
+}
+
+// restoringInPlace returns true if an in-place restore is in progress for the PostgresCluster
+// provided, as indicated by the ConditionPGBackRestRestoreProgressing condition.  Backups should
+// not be created while an in-place restore is in progress.
+func restoringInPlace(cluster *v1beta1.PostgresCluster) bool {
+	condition := meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionPGBackRestRestoreProgressing)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
 // +kubebuilder:rbac:groups="",resources=endpoints,verbs=delete
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=delete
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=delete
@@ -843,8 +2235,8 @@ func (r *Reconciler) prepareForRestore(ctx context.Context,
 	}
 
 	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
-	cluster.Status.PGBackRest.Restore = &v1beta1.PGBackRestJobStatus{
-		ID: restoreID,
+	cluster.Status.PGBackRest.Restore = &v1beta1.PGBackRestRestoreStatus{
+		PGBackRestJobStatus: v1beta1.PGBackRestJobStatus{ID: restoreID},
 	}
 
 	// find all runners, the primary, and determine if the cluster is still running
@@ -968,6 +2360,16 @@ func (r *Reconciler) reconcileRestoreJob(ctx context.Context,
 		case strings.Contains(opt, "--pg1-path"):
 			msg = "Option '--pg1-path' is not allowed: the operator will automatically set this " +
 				"option"
+		case strings.Contains(opt, "--target-timeline") && dataSource.TargetTimeline != "":
+			msg = "Option '--target-timeline' is not allowed: please use the 'targetTimeline' " +
+				"field instead."
+		case strings.Contains(opt, "--set") && dataSource.Set != "":
+			msg = "Option '--set' is not allowed: please use the 'set' field instead."
+		case strings.HasPrefix(opt, "--type") && dataSource.Type != "":
+			msg = "Option '--type' is not allowed: please use the 'type' field instead."
+		case (opt == "--target" || strings.HasPrefix(opt, "--target=")) &&
+			dataSource.Target != "":
+			msg = "Option '--target' is not allowed: please use the 'target' field instead."
 		case strings.Contains(opt, "--target-action"):
 			msg = "Option '--target-action' is not allowed: the operator will automatically set this " +
 				"option "
@@ -981,12 +2383,41 @@ func (r *Reconciler) reconcileRestoreJob(ctx context.Context,
 		}
 	}
 
-	pgdata := postgres.DataDirectory(cluster)
+	if dataSource.TargetTimeline != "" &&
+		!regexTargetTimeline.MatchString(dataSource.TargetTimeline) {
+		r.Recorder.Eventf(cluster, v1.EventTypeWarning, "InvalidDataSource",
+			"Invalid target timeline %q: must be a positive integer, 'current' or 'latest'",
+			dataSource.TargetTimeline)
+		return nil
+	}
+
+	if dataSource.Set != "" && !regexBackupSet.MatchString(dataSource.Set) {
+		r.Recorder.Eventf(cluster, v1.EventTypeWarning, "InvalidDataSource",
+			"Invalid backup set %q: must be the label of a pgBackRest backup", dataSource.Set)
+		return nil
+	}
+
+	if err := validateRestoreTarget(dataSource); err != nil {
+		r.Recorder.Eventf(cluster, v1.EventTypeWarning, EventInvalidRestoreTarget,
+			"Invalid recovery target: %s", err.Error())
+		return nil
+	}
+
+	pgdata := pgbackrest.PGPath(cluster)
 	// combine options provided by user in the spec with those populated by the operator for a
 	// successful restore
 	opts := append(options, []string{
 		"--stanza=" + pgbackrest.DefaultStanzaName, "--pg1-path=" + pgdata,
 		"--repo=" + regexRepoIndex.FindString(repoName)}...)
+	if dataSource.TargetTimeline != "" {
+		opts = append(opts, "--target-timeline="+dataSource.TargetTimeline)
+	}
+	if dataSource.Set != "" {
+		opts = append(opts, "--set="+dataSource.Set)
+	}
+	if dataSource.Type != "" {
+		opts = append(opts, "--type="+dataSource.Type, `--target="`+dataSource.Target+`"`)
+	}
 	var deltaOptFound bool
 	for _, opt := range opts {
 		if strings.Contains(opt, "--delta") {
@@ -998,7 +2429,20 @@ func (r *Reconciler) reconcileRestoreJob(ctx context.Context,
 		opts = append(opts, "--delta")
 	}
 
-	var foundTarget, foundTargetAction bool
+	// log each file restored to the restore log file (unless a file log level is explicitly set)
+	// so that restore progress can be reported while the restore Job is running
+	var logLevelFileFound bool
+	for _, opt := range opts {
+		if strings.Contains(opt, "--log-level-file") {
+			logLevelFileFound = true
+			break
+		}
+	}
+	if !logLevelFileFound {
+		opts = append(opts, "--log-level-file=detail")
+	}
+
+	foundTarget, foundTargetAction := dataSource.Target != "", false
 	for _, opt := range options {
 		switch {
 		case strings.Contains(opt, "--target"):
@@ -1033,6 +2477,7 @@ func (r *Reconciler) reconcileRestoreJob(ctx context.Context,
 	labels := naming.Merge(
 		cluster.Spec.Metadata.GetLabelsOrNil(),
 		cluster.Spec.Metadata.GetLabelsOrNil(),
+		r.PGBackRestSelectorLabels,
 		naming.PGBackRestRestoreJobLabels(cluster.Name),
 		map[string]string{naming.LabelStartupInstance: instanceName},
 	)
@@ -1084,8 +2529,9 @@ func (r *Reconciler) reconcileRestoreJob(ctx context.Context,
 						SecurityContext: initialize.RestrictedSecurityContext(),
 						Resources:       dataSource.Resources,
 					}},
-					RestartPolicy: v1.RestartPolicyNever,
-					Volumes:       volumes,
+					PriorityClassName: dataSource.PriorityClassName,
+					RestartPolicy:     v1.RestartPolicyNever,
+					Volumes:           volumes,
 				},
 			},
 		},
@@ -1103,12 +2549,8 @@ func (r *Reconciler) reconcileRestoreJob(ctx context.Context,
 	}
 
 	podSecurityContext := initialize.RestrictedPodSecurityContext()
-	// TODO (andrewlecuyer): make supplemental groups configurable
-	podSecurityContext.SupplementalGroups = []int64{65534}
-	// set fsGroups if not OpenShift
-	if cluster.Spec.OpenShift == nil || !*cluster.Spec.OpenShift {
-		podSecurityContext.FSGroup = initialize.Int64(26)
-	}
+	podSecurityContext.SupplementalGroups = supplementalGroups(cluster)
+	podSecurityContext.FSGroup = fsGroup(cluster)
 	restoreJob.Spec.Template.Spec.SecurityContext = podSecurityContext
 
 	if pgbackrest.RepoHostEnabled(sourceCluster) {
@@ -1128,12 +2570,58 @@ func (r *Reconciler) reconcileRestoreJob(ctx context.Context,
 
 	// add nss_wrapper init container and add nss_wrapper env vars to the pgbackrest restore
 	// container
-	addNSSWrapper(cluster.Spec.Backups.PGBackRest.Image, &restoreJob.Spec.Template)
-	addTMPEmptyDir(&restoreJob.Spec.Template)
+	addNSSWrapper(pgBackRestInitImage(cluster), &restoreJob.Spec.Template)
+	addTMPEmptyDir(&restoreJob.Spec.Template, cluster.Spec.Backups.PGBackRest.TmpVolume)
+
+	// the restore Job never runs within the namespaces of its node
+	disableHostNamespaces(&restoreJob.Spec.Template.Spec)
 
 	return errors.WithStack(r.apply(ctx, restoreJob))
 }
 
+// validateRestoreTarget validates the recovery target type and recovery target defined for a
+// restore, if any.  Since the target is provided to the restore command within double quotes,
+// characters that would be interpreted by the shell are not allowed.  An error describing the
+// problem found is returned if the recovery target is invalid.
+func validateRestoreTarget(dataSource *v1beta1.PostgresClusterDataSource) error {
+	targetType, target := dataSource.Type, dataSource.Target
+
+	switch {
+	case targetType == "" && target == "":
+		return nil
+	case targetType == "":
+		return errors.Errorf("a type is required for target %q", target)
+	case target == "":
+		return errors.Errorf("a target is required for type %q", targetType)
+	case strings.ContainsAny(target, "\"$`\\\n"):
+		return errors.Errorf("target %q contains characters that are not allowed", target)
+	}
+
+	var valid bool
+	switch targetType {
+	case "time":
+		valid = regexTargetTime.MatchString(target)
+		if valid {
+			// ensure the date and time are actual calendar values, e.g. not month 13
+			_, err := time.Parse("2006-01-02 15:04:05",
+				strings.Replace(target[:19], "T", " ", 1))
+			valid = err == nil
+		}
+	case "lsn":
+		valid = regexTargetLSN.MatchString(target)
+	case "xid":
+		valid = regexTargetXID.MatchString(target)
+	case "name":
+		valid = true
+	default:
+		return errors.Errorf("unknown type %q", targetType)
+	}
+	if !valid {
+		return errors.Errorf("target %q is not a valid %s target", target, targetType)
+	}
+	return nil
+}
+
 // reconcilePGBackRest is responsible for reconciling any/all pgBackRest resources owned by a
 // specific PostgresCluster (e.g. Deployments, ConfigMaps, Secrets, etc.).  This function will
 // ensure various reconciliation logic is run as needed for each pgBackRest resource, while then
@@ -1152,6 +2640,25 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 		postgresCluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
 	}
 
+	// Nothing can be reconciled without at least one repo, e.g. since the first repo is used to
+	// create replicas.  This includes the cleanup of existing pgBackRest resources, which ensures
+	// a partially configured spec does not result in the removal of any repo volumes.  A change
+	// to the spec triggers the reconcile needed to proceed once a repo is defined.
+	if len(postgresCluster.Spec.Backups.PGBackRest.Repos) == 0 {
+		meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
+			ObservedGeneration: postgresCluster.GetGeneration(),
+			Type:               ConditionReposMissing,
+			Status:             metav1.ConditionTrue,
+			Reason:             "NoReposDefined",
+			Message:            "No pgBackRest repositories are defined in the spec",
+		})
+		return reconcile.Result{}, nil
+	}
+	// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+	if len(postgresCluster.Status.Conditions) > 0 {
+		meta.RemoveStatusCondition(&postgresCluster.Status.Conditions, ConditionReposMissing)
+	}
+
 	// create the Result that will be updated while reconciling any/all pgBackRest resources
 	result := reconcile.Result{}
 
@@ -1164,17 +2671,71 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 		return reconcile.Result{}, errors.WithStack(err)
 	}
 
+	// While in dry-run mode, the changes cleanup would make have been reported above, and nothing
+	// else is reconciled, since reconciling the remaining resources would change them.
+	if pgBackRestDryRun(postgresCluster) {
+		return result, nil
+	}
+
+	// report any repository that ran out of space according to the most recent backups
+	if err := r.reconcileRepoDiskFull(ctx, postgresCluster); err != nil {
+		log.Error(err, "unable to reconcile pgBackRest repo disk full condition")
+		result = updateReconcileResult(result, reconcile.Result{Requeue: true})
+	}
+
+	// Verify any existing repository volumes belong to this PostgresCluster before mounting (or
+	// adopting) them.  The volumes of repos that do not are neither mounted nor adopted, since
+	// mounting a repository used by another cluster could corrupt its backups, while all other
+	// repos are reconciled as usual.  Requeue to check again, since changes to volumes that are
+	// not owned do not trigger a reconcile.
+	unownedRepos, err := r.reconcileRepoVolumeOwnership(ctx, postgresCluster)
+	if err != nil {
+		log.Error(err, "unable to reconcile pgBackRest repo volume ownership")
+		return updateReconcileResult(result, reconcile.Result{Requeue: true}), nil
+	} else if len(unownedRepos) > 0 {
+		result = updateReconcileResult(result, reconcile.Result{RequeueAfter: time.Minute})
+	}
+
+	// report any pgBackRest Pods that were elevated to the namespaces of their node
+	if err := r.reconcileHostNamespaces(ctx, postgresCluster); err != nil {
+		log.Error(err, "unable to reconcile pgBackRest host namespaces")
+		result = updateReconcileResult(result, reconcile.Result{Requeue: true})
+	}
+
 	var repoHost *appsv1.StatefulSet
 	var repoHostName string
 	dedicatedEnabled := (postgresCluster.Spec.Backups.PGBackRest.RepoHost != nil) &&
 		(postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated != nil)
 	if dedicatedEnabled {
 		// reconcile the pgbackrest repository host
-		repoHost, err = r.reconcileDedicatedRepoHost(ctx, postgresCluster, repoResources)
+		repoHost, err = r.reconcileDedicatedRepoHost(ctx, postgresCluster, repoResources,
+			unownedRepos)
 		if err != nil {
 			log.Error(err, "unable to reconcile pgBackRest repo host")
 			result = updateReconcileResult(result, reconcile.Result{Requeue: true})
 		}
+		// If the repo host is not ready because the pgBackRest check failed, then requeue to
+		// check again once the next check is due, since the repositories may become reachable
+		// without any other event triggering a reconcile.
+		if condition := meta.FindStatusCondition(postgresCluster.Status.Conditions,
+			ConditionRepoHostReady); condition != nil &&
+			condition.Reason == EventRepoHostCheckFailed {
+			requeue := repoHostCheckRemaining(postgresCluster.Status.PGBackRest.RepoHost,
+				time.Now())
+			if requeue == 0 {
+				requeue = repoHostCheckInterval
+			}
+			result = updateReconcileResult(result, reconcile.Result{RequeueAfter: requeue})
+		}
+		// Similarly, requeue when the repo host pod has not yet been ready for the minimum
+		// number of seconds, since nothing else triggers a reconcile once it has.
+		if condition := meta.FindStatusCondition(postgresCluster.Status.Conditions,
+			ConditionRepoHostReady); condition != nil &&
+			condition.Reason == ReasonRepoHostNotStable {
+			result = updateReconcileResult(result, reconcile.Result{
+				RequeueAfter: repoHostMinReady(postgresCluster),
+			})
+		}
 		repoHostName = repoHost.GetName()
 	} else if len(postgresCluster.Status.Conditions) > 0 {
 		// TODO: remove guard above with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
@@ -1182,22 +2743,57 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 		meta.RemoveStatusCondition(&postgresCluster.Status.Conditions, ConditionRepoHostReady)
 	}
 
+	// reconcile the Service and ServiceMonitor that expose the metrics of the repo host, if any
+	if _, err := r.reconcileRepoHostMetricsService(ctx, postgresCluster); err != nil {
+		log.Error(err, "unable to reconcile pgBackRest repo host metrics Service")
+		result = updateReconcileResult(result, reconcile.Result{Requeue: true})
+	}
+	if err := r.reconcileRepoHostServiceMonitor(ctx, postgresCluster); err != nil {
+		log.Error(err, "unable to reconcile pgBackRest repo host ServiceMonitor")
+		result = updateReconcileResult(result, reconcile.Result{Requeue: true})
+	}
+
+	// Get the contents of any custom pgBackRest configuration files referenced in the spec.
+	// Nothing that depends on the configuration is reconciled until they can be read, since a
+	// config hash calculated without them would appear to be a configuration change, e.g.
+	// resetting the status of stanza creation for every repo.
+	customConfig, err := r.getPGBackRestCustomConfig(ctx, postgresCluster)
+	if err != nil {
+		log.Error(err, "unable to get custom pgBackRest configuration")
+		return updateReconcileResult(result, reconcile.Result{Requeue: true}), nil
+	}
+
 	// calculate hashes for the external repository configurations in the spec (e.g. for Azure,
-	// GCS and/or S3 repositories) as needed to properly detect changes to external repository
-	// configuration (and then execute stanza create commands accordingly)
-	configHashes, configHash, err := pgbackrest.CalculateConfigHashes(postgresCluster)
+	// GCS and/or S3 repositories), as well as for any custom configuration files, as needed to
+	// properly detect changes to external repository configuration (and then execute stanza
+	// create commands accordingly)
+	configHashes, configHash, err := pgbackrest.CalculateConfigHashes(postgresCluster, customConfig)
 	if err != nil {
 		log.Error(err, "unable to calculate config hashes")
 		result = updateReconcileResult(result, reconcile.Result{Requeue: true})
 	}
 
 	// reconcile all pgbackrest repository repos
-	replicaCreateRepo, err := r.reconcileRepos(ctx, postgresCluster, configHashes)
+	replicaCreateRepo, err := r.reconcileRepos(ctx, postgresCluster, configHashes, unownedRepos)
 	if err != nil {
 		log.Error(err, "unable to reconcile pgBackRest repo host")
 		result = updateReconcileResult(result, reconcile.Result{Requeue: true})
 	}
 
+	// report when the most recent backup of each repository completed, and its type
+	setLastBackupStatus(postgresCluster, repoResources)
+
+	// validate the configuration of any external repositories (e.g. Azure, GCS and/or S3
+	// repositories), surfacing missing credentials before stanza creation fails, as well as
+	// the retention settings of all repositories
+	if configOptions, err := r.getPGBackRestConfigOptions(ctx, postgresCluster,
+		customConfig); err != nil {
+		log.Error(err, "unable to get pgBackRest configuration options")
+		result = updateReconcileResult(result, reconcile.Result{Requeue: true})
+	} else {
+		r.setRepoConfigStatus(postgresCluster, configOptions)
+	}
+
 	// gather instance names and reconcile all pgbackrest configuration and secrets
 	instanceNames := []string{}
 	for _, instance := range instances.forCluster {
@@ -1205,8 +2801,12 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 	}
 	// sort to ensure consistent ordering of hosts when creating pgBackRest configs
 	sort.Strings(instanceNames)
-	if err := r.reconcilePGBackRestConfig(ctx, postgresCluster, nil, repoHostName,
-		configHash, naming.ClusterPodService(postgresCluster).Name,
+	// Any repos with volumes not owned by the cluster are left out of the configuration, which
+	// ensures stanzas, backups and WAL archives are never written to them (the volumes are not
+	// mounted, so they would otherwise be written to ephemeral storage).  Since these repos also
+	// have no status, backups of any kind are not attempted for them either.
+	if err := r.reconcilePGBackRestConfig(ctx, withoutRepos(postgresCluster, unownedRepos), nil,
+		repoHostName, configHash, naming.ClusterPodService(postgresCluster).Name,
 		postgresCluster.GetNamespace(), instanceNames, repoResources.sshSecret); err != nil {
 		log.Error(err, "unable to reconcile pgBackRest configuration")
 		result = updateReconcileResult(result, reconcile.Result{Requeue: true})
@@ -1219,6 +2819,33 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 		result = updateReconcileResult(result, reconcile.Result{Requeue: true})
 	}
 
+	// The remaining steps run pgBackRest within the Pods of the cluster, e.g. to create stanzas
+	// and take backups.  Skip them while the cluster is intentionally shut down, since those
+	// Pods no longer exist, and only ensure the CronJobs for scheduled backups are suspended.
+	// Everything is reconciled again once the cluster is started.
+	if postgresCluster.Spec.Shutdown != nil && *postgresCluster.Spec.Shutdown {
+		if requeue := r.reconcileScheduledBackups(ctx, postgresCluster, instances,
+			sa); requeue {
+			result = updateReconcileResult(result, reconcile.Result{RequeueAfter: 10 * time.Second})
+		}
+		if err := r.reconcilePGBackRestCheck(ctx, postgresCluster,
+			repoResources.checkJobs, instances, sa); err != nil {
+			log.Error(err, "unable to reconcile pgBackRest check")
+			result = updateReconcileResult(result, reconcile.Result{RequeueAfter: 10 * time.Second})
+		}
+		return result, nil
+	}
+
+	// Verify the buckets or containers of any cloud repos can be accessed before creating
+	// stanzas, so that a missing bucket or denied access is reported precisely (and early).
+	// Inaccessible repos are checked again a while later, e.g. once a bucket is created.
+	if retry, err := r.reconcileRepoAccess(ctx, postgresCluster, configHash); err != nil {
+		log.Error(err, "unable to check access to pgBackRest repos")
+		result = updateReconcileResult(result, reconcile.Result{RequeueAfter: time.Minute})
+	} else if retry {
+		result = updateReconcileResult(result, reconcile.Result{RequeueAfter: time.Minute})
+	}
+
 	// reconcile the pgBackRest stanza for all configuration pgBackRest repos
 	configHashMismatch, err := r.reconcileStanzaCreate(ctx, postgresCluster, instances, configHash)
 	// If a stanza create error then requeue but don't return the error.  This prevents
@@ -1241,17 +2868,89 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 	// container.
 	if configHashMismatch {
 		log.Info("pgBackRest config hash mismatch detected, requeuing to reattempt stanza create")
+		result = updateReconcileResult(result,
+			reconcile.Result{RequeueAfter: r.configHashMismatchInterval()})
+	}
+	if err == nil {
+		r.setConfigHashMismatchCondition(postgresCluster, configHashMismatch)
+	}
+	// Report the config hash currently mounted within the dedicated repo host alongside the
+	// desired hash, e.g. to help diagnose config hash mismatches.  Like backup counts, errors
+	// are logged and retried a while later, since the hashes are purely informational.
+	if err := r.reconcileRepoHostConfigHash(ctx, postgresCluster, configHash); err != nil {
+		log.Error(err, "unable to read pgBackRest config hash from repo host")
+		result = updateReconcileResult(result, reconcile.Result{RequeueAfter: time.Minute})
+	}
+
+	// Compare the versions of pgBackRest installed within the repo host and the instances, since
+	// e.g. an older pgBackRest on the repo host might be unable to read newer backups.  Errors
+	// are logged and retried a while later, since the comparison is purely informational.
+	if err := r.reconcileVersionMismatch(ctx, postgresCluster); err != nil {
+		log.Error(err, "unable to compare pgBackRest versions")
+		result = updateReconcileResult(result, reconcile.Result{RequeueAfter: time.Minute})
+	}
+
+	// Refresh the number of backups within each repo when they are due, and then requeue for the
+	// next periodic refresh.  Errors are logged without being returned, and are retried a while
+	// later, since backup counts are purely informational.
+	if refresh, err := r.reconcileBackupCounts(ctx, postgresCluster); err != nil {
+		log.Error(err, "unable to reconcile pgBackRest backup counts")
+		result = updateReconcileResult(result, reconcile.Result{RequeueAfter: time.Minute})
+	} else if refresh > 0 {
+		result = updateReconcileResult(result, reconcile.Result{RequeueAfter: refresh})
+	}
+	// Record the pgBackRest backup set created by each of the latest backup Jobs once they
+	// complete.  Like backup counts, errors are logged and retried a while later.
+	if err := r.reconcileBackupSets(ctx, postgresCluster, repoResources); err != nil {
+		log.Error(err, "unable to reconcile pgBackRest backup sets")
+		result = updateReconcileResult(result, reconcile.Result{RequeueAfter: time.Minute})
+	}
+	// Reconcile the backup lock prior to reconciling any backups, ensuring it reflects the
+	// backup Jobs currently running for the cluster.  The backups that rely on the lock are
+	// not reconciled when it cannot be, since another backup might then be running.
+	lockErr := r.reconcileBackupLock(ctx, postgresCluster, repoResources)
+	if lockErr != nil {
+		log.Error(lockErr, "unable to reconcile backup lock")
+		result = updateReconcileResult(result, reconcile.Result{Requeue: true})
+	}
+
+	// Reconcile a final backup as defined in the spec, and triggered by the end-user via
+	// annotation.  This is done prior to reconciling the backup CronJobs so that they are
+	// suspended as soon as the final backup is complete.
+	if lockErr == nil {
+		if err := r.reconcileFinalBackup(ctx, postgresCluster, repoResources.finalBackupJobs,
+			sa, instances); err != nil {
+			log.Error(err, "unable to reconcile final backup")
+			result = updateReconcileResult(result, reconcile.Result{Requeue: true})
+		}
+	}
+
+	// Reconcile the pgBackRest backup CronJobs, unless scheduled backups are deferred until the
+	// cluster has been bootstrapped for the delay configured in the spec.  In that case requeue
+	// once the delay has passed, since nothing else is guaranteed to trigger a reconcile then.
+	if deferral := scheduledBackupsDeferral(postgresCluster, time.Now()); deferral > 0 {
+		result = updateReconcileResult(result, reconcile.Result{RequeueAfter: deferral})
+	} else if requeue := r.reconcileScheduledBackups(ctx, postgresCluster, instances,
+		sa); requeue {
+		// If the pgBackRest backup CronJob reconciliation function has encountered an error,
+		// requeue after 10 seconds. The error will not bubble up to allow the reconcile loop to
+		// continue. An error is not logged because an event was already created.
+		// TODO(tjmoore4): Is this the desired eventing/logging/reconciliation strategy?
+		// A potential option to handle this proactively would be to use a webhook:
+		// https://book.kubebuilder.io/cronjob-tutorial/webhook-implementation.html
 		result = updateReconcileResult(result, reconcile.Result{RequeueAfter: 10 * time.Second})
 	}
-	// reconcile the pgBackRest backup CronJobs
-	requeue := r.reconcileScheduledBackups(ctx, postgresCluster, instances, sa)
-	// If the pgBackRest backup CronJob reconciliation function has encountered an error, requeue
-	// after 10 seconds. The error will not bubble up to allow the reconcile loop to continue.
-	// An error is not logged because an event was already created.
-	// TODO(tjmoore4): Is this the desired eventing/logging/reconciliation strategy?
-	// A potential option to handle this proactively would be to use a webhook:
-	// https://book.kubebuilder.io/cronjob-tutorial/webhook-implementation.html
-	if requeue {
+	// Requeue when the next backup window opens or closes, since nothing else is guaranteed to
+	// trigger the reconcile needed to resume or suspend the backup CronJobs at that time.
+	if _, next := scheduledBackupWindow(postgresCluster, time.Now()); next > 0 {
+		result = updateReconcileResult(result, reconcile.Result{RequeueAfter: next})
+	}
+
+	// Reconcile the CronJob that periodically runs the pgBackRest check command, and report the
+	// result of the most recent check
+	if err := r.reconcilePGBackRestCheck(ctx, postgresCluster, repoResources.checkJobs,
+		instances, sa); err != nil {
+		log.Error(err, "unable to reconcile pgBackRest check")
 		result = updateReconcileResult(result, reconcile.Result{RequeueAfter: 10 * time.Second})
 	}
 
@@ -1263,6 +2962,11 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 		result = updateReconcileResult(result, reconcile.Result{Requeue: true})
 	}
 
+	// The remaining backups also rely on the backup lock, see above
+	if lockErr != nil {
+		return result, nil
+	}
+
 	// Reconcile a manual backup as defined in the spec, and triggered by the end-user via
 	// annotation
 	if err := r.reconcileManualBackup(ctx, postgresCluster, repoResources.manualBackupJobs,
@@ -1271,9 +2975,78 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 		result = updateReconcileResult(result, reconcile.Result{Requeue: true})
 	}
 
+	// Reconcile the backups of any repos that follow the schedules of another repo
+	if err := r.reconcileFollowedBackups(ctx, postgresCluster,
+		repoResources.scheduledBackupJobs, sa, instances); err != nil {
+		log.Error(err, "unable to reconcile followed backups")
+		result = updateReconcileResult(result, reconcile.Result{Requeue: true})
+	}
+
+	// Reconcile an expire as defined in the spec, and triggered by the end-user via annotation
+	if err := r.reconcileExpire(ctx, postgresCluster, repoResources.expireJobs, sa,
+		instances); err != nil {
+		log.Error(err, "unable to reconcile expire")
+		result = updateReconcileResult(result, reconcile.Result{Requeue: true})
+	}
+
 	return result, nil
 }
 
+// +kubebuilder:rbac:groups=postgres-operator.crunchydata.com,resources=postgresclusters,verbs=patch
+
+// reconcileBackupLock ensures the backup lock annotation of the PostgresCluster reflects the
+// backup Jobs currently running for the cluster.  The lock is released once the Job holding it
+// has finished, and is otherwise acquired by any other backup Job that is running (e.g. a Job
+// created by a scheduled backup CronJob).
+func (r *Reconciler) reconcileBackupLock(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, repoResources *RepoResources) error {
+
+	holder := postgresCluster.GetAnnotations()[naming.PGBackRestBackupLock]
+
+	var running []*batchv1.Job
+	for _, job := range backupJobs(repoResources) {
+		if !jobCompleted(job) && !jobFailed(job) {
+			if job.GetName() == holder {
+				return nil
+			}
+			running = append(running, job)
+		}
+	}
+
+	if len(running) > 0 {
+		sort.Slice(running, func(i, j int) bool {
+			return running[i].CreationTimestamp.Before(&running[j].CreationTimestamp)
+		})
+		return r.setBackupLock(ctx, postgresCluster, running[0].GetName())
+	}
+	if holder != "" {
+		return r.setBackupLock(ctx, postgresCluster, "")
+	}
+	return nil
+}
+
+// setBackupLock sets the backup lock annotation of the PostgresCluster to the name of the backup
+// Job provided, or removes the annotation when the name is empty.
+func (r *Reconciler) setBackupLock(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, jobName string) error {
+
+	before := postgresCluster.DeepCopy()
+	// Make another copy so that Patch doesn't write back to the cluster.
+	intent := before.DeepCopy()
+	initialize.Annotations(intent)
+	if jobName == "" {
+		delete(intent.Annotations, naming.PGBackRestBackupLock)
+	} else {
+		intent.Annotations[naming.PGBackRestBackupLock] = jobName
+	}
+
+	err := errors.WithStack(r.patch(ctx, intent, client.MergeFrom(before)))
+	if err == nil {
+		postgresCluster.SetAnnotations(intent.GetAnnotations())
+	}
+	return err
+}
+
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=create;patch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;patch;delete
 
@@ -1496,40 +3269,325 @@ func (r *Reconciler) copyRestoreConfiguration(ctx context.Context,
 			return errors.WithStack(err)
 		}
 	}
-	metadata := naming.PGBackRestSSHSecret(sourceCluster)
-	// label according to PostgresCluster being created (not the source cluster)
-	metadata.Labels = naming.Merge(cluster.Spec.Metadata.GetLabelsOrNil(),
-		cluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
-		naming.PGBackRestRestoreConfigLabels(cluster.GetName()),
-	)
-	metadata.Annotations = naming.Merge(
-		cluster.Spec.Metadata.GetAnnotationsOrNil(),
-		cluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil())
-	restoreSSHConfig := &v1.Secret{
-		ObjectMeta: metadata,
-		Data:       sourceSSHConfig.Data,
-	}
-	// set ownership refs according to PostgresCluster being created (not the source cluster)
-	if err := r.setOwnerReference(cluster, restoreSSHConfig); err != nil {
-		return errors.WithStack(err)
+	metadata := naming.PGBackRestSSHSecret(sourceCluster)
+	// label according to PostgresCluster being created (not the source cluster)
+	metadata.Labels = naming.Merge(cluster.Spec.Metadata.GetLabelsOrNil(),
+		cluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
+		r.PGBackRestSelectorLabels,
+		naming.PGBackRestRestoreConfigLabels(cluster.GetName()),
+	)
+	metadata.Annotations = naming.Merge(
+		cluster.Spec.Metadata.GetAnnotationsOrNil(),
+		cluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil())
+	restoreSSHConfig := &v1.Secret{
+		ObjectMeta: metadata,
+		Data:       sourceSSHConfig.Data,
+	}
+	// set ownership refs according to PostgresCluster being created (not the source cluster)
+	if err := r.setOwnerReference(cluster, restoreSSHConfig); err != nil {
+		return errors.WithStack(err)
+	}
+	restoreSSHConfig.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("Secret"))
+	// Create metadata that can be used to override metadata (labels, annotations and ownership
+	// refs) in pgBackRest configuration resources.  This allows us to copy resources from
+	// another cluster, but ensure pertinent metadata details are set according to the cluster
+	// currently being reconciled (ensuring proper garbage collection, etc.).
+	overrideMetadata := &metav1.ObjectMeta{
+		Annotations:     metadata.GetAnnotations(),
+		Labels:          metadata.GetLabels(),
+		OwnerReferences: restoreSSHConfig.OwnerReferences,
+	}
+	if err := r.reconcilePGBackRestConfig(ctx, sourceCluster, overrideMetadata, repoHostName, "",
+		naming.ClusterPodService(origSourceCluster).Name, origSourceCluster.GetNamespace(),
+		[]string{sourceClusterInstance}, restoreSSHConfig); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// getPGBackRestCustomConfig returns the contents of the ConfigMap containing custom pgBackRest
+// configuration files, if one is referenced in the PostgresCluster spec
+func (r *Reconciler) getPGBackRestCustomConfig(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster) (map[string]string, error) {
+
+	ref := postgresCluster.Spec.Backups.PGBackRest.ConfigMap
+	if ref == nil {
+		return nil, nil
+	}
+
+	customConfig := &v1.ConfigMap{}
+	if err := r.Client.Get(ctx, client.ObjectKey{
+		Namespace: postgresCluster.GetNamespace(),
+		Name:      ref.Name,
+	}, customConfig); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return customConfig.Data, nil
+}
+
+// getPGBackRestConfigOptions returns the pgBackRest configuration options provided for the
+// PostgresCluster, i.e. the global options in the spec, as well as the options set in any custom
+// configuration files, including those projected from the ConfigMaps and Secrets referenced in
+// the spec.  ConfigMaps and Secrets that do not exist are ignored.
+func (r *Reconciler) getPGBackRestConfigOptions(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, customConfig map[string]string) (map[string]string, error) {
+
+	// projectedFiles returns the file names and contents projected from the provided data
+	projectedFiles := func(data map[string]string, items []v1.KeyToPath) map[string]string {
+		if len(items) == 0 {
+			return data
+		}
+		files := make(map[string]string, len(items))
+		for _, item := range items {
+			if value, ok := data[item.Key]; ok {
+				files[item.Path] = value
+			}
+		}
+		return files
+	}
+
+	files := []map[string]string{customConfig}
+	for _, projection := range postgresCluster.Spec.Backups.PGBackRest.Configuration {
+		key := client.ObjectKey{Namespace: postgresCluster.GetNamespace()}
+		switch {
+		case projection.ConfigMap != nil:
+			key.Name = projection.ConfigMap.Name
+			configMap := &v1.ConfigMap{}
+			if err := r.Client.Get(ctx, key, configMap); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, errors.WithStack(err)
+			}
+			files = append(files, projectedFiles(configMap.Data, projection.ConfigMap.Items))
+		case projection.Secret != nil:
+			key.Name = projection.Secret.Name
+			secret := &v1.Secret{}
+			if err := r.Client.Get(ctx, key, secret); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, errors.WithStack(err)
+			}
+			data := make(map[string]string, len(secret.Data))
+			for k, v := range secret.Data {
+				data[k] = string(v)
+			}
+			files = append(files, projectedFiles(data, projection.Secret.Items))
+		}
+	}
+
+	options := make(map[string]string)
+	for _, f := range files {
+		for k, v := range pgbackrest.ConfigOptions(f) {
+			options[k] = v
+		}
+	}
+	for k, v := range postgresCluster.Spec.Backups.PGBackRest.Global {
+		options[k] = v
+	}
+
+	return options, nil
+}
+
+// setRepoConfigStatus validates the configuration of any external (i.e. Azure, GCS or S3)
+// repositories, as well as the retention settings of any repository, in the PostgresCluster
+// spec using the provided pgBackRest configuration options, and then updates the status of each
+// repository accordingly.  An event is recorded whenever a new problem is found with the
+// configuration of a repository.
+func (r *Reconciler) setRepoConfigStatus(postgresCluster *v1beta1.PostgresCluster,
+	configOptions map[string]string) {
+
+	for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+		if repo.Volume != nil && repo.Retention == nil {
+			continue
+		}
+		for i := range postgresCluster.Status.PGBackRest.Repos {
+			repoStatus := &postgresCluster.Status.PGBackRest.Repos[i]
+			if repoStatus.Name != repo.Name {
+				continue
+			}
+
+			err := pgbackrest.ValidateRetention(repo, configOptions)
+			if err == nil && repo.Volume == nil {
+				err = pgbackrest.ValidateExternalRepo(repo, configOptions)
+			}
+			repoStatus.ConfigValid = initialize.Bool(err == nil)
+			if err == nil {
+				repoStatus.ConfigError = ""
+				break
+			}
+			if repoStatus.ConfigError != err.Error() {
+				r.Recorder.Eventf(postgresCluster, v1.EventTypeWarning, EventInvalidRepoConfig,
+					"The configuration of repo %s is invalid: %s", repo.Name, err.Error())
+			}
+			repoStatus.ConfigError = err.Error()
+			break
+		}
+	}
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+
+// reconcileRepoAccess verifies that the bucket or container of each Azure, GCS or S3 repository
+// with a valid configuration exists and can be accessed, by running the pgBackRest "repo-ls"
+// command within the Pod used to create stanzas.  Each repository is only checked until it is
+// found to be accessible with the current pgBackRest configuration.  The results are reported in
+// the status of each repository and in ConditionReposAccessible, and the bool returned is true
+// when any repository should be checked again, e.g. because it was not accessible.
+func (r *Reconciler) reconcileRepoAccess(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, configHash string) (bool, error) {
+
+	defer r.setReposAccessibleCondition(postgresCluster)
+
+	pending := []*v1beta1.RepoStatus{}
+	for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+		if repo.Volume != nil {
+			continue
+		}
+		for i := range postgresCluster.Status.PGBackRest.Repos {
+			repoStatus := &postgresCluster.Status.PGBackRest.Repos[i]
+			if repoStatus.Name == repo.Name && repoStatus.ConfigValid != nil &&
+				*repoStatus.ConfigValid && repoStatus.AccessConfigHash != configHash {
+				pending = append(pending, repoStatus)
+			}
+		}
+	}
+	if len(pending) == 0 {
+		return false, nil
+	}
+
+	// the dedicated repository host (if any) must be ready to run pgBackRest commands
+	if condition := meta.FindStatusCondition(postgresCluster.Status.Conditions,
+		ConditionRepoHostReady); condition != nil && condition.Status != metav1.ConditionTrue {
+		return false, nil
+	}
+
+	selector, containerName, err := getPGBackRestExecSelector(postgresCluster)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	pods := &v1.PodList{}
+	if err := r.Client.List(ctx, pods, client.InNamespace(postgresCluster.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return false, errors.WithStack(err)
+	}
+	candidates := execPods(pods.Items)
+	if len(candidates) == 0 {
+		return false, nil
+	}
+
+	podName := candidates[0].GetName()
+	exec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+		command ...string) error {
+		return r.PodExec(postgresCluster.GetNamespace(), podName, containerName,
+			stdin, stdout, stderr, command...)
+	}
+
+	retry := false
+	for _, repoStatus := range pending {
+		configHashMismatch, err := pgbackrest.Executor(exec).RepoAccessible(ctx, configHash,
+			regexRepoIndex.FindString(repoStatus.Name))
+
+		// access is checked once the configuration has propagated to the Pod
+		if configHashMismatch {
+			return true, nil
+		}
+
+		var commandErr *pgbackrest.CommandError
+		if err != nil && !errors.As(err, &commandErr) {
+			return false, err
+		}
+		if err != nil {
+			accessError := commandErr.Tail()
+			if accessError == "" {
+				accessError = commandErr.Err.Error()
+			}
+			if repoStatus.AccessError != accessError {
+				r.Recorder.Eventf(postgresCluster, v1.EventTypeWarning, EventRepoInaccessible,
+					"Unable to access repo %s: %s", repoStatus.Name, accessError)
+			}
+			repoStatus.AccessError = accessError
+			retry = true
+			continue
+		}
+
+		repoStatus.AccessConfigHash = configHash
+		repoStatus.AccessError = ""
+	}
+
+	return retry, nil
+}
+
+// setReposAccessibleCondition sets ConditionReposAccessible according to the access checked
+// for each Azure, GCS and S3 repository in the status of the PostgresCluster provided.  The
+// condition is removed when there are no such repositories.  When a repository cannot be
+// accessed, the reason of the condition identifies the problem (when known) using the HTTP
+// status reported by pgBackRest, e.g. "BucketNotFound" or "AccessDenied".
+func (r *Reconciler) setReposAccessibleCondition(postgresCluster *v1beta1.PostgresCluster) {
+
+	condition := metav1.Condition{
+		ObservedGeneration: postgresCluster.GetGeneration(),
+		Type:               ConditionReposAccessible,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ReposAccessible",
+		Message:            "pgBackRest repos are accessible",
 	}
-	restoreSSHConfig.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("Secret"))
-	// Create metadata that can be used to override metadata (labels, annotations and ownership
-	// refs) in pgBackRest configuration resources.  This allows us to copy resources from
-	// another cluster, but ensure pertinent metadata details are set according to the cluster
-	// currently being reconciled (ensuring proper garbage collection, etc.).
-	overrideMetadata := &metav1.ObjectMeta{
-		Annotations:     metadata.GetAnnotations(),
-		Labels:          metadata.GetLabels(),
-		OwnerReferences: restoreSSHConfig.OwnerReferences,
+
+	external := false
+	for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+		if repo.Volume != nil {
+			continue
+		}
+		external = true
+		for _, repoStatus := range postgresCluster.Status.PGBackRest.Repos {
+			if repoStatus.Name != repo.Name {
+				continue
+			}
+			switch {
+			case repoStatus.AccessError != "":
+				if condition.Status != metav1.ConditionFalse {
+					condition.Status = metav1.ConditionFalse
+					condition.Reason = repoAccessErrorReason(repoStatus.AccessError)
+					condition.Message = fmt.Sprintf("Unable to access repo %s: %s",
+						repoStatus.Name, repoStatus.AccessError)
+				}
+			case repoStatus.AccessConfigHash == "" && condition.Status == metav1.ConditionTrue:
+				condition.Status = metav1.ConditionUnknown
+				condition.Reason = "AccessNotChecked"
+				condition.Message = fmt.Sprintf("Access to repo %s has not been checked",
+					repoStatus.Name)
+			}
+		}
 	}
-	if err := r.reconcilePGBackRestConfig(ctx, sourceCluster, overrideMetadata, repoHostName, "",
-		naming.ClusterPodService(origSourceCluster).Name, origSourceCluster.GetNamespace(),
-		[]string{sourceClusterInstance}, restoreSSHConfig); err != nil {
-		return errors.WithStack(err)
+
+	if !external {
+		// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+		if len(postgresCluster.Status.Conditions) > 0 {
+			meta.RemoveStatusCondition(&postgresCluster.Status.Conditions,
+				ConditionReposAccessible)
+		}
+		return
 	}
 
-	return nil
+	meta.SetStatusCondition(&postgresCluster.Status.Conditions, condition)
+}
+
+// repoAccessErrorReason returns the reason for a condition describing the repo access error
+// provided, according to the HTTP status reported by pgBackRest, e.g. "HTTP request failed with
+// 404 (Not Found)".
+func repoAccessErrorReason(accessError string) string {
+	switch {
+	case strings.Contains(accessError, "failed with 404"):
+		return "BucketNotFound"
+	case strings.Contains(accessError, "failed with 401"),
+		strings.Contains(accessError, "failed with 403"):
+		return "AccessDenied"
+	}
+	return EventRepoInaccessible
 }
 
 // reconcileRepoHosts is responsible for reconciling the pgBackRest ConfigMaps and Secrets.
@@ -1564,6 +3622,8 @@ func (r *Reconciler) reconcilePGBackRestConfig(ctx context.Context,
 
 	backrestConfig := pgbackrest.CreatePGBackRestConfigMapIntent(postgresCluster, repoHostName,
 		configHash, serviceName, serviceNamespace, instanceNames)
+	backrestConfig.Labels = naming.Merge(backrestConfig.Labels, r.PGBackRestSelectorLabels,
+		naming.PGBackRestConfigLabels(postgresCluster.GetName()))
 	if metadataOverride != nil {
 		backrestConfig.ObjectMeta = overrideMetadata(backrestConfig.ObjectMeta)
 	} else if err := controllerutil.SetControllerReference(postgresCluster, backrestConfig,
@@ -1582,6 +3642,8 @@ func (r *Reconciler) reconcilePGBackRestConfig(ctx context.Context,
 	}
 
 	sshdConfig := pgbackrest.CreateSSHConfigMapIntent(postgresCluster)
+	sshdConfig.Labels = naming.Merge(sshdConfig.Labels, r.PGBackRestSelectorLabels,
+		naming.PGBackRestRepoHostLabels(postgresCluster.GetName()))
 	if metadataOverride != nil {
 		sshdConfig.ObjectMeta = overrideMetadata(sshdConfig.ObjectMeta)
 	} else if err := controllerutil.SetControllerReference(postgresCluster, &sshdConfig,
@@ -1600,6 +3662,8 @@ func (r *Reconciler) reconcilePGBackRestConfig(ctx context.Context,
 		log.Error(err, errMsg)
 		return err
 	}
+	sshdSecret.Labels = naming.Merge(sshdSecret.Labels, r.PGBackRestSelectorLabels,
+		naming.PGBackRestRepoHostLabels(postgresCluster.GetName()))
 	if metadataOverride != nil {
 		sshdSecret.ObjectMeta = overrideMetadata(sshdSecret.ObjectMeta)
 	} else if err := controllerutil.SetControllerReference(postgresCluster, &sshdSecret,
@@ -1669,6 +3733,9 @@ func (r *Reconciler) reconcilePGBackRestRBAC(ctx context.Context,
 	}}
 	role.Rules = pgbackrest.Permissions(postgresCluster)
 
+	// pgBackRest Pods using the ServiceAccount inherit its image pull secrets
+	sa.ImagePullSecrets = postgresCluster.Spec.Backups.PGBackRest.ServiceAccountImagePullSecrets
+
 	if err := r.apply(ctx, sa); err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -1683,13 +3750,22 @@ func (r *Reconciler) reconcilePGBackRestRBAC(ctx context.Context,
 }
 
 // reconcileDedicatedRepoHost is responsible for reconciling a pgBackRest dedicated repository host
-// StatefulSet according to a specific PostgresCluster custom resource.
+// StatefulSet according to a specific PostgresCluster custom resource.  The volumes of any
+// unowned repos provided are not mounted within the repository host.
 func (r *Reconciler) reconcileDedicatedRepoHost(ctx context.Context,
-	postgresCluster *v1beta1.PostgresCluster,
-	repoResources *RepoResources) (*appsv1.StatefulSet, error) {
+	postgresCluster *v1beta1.PostgresCluster, repoResources *RepoResources,
+	unownedRepos map[string]bool) (*appsv1.StatefulSet, error) {
 
 	log := logging.FromContext(ctx).WithValues("reconcileResource", "repoHost")
 
+	// tracks whether or not the repo host is not ready due to a failed pgBackRest check, either
+	// during this reconcile or (until the next check is due) a previous one
+	var checkErr error
+	var checkFailure string
+
+	// tracks how much longer the repo host pod must remain ready before the repo host is ready
+	var minReadyRemaining time.Duration
+
 	// ensure conditions are set before returning as needed by subsequent reconcile functions
 	defer func() {
 		repoHostReady := metav1.Condition{
@@ -1700,6 +3776,20 @@ func (r *Reconciler) reconcileDedicatedRepoHost(ctx context.Context,
 			repoHostReady.Status = metav1.ConditionUnknown
 			repoHostReady.Reason = "RepoHostStatusMissing"
 			repoHostReady.Message = "pgBackRest dedicated repository host status is missing"
+		} else if checkErr != nil {
+			repoHostReady.Status = metav1.ConditionFalse
+			repoHostReady.Reason = EventRepoHostCheckFailed
+			repoHostReady.Message = withCommandOutput("pgBackRest dedicated repository host "+
+				"is unable to reach one or more repositories", checkErr)
+		} else if checkFailure != "" {
+			repoHostReady.Status = metav1.ConditionFalse
+			repoHostReady.Reason = EventRepoHostCheckFailed
+			repoHostReady.Message = checkFailure
+		} else if minReadyRemaining > 0 {
+			repoHostReady.Status = metav1.ConditionFalse
+			repoHostReady.Reason = ReasonRepoHostNotStable
+			repoHostReady.Message = fmt.Sprintf("pgBackRest dedicated repository host has not "+
+				"been ready for the minimum of %s", repoHostMinReady(postgresCluster))
 		} else if postgresCluster.Status.PGBackRest.RepoHost.Ready {
 			repoHostReady.Status = metav1.ConditionTrue
 			repoHostReady.Reason = "RepoHostReady"
@@ -1709,37 +3799,489 @@ func (r *Reconciler) reconcileDedicatedRepoHost(ctx context.Context,
 			repoHostReady.Reason = "RepoHostNotReady"
 			repoHostReady.Message = "pgBackRest dedicated repository host is not ready"
 		}
-		meta.SetStatusCondition(&postgresCluster.Status.Conditions, repoHostReady)
-	}()
-	var isCreate bool
-	if len(repoResources.hosts) == 0 {
-		name := fmt.Sprintf("%s-%s", postgresCluster.GetName(), "repo-host")
-		repoResources.hosts = append(repoResources.hosts, &appsv1.StatefulSet{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: name,
-			}})
-		isCreate = true
-	} else {
-		sort.Slice(repoResources.hosts, func(i, j int) bool {
-			return repoResources.hosts[i].CreationTimestamp.Before(
-				&repoResources.hosts[j].CreationTimestamp)
-		})
+		meta.SetStatusCondition(&postgresCluster.Status.Conditions, repoHostReady)
+	}()
+	var isCreate bool
+	if len(repoResources.hosts) == 0 {
+		name := fmt.Sprintf("%s-%s", postgresCluster.GetName(), "repo-host")
+		repoResources.hosts = append(repoResources.hosts, &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			}})
+		isCreate = true
+	} else {
+		sort.Slice(repoResources.hosts, func(i, j int) bool {
+			return repoResources.hosts[i].CreationTimestamp.Before(
+				&repoResources.hosts[j].CreationTimestamp)
+		})
+	}
+	repoHostName := repoResources.hosts[0].Name
+
+	// Defer any rollout of an existing repo host while a backup is running to ensure the backup
+	// is not interrupted by a restart of the repo host Pod.  Completion of the backup triggers
+	// the reconcile needed to then roll out the repo host.
+	deferRollout := !isCreate && backupInProgress(repoResources)
+
+	repoHost, err := r.applyRepoHostIntent(ctx, postgresCluster, repoHostName, deferRollout,
+		unownedRepos)
+	if err != nil {
+		log.Error(err, "reconciling repository host")
+		return nil, err
+	}
+
+	if deferRollout && repoHost.Status.UpdateRevision != repoHost.Status.CurrentRevision {
+		r.Recorder.Event(postgresCluster, v1.EventTypeNormal, EventRepoHostRolloutDeferred,
+			"Deferring the rollout of the pgBackRest repository host until the running backup "+
+				"completes")
+	}
+
+	// keep the config hash last read from the repo host, which is only read again as needed
+	previous := postgresCluster.Status.PGBackRest.RepoHost
+	postgresCluster.Status.PGBackRest.RepoHost = getRepoHostStatus(repoHost)
+	if previous != nil {
+		postgresCluster.Status.PGBackRest.RepoHost.ActiveConfigHash = previous.ActiveConfigHash
+		// likewise keep the time of the last pgBackRest check, but only while the repo host
+		// remains ready, so that a restarted repo host is always checked again
+		if postgresCluster.Status.PGBackRest.RepoHost.Ready {
+			postgresCluster.Status.PGBackRest.RepoHost.LastCheckTime = previous.LastCheckTime
+		}
+	}
+
+	// if configured, the repo host is only ready once its pod has been ready for the minimum
+	// number of seconds in the spec.  StatefulSets of all supported Kubernetes versions do not
+	// support minReadySeconds, so this is determined using the Ready condition of the pod.
+	if minReady := repoHostMinReady(postgresCluster); minReady > 0 &&
+		postgresCluster.Status.PGBackRest.RepoHost.Ready {
+		pods := &v1.PodList{}
+		if err := r.Client.List(ctx, pods, client.InNamespace(postgresCluster.GetNamespace()),
+			client.MatchingLabelsSelector{
+				Selector: naming.PGBackRestDedicatedSelector(postgresCluster.GetName()),
+			}); err != nil {
+			postgresCluster.Status.PGBackRest.RepoHost.Ready = false
+			return repoHost, errors.WithStack(err)
+		}
+		minReadyRemaining = podsMinReadyRemaining(minReady, pods.Items, time.Now())
+		if minReadyRemaining > 0 {
+			postgresCluster.Status.PGBackRest.RepoHost.Ready = false
+		}
+	}
+
+	// if configured, verify the repositories can actually be reached from the repo host before
+	// considering it ready.  Since the check requires an exec, it runs at most once per
+	// repoHostCheckInterval, and the result of the previous check applies in the meantime.
+	if status := postgresCluster.Status.PGBackRest.RepoHost; status.Ready &&
+		repoHostCheckEnabled(postgresCluster) {
+		if repoHostCheckRemaining(status, time.Now()) == 0 {
+			now := metav1.Now()
+			status.LastCheckTime = &now
+			if err := r.checkDedicatedRepoHost(ctx, postgresCluster); err != nil {
+				checkErr = err
+				status.Ready = false
+				r.Recorder.Event(postgresCluster, v1.EventTypeWarning, EventRepoHostCheckFailed,
+					err.Error())
+			}
+		} else if condition := meta.FindStatusCondition(postgresCluster.Status.Conditions,
+			ConditionRepoHostReady); condition != nil &&
+			condition.Reason == EventRepoHostCheckFailed {
+			checkFailure = condition.Message
+			status.Ready = false
+		}
+	}
+
+	if isCreate {
+		r.Recorder.Eventf(postgresCluster, v1.EventTypeNormal, EventRepoHostCreated,
+			"created pgBackRest repository host %s/%s", repoHost.TypeMeta.Kind, repoHostName)
+	}
+
+	return repoHost, nil
+}
+
+// repoHostCheckRemaining returns how long until the pgBackRest "check" command should run again
+// within the dedicated repository host according to the status provided.  Zero is returned when
+// the check is due, including when it has not yet run.
+func repoHostCheckRemaining(status *v1beta1.RepoHostStatus, now time.Time) time.Duration {
+	if status == nil || status.LastCheckTime == nil {
+		return 0
+	}
+	if remaining := status.LastCheckTime.Add(repoHostCheckInterval).Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// repoHostCheckEnabled returns true if the readiness of the dedicated repository host should
+// account for the result of the pgBackRest "check" command.  Since the check requires a stanza,
+// it is only enabled once stanzas have been created for all repositories.
+func repoHostCheckEnabled(postgresCluster *v1beta1.PostgresCluster) bool {
+	repoHost := postgresCluster.Spec.Backups.PGBackRest.RepoHost
+	if repoHost == nil || repoHost.Dedicated == nil ||
+		repoHost.Dedicated.CheckReadiness == nil || !*repoHost.Dedicated.CheckReadiness {
+		return false
+	}
+
+	if len(postgresCluster.Status.PGBackRest.Repos) == 0 {
+		return false
+	}
+	for _, repoStatus := range postgresCluster.Status.PGBackRest.Repos {
+		if !repoStatus.StanzaCreated {
+			return false
+		}
+	}
+	return true
+}
+
+// repoHostMinReady returns the minimum amount of time the dedicated repository host pod must be
+// ready before the repository host is considered ready, or zero if no minimum is configured.
+func repoHostMinReady(postgresCluster *v1beta1.PostgresCluster) time.Duration {
+	repoHost := postgresCluster.Spec.Backups.PGBackRest.RepoHost
+	if repoHost == nil || repoHost.Dedicated == nil || repoHost.Dedicated.MinReadySeconds == nil {
+		return 0
+	}
+	return time.Duration(*repoHost.Dedicated.MinReadySeconds) * time.Second
+}
+
+// podsMinReadyRemaining returns how much longer any of the Pods provided must remain ready in
+// order to have been ready for minReady as of now.  Since the Ready condition of a Pod
+// transitions whenever one of its containers restarts, a Pod that becomes ready only briefly
+// never satisfies the minimum.  The full minimum is returned when none of the Pods are ready.
+func podsMinReadyRemaining(minReady time.Duration, pods []v1.Pod, now time.Time) time.Duration {
+	remaining := minReady
+	for _, pod := range pods {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type != v1.PodReady || condition.Status != v1.ConditionTrue {
+				continue
+			}
+			if r := minReady - now.Sub(condition.LastTransitionTime.Time); r < remaining {
+				remaining = r
+			}
+		}
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining
+}
+
+// repoHostMetrics returns the metrics configuration for the dedicated repository host, or nil
+// if metrics are not enabled
+func repoHostMetrics(postgresCluster *v1beta1.PostgresCluster) *v1beta1.DedicatedRepoMetrics {
+	repoHost := postgresCluster.Spec.Backups.PGBackRest.RepoHost
+	if repoHost == nil || repoHost.Dedicated == nil {
+		return nil
+	}
+	return repoHost.Dedicated.Metrics
+}
+
+// +kubebuilder:rbac:groups="",resources=services,verbs=get
+// +kubebuilder:rbac:groups="",resources=services,verbs=create;delete;patch
+
+// reconcileRepoHostMetricsService writes the Service that exposes the metrics served within the
+// dedicated repository host, or deletes it when metrics are not enabled.
+func (r *Reconciler) reconcileRepoHostMetricsService(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster) (*v1.Service, error) {
+
+	service := &v1.Service{ObjectMeta: naming.PGBackRestRepoHostMetrics(postgresCluster)}
+	service.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("Service"))
+
+	metrics := repoHostMetrics(postgresCluster)
+	if metrics == nil {
+		// Metrics are disabled; delete the Service if it exists. Check the client
+		// cache first using Get.
+		key := client.ObjectKeyFromObject(service)
+		err := errors.WithStack(r.Client.Get(ctx, key, service))
+		if err == nil {
+			err = errors.WithStack(r.deleteControlled(ctx, postgresCluster, service))
+		}
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	err := errors.WithStack(r.setControllerReference(postgresCluster, service))
+
+	service.Annotations = naming.Merge(
+		postgresCluster.Spec.Metadata.GetAnnotationsOrNil(),
+		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil())
+	service.Labels = naming.Merge(
+		postgresCluster.Spec.Metadata.GetLabelsOrNil(),
+		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
+		naming.PGBackRestDedicatedLabels(postgresCluster.GetName()))
+
+	// The metrics are served by a container (e.g. a sidecar) that is not managed by the
+	// operator, so target the port number rather than a named ContainerPort.
+	service.Spec.Type = v1.ServiceTypeClusterIP
+	service.Spec.Selector = naming.PGBackRestDedicatedLabels(postgresCluster.GetName())
+	service.Spec.Ports = []v1.ServicePort{{
+		Name:       naming.PortMetrics,
+		Port:       metrics.Port,
+		Protocol:   v1.ProtocolTCP,
+		TargetPort: intstr.FromInt(int(metrics.Port)),
+	}}
+
+	if err == nil {
+		err = errors.WithStack(r.apply(ctx, service))
+	}
+	return service, err
+}
+
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=create;delete;patch
+
+// reconcileRepoHostServiceMonitor writes the Prometheus Operator ServiceMonitor that targets the
+// Service exposing the metrics of the dedicated repository host, or deletes it when metrics are
+// not enabled.  Nothing is done when the ServiceMonitor API is not installed.
+func (r *Reconciler) reconcileRepoHostServiceMonitor(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster) error {
+
+	objectMeta := naming.PGBackRestRepoHostMetrics(postgresCluster)
+	monitor := &unstructured.Unstructured{}
+	monitor.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor",
+	})
+	monitor.SetNamespace(objectMeta.Namespace)
+	monitor.SetName(objectMeta.Name)
+
+	metrics := repoHostMetrics(postgresCluster)
+	if metrics == nil {
+		// Metrics are disabled; delete the ServiceMonitor if it exists.
+		err := r.Client.Get(ctx, client.ObjectKeyFromObject(monitor), monitor)
+		if err == nil {
+			err = r.deleteControlled(ctx, postgresCluster, monitor)
+		}
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return client.IgnoreNotFound(errors.WithStack(err))
+	}
+
+	err := errors.WithStack(r.setControllerReference(postgresCluster, monitor))
+
+	monitor.SetAnnotations(naming.Merge(
+		postgresCluster.Spec.Metadata.GetAnnotationsOrNil(),
+		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil()))
+	monitor.SetLabels(naming.Merge(
+		postgresCluster.Spec.Metadata.GetLabelsOrNil(),
+		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
+		naming.PGBackRestDedicatedLabels(postgresCluster.GetName())))
+
+	path := metrics.Path
+	if path == "" {
+		path = "/metrics"
+	}
+	matchLabels := map[string]interface{}{}
+	for k, v := range naming.PGBackRestDedicatedLabels(postgresCluster.GetName()) {
+		matchLabels[k] = v
+	}
+	if err == nil {
+		err = errors.WithStack(unstructured.SetNestedField(monitor.Object, map[string]interface{}{
+			"endpoints": []interface{}{map[string]interface{}{
+				"path": path,
+				"port": naming.PortMetrics,
+			}},
+			"selector": map[string]interface{}{
+				"matchLabels": matchLabels,
+			},
+		}, "spec"))
+	}
+
+	if err == nil {
+		err = r.patch(ctx, monitor, client.Apply, client.ForceOwnership)
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		err = errors.WithStack(err)
+	}
+	return err
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+
+// checkDedicatedRepoHost runs the pgBackRest "check" command within the dedicated repository
+// host, returning an error if the repositories cannot be reached.
+func (r *Reconciler) checkDedicatedRepoHost(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster) error {
+
+	selector, containerName, err := getPGBackRestExecSelector(postgresCluster)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	pods := &v1.PodList{}
+	if err := r.Client.List(ctx, pods, client.InNamespace(postgresCluster.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return errors.WithStack(err)
+	}
+	if len(pods.Items) != 1 {
+		return errors.WithStack(
+			errors.New("invalid number of Pods found when attempting to check repo host"))
+	}
+
+	exec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+		command ...string) error {
+		return r.PodExec(postgresCluster.GetNamespace(), pods.Items[0].GetName(), containerName,
+			stdin, stdout, stderr, command...)
+	}
+
+	return pgbackrest.Executor(exec).Check(ctx)
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+
+// reconcileRepoHostConfigHash records, in the status of the dedicated repository host, both the
+// desired pgBackRest config hash and the hash currently mounted within the repository host.  The
+// mounted hash is only read (using an exec) while it differs from the desired hash, e.g. while
+// waiting for configuration changes to propagate to the repository host.
+func (r *Reconciler) reconcileRepoHostConfigHash(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, configHash string) error {
+
+	status := postgresCluster.Status.PGBackRest.RepoHost
+	if !pgbackrest.DedicatedRepoHostEnabled(postgresCluster) || status == nil {
+		return nil
+	}
+
+	status.DesiredConfigHash = configHash
+	if !status.Ready || status.ActiveConfigHash == configHash {
+		return nil
+	}
+
+	selector, containerName, err := getPGBackRestExecSelector(postgresCluster)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	pods := &v1.PodList{}
+	if err := r.Client.List(ctx, pods, client.InNamespace(postgresCluster.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return errors.WithStack(err)
+	}
+	if len(pods.Items) != 1 {
+		return nil
+	}
+
+	exec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+		command ...string) error {
+		return r.PodExec(postgresCluster.GetNamespace(), pods.Items[0].GetName(), containerName,
+			stdin, stdout, stderr, command...)
+	}
+
+	active, err := pgbackrest.Executor(exec).ConfigHash(ctx)
+	if err != nil {
+		return err
+	}
+	status.ActiveConfigHash = active
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+
+// reconcileVersionMismatch compares the version of pgBackRest installed within the "database"
+// containers of the instances and the "pgbackrest" container of the dedicated repo host, and sets
+// ConditionVersionMismatch accordingly.  A Warning event is also recorded when the versions
+// differ.  Since the version installed only changes along with the image of a container, a single
+// container is exec'd into for each distinct image, and only when the set of images differs from
+// the set last compared.
+func (r *Reconciler) reconcileVersionMismatch(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster) error {
+
+	pods := &v1.PodList{}
+	if err := r.Client.List(ctx, pods, client.InNamespace(postgresCluster.GetNamespace()),
+		client.MatchingLabels{naming.LabelCluster: postgresCluster.GetName()}); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// find a running Pod and container for each distinct image, preferring Pods that are ready
+	type execTarget struct{ pod, container string }
+	targets := make(map[string]execTarget)
+	for _, pod := range execPods(pods.Items) {
+		_, instance := pod.GetLabels()[naming.LabelInstance]
+		_, repoHost := pod.GetLabels()[naming.LabelPGBackRestDedicated]
+		if (!instance && !repoHost) || pod.Status.Phase != v1.PodRunning {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			if (instance && container.Name != naming.ContainerDatabase) ||
+				(repoHost && container.Name != naming.PGBackRestRepoContainerName) {
+				continue
+			}
+			if _, ok := targets[container.Image]; !ok {
+				targets[container.Image] = execTarget{pod: pod.GetName(), container: container.Name}
+			}
+		}
+	}
+
+	images := make([]string, 0, len(targets))
+	for image := range targets {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+
+	imagesHash, err := safeHash32(func(w io.Writer) (err error) {
+		for i := 0; err == nil && i < len(images); i++ {
+			_, err = fmt.Fprintln(w, images[i])
+		}
+		return
+	})
+	if err != nil || imagesHash == postgresCluster.Status.PGBackRest.VersionCheckHash {
+		return err
 	}
-	repoHostName := repoResources.hosts[0].Name
-	repoHost, err := r.applyRepoHostIntent(ctx, postgresCluster, repoHostName)
-	if err != nil {
-		log.Error(err, "reconciling repository host")
-		return nil, err
+
+	// a single image (or none at all) always provides a single version of pgBackRest
+	if len(images) < 2 {
+		// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+		if len(postgresCluster.Status.Conditions) > 0 {
+			meta.RemoveStatusCondition(&postgresCluster.Status.Conditions,
+				ConditionVersionMismatch)
+		}
+		postgresCluster.Status.PGBackRest.VersionCheckHash = imagesHash
+		return nil
 	}
 
-	postgresCluster.Status.PGBackRest.RepoHost = getRepoHostStatus(repoHost)
+	versions := make(map[string][]string)
+	for _, image := range images {
+		target := targets[image]
+		exec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			return r.PodExec(postgresCluster.GetNamespace(), target.pod, target.container,
+				stdin, stdout, stderr, command...)
+		}
+		version, err := pgbackrest.Executor(exec).Version(ctx)
+		if err != nil {
+			return err
+		}
+		versions[version] = append(versions[version], image)
+	}
 
-	if isCreate {
-		r.Recorder.Eventf(postgresCluster, v1.EventTypeNormal, EventRepoHostCreated,
-			"created pgBackRest repository host %s/%s", repoHost.TypeMeta.Kind, repoHostName)
+	condition := metav1.Condition{
+		ObservedGeneration: postgresCluster.GetGeneration(),
+		Type:               ConditionVersionMismatch,
+		Status:             metav1.ConditionFalse,
+		Reason:             "VersionsMatch",
+	}
+	if len(versions) == 1 {
+		for version := range versions {
+			condition.Message = fmt.Sprintf("pgBackRest %s is installed in every container",
+				version)
+		}
+	} else {
+		found := make([]string, 0, len(versions))
+		for version, versionImages := range versions {
+			found = append(found, fmt.Sprintf("%s (%s)", version,
+				strings.Join(versionImages, ", ")))
+		}
+		sort.Strings(found)
+
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = EventVersionMismatch
+		condition.Message = "different versions of pgBackRest are installed: " +
+			strings.Join(found, "; ")
+		r.Recorder.Event(postgresCluster, v1.EventTypeWarning, EventVersionMismatch,
+			condition.Message)
 	}
+	meta.SetStatusCondition(&postgresCluster.Status.Conditions, condition)
+	postgresCluster.Status.PGBackRest.VersionCheckHash = imagesHash
 
-	return repoHost, nil
+	return nil
 }
 
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;patch;delete
@@ -1752,58 +4294,24 @@ func (r *Reconciler) reconcileManualBackup(ctx context.Context,
 
 	manualAnnotation := postgresCluster.GetAnnotations()[naming.PGBackRestBackup]
 	manualStatus := postgresCluster.Status.PGBackRest.ManualBackup
+	manual := postgresCluster.Spec.Backups.PGBackRest.Manual
+
+	request := backupRequest{
+		jobType:         naming.BackupManual,
+		annotation:      naming.PGBackRestBackup,
+		id:              manualAnnotation,
+		condition:       ConditionManualBackupSuccessful,
+		reason:          "ManualBackup",
+		completeMessage: "Manual backup completed successfully",
+		failedMessage:   "Manual backup did not complete successfully",
+	}
 
 	// first update status and cleanup according to any existing manual backup Jobs observed in
 	// the environment
-	var currentBackupJob *batchv1.Job
-	if len(manualBackupJobs) > 0 {
-
-		currentBackupJob = manualBackupJobs[0]
-		completed := jobCompleted(currentBackupJob)
-		failed := jobFailed(currentBackupJob)
-		backupID := currentBackupJob.GetAnnotations()[naming.PGBackRestBackup]
-
-		if manualStatus != nil && manualStatus.ID == backupID {
-			if completed {
-				meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
-					ObservedGeneration: postgresCluster.GetGeneration(),
-					Type:               ConditionManualBackupSuccessful,
-					Status:             metav1.ConditionTrue,
-					Reason:             "ManualBackupComplete",
-					Message:            "Manual backup completed successfully",
-				})
-			} else if failed {
-				meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
-					ObservedGeneration: postgresCluster.GetGeneration(),
-					Type:               ConditionManualBackupSuccessful,
-					Status:             metav1.ConditionFalse,
-					Reason:             "ManualBackupFailed",
-					Message:            "Manual backup did not complete successfully",
-				})
-			}
-
-			// update the manual backup status based on the current status of the manual backup Job
-			manualStatus.StartTime = currentBackupJob.Status.StartTime
-			manualStatus.CompletionTime = currentBackupJob.Status.CompletionTime
-			manualStatus.Succeeded = currentBackupJob.Status.Succeeded
-			manualStatus.Failed = currentBackupJob.Status.Failed
-			manualStatus.Active = currentBackupJob.Status.Active
-			if completed || failed {
-				manualStatus.Finished = true
-			}
-		}
-
-		// If the Job is finished with a "completed" or "failure" condition, and the Job is not
-		// annotated per the current value of the "pgbackrest-backup" annotation, then delete it so
-		// that a new Job can be generated with the proper (i.e. new) backup ID.  This means any
-		// Jobs that are in progress will complete before being deleted to trigger a new backup
-		// per a new value for the annotation (unless the user manually deletes the Job).
-		if completed || failed {
-			if manualAnnotation != "" && backupID != manualAnnotation {
-				return errors.WithStack(r.Client.Delete(ctx, currentBackupJob,
-					client.PropagationPolicy(metav1.DeletePropagationBackground)))
-			}
-		}
+	currentBackupJob, deleted, err := r.observeBackupRequestJob(ctx, postgresCluster,
+		manualBackupJobs, manualStatus, request)
+	if deleted || err != nil {
+		return err
 	}
 
 	// pgBackRest connects to a PostgreSQL instance that is not in recovery to
@@ -1817,10 +4325,13 @@ func (r *Reconciler) reconcileManualBackup(ctx context.Context,
 		}
 	}
 
+	// An offline (cold) backup is taken while PostgreSQL is stopped, and therefore does not
+	// require a writable cluster.
+	offline := manual != nil && manual.Online != nil && !*manual.Online
+
 	// nothing to reconcile if there is no postgres or if a manual backup has not been
 	// requested
-	if !clusterWritable || manualAnnotation == "" ||
-		postgresCluster.Spec.Backups.PGBackRest.Manual == nil {
+	if (!clusterWritable && !offline) || manualAnnotation == "" || manual == nil {
 		return nil
 	}
 
@@ -1846,6 +4357,232 @@ func (r *Reconciler) reconcileManualBackup(ctx context.Context,
 		return nil
 	}
 
+	// Do not start a backup while an in-place restore is in progress.  The manual backup will be
+	// reconciled once the restore is complete.
+	if restoringInPlace(postgresCluster) {
+		return nil
+	}
+
+	// Users should specify the repo for the command using the "manual.repoName" field in the spec,
+	// and not using the "--repo" option in the "manual.options" field.  Therefore, record a
+	// warning event and return if a "--repo" option is found.  Reconciliation will then be
+	// reattempted when "--repo" is removed from "manual.options" and the spec is updated.
+	request.repoName = manual.RepoName
+	request.options = manual.Options
+	for _, opt := range request.options {
+		if strings.Contains(opt, "--repo") {
+			r.Recorder.Eventf(postgresCluster, v1.EventTypeWarning, "InvalidManualBackup",
+				"Option '--repo' is not allowed: please use the 'repoName' field instead.",
+				request.repoName)
+			return nil
+		}
+	}
+
+	// Similarly, the type of backup is defined using either the "manual.type" field or the
+	// "--type" option, but not both, since pgBackRest rejects an option that is set twice.
+	if backupType := manual.Type; backupType != "" {
+		for _, opt := range request.options {
+			if strings.HasPrefix(opt, "--type") {
+				r.Recorder.Event(postgresCluster, v1.EventTypeWarning, "InvalidManualBackup",
+					"Option '--type' is not allowed along with the 'type' field: "+
+						"please use only one of them.")
+				return nil
+			}
+		}
+		request.options = append(append([]string{}, request.options...), "--type="+backupType)
+	}
+
+	// Only start an offline backup once Patroni is paused, which ensures Patroni neither starts
+	// PostgreSQL again nor fails over once PostgreSQL is stopped for the backup.  Updating the
+	// spec to pause Patroni triggers the reconcile needed to then proceed with the backup.
+	if offline {
+		if !patroni.ClusterPaused(postgresCluster) {
+			r.Recorder.Event(postgresCluster, v1.EventTypeWarning, "InvalidManualBackup",
+				"An offline backup requires Patroni to be paused: please set 'pause' to true "+
+					"in the Patroni dynamic configuration and then stop PostgreSQL.")
+			return nil
+		}
+		request.options = append(append([]string{}, request.options...), "--no-online")
+	}
+
+	return r.reconcileBackupRequestJob(ctx, postgresCluster, currentBackupJob, serviceAccount,
+		instances, request)
+}
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;patch;delete
+
+// reconcileFinalBackup is responsible for reconciling a final full pgBackRest backup that is
+// initiated by the end-user via annotation.  Once the final backup completes successfully, all
+// scheduled backups for the cluster are suspended (see finalBackupComplete).
+func (r *Reconciler) reconcileFinalBackup(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, finalBackupJobs []*batchv1.Job,
+	serviceAccount *v1.ServiceAccount, instances *observedInstances) error {
+
+	finalAnnotation := postgresCluster.GetAnnotations()[naming.PGBackRestFinalBackup]
+	finalStatus := postgresCluster.Status.PGBackRest.FinalBackup
+
+	request := backupRequest{
+		jobType:    naming.BackupFinal,
+		annotation: naming.PGBackRestFinalBackup,
+		id:         finalAnnotation,
+		condition:  ConditionFinalBackupSuccessful,
+		reason:     "FinalBackup",
+		completeMessage: "Final backup completed successfully, scheduled backups " +
+			"are now suspended",
+		failedMessage: "Final backup did not complete successfully",
+		// the final backup is always a full backup
+		options: []string{"--type=" + full},
+	}
+
+	// first update status and cleanup according to any existing final backup Jobs observed in
+	// the environment
+	currentBackupJob, deleted, err := r.observeBackupRequestJob(ctx, postgresCluster,
+		finalBackupJobs, finalStatus, request)
+	if deleted || err != nil {
+		return err
+	}
+
+	// nothing to reconcile if a final backup has not been requested
+	if finalAnnotation == "" || postgresCluster.Spec.Backups.PGBackRest.FinalBackup == nil {
+		return nil
+	}
+
+	// if there is an existing status, see if a new backup id has been provided, and if so reset
+	// the status and proceed with reconciling a new backup
+	if finalStatus == nil || finalStatus.ID != finalAnnotation {
+		finalStatus = &v1beta1.PGBackRestJobStatus{
+			ID: finalAnnotation,
+		}
+		// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+		if len(postgresCluster.Status.Conditions) > 0 {
+			// Remove an existing final backup condition if present.  It will be
+			// created again as needed based on the newly reconciled backup Job.
+			meta.RemoveStatusCondition(&postgresCluster.Status.Conditions,
+				ConditionFinalBackupSuccessful)
+		}
+		postgresCluster.Status.PGBackRest.FinalBackup = finalStatus
+	}
+
+	// if the status shows the Job is no longer in progress, then simply exit (which means a Job
+	// that has reached a "completed" or "failed" status is no longer reconciled)
+	if finalStatus.Finished {
+		return nil
+	}
+
+	// pgBackRest connects to a PostgreSQL instance that is not in recovery to
+	// initiate a backup. Similar to "writable" but not exactly.
+	clusterWritable := false
+	for _, instance := range instances.forCluster {
+		writable, known := instance.IsWritable()
+		if writable && known {
+			clusterWritable = true
+			break
+		}
+	}
+	if !clusterWritable {
+		return nil
+	}
+
+	request.repoName = postgresCluster.Spec.Backups.PGBackRest.FinalBackup.RepoName
+	return r.reconcileBackupRequestJob(ctx, postgresCluster, currentBackupJob, serviceAccount,
+		instances, request)
+}
+
+// backupRequest describes a pgBackRest backup that is requested by the end-user via annotation,
+// i.e. a manual or final backup, as needed to reconcile its Job
+type backupRequest struct {
+	// the type of backup Job, e.g. manual or final
+	jobType naming.BackupJobType
+
+	// the annotation used to request the backup, and the ID of the backup currently requested
+	annotation, id string
+
+	// the repo to back up, and the pgBackRest options for the backup
+	repoName string
+	options  []string
+
+	// any labels identifying the Job in addition to those of its type of backup
+	labels map[string]string
+
+	// generates the spec of the Job, when other than generateBackupJobSpecIntent (e.g. to
+	// expire backups rather than take one)
+	jobSpec func(postgresCluster *v1beta1.PostgresCluster, selector, containerName, repoName,
+		serviceAccountName, configName string, labels, annotations map[string]string,
+		opts ...string) (*batchv1.JobSpec, error)
+
+	// the condition reporting the outcome of the backup Job, the prefix of its reasons, and its
+	// messages once the Job completes or fails
+	condition, reason              string
+	completeMessage, failedMessage string
+}
+
+// observeBackupRequestJob updates the status provided, and the condition of the request, according
+// to the most recent Job for the requested backup.  If that Job is finished and does not belong
+// to the backup currently requested, then it is deleted so that a new Job can be generated with
+// the proper (i.e. new) backup ID.  This means any Jobs that are in progress will complete before
+// being deleted to trigger a new backup (unless the user manually deletes the Job).  The most
+// recent Job (if any) is returned, along with whether or not it was deleted.
+func (r *Reconciler) observeBackupRequestJob(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, jobs []*batchv1.Job,
+	status *v1beta1.PGBackRestJobStatus, request backupRequest) (*batchv1.Job, bool, error) {
+
+	if len(jobs) == 0 {
+		return nil, false, nil
+	}
+
+	job := jobs[0]
+	completed := jobCompleted(job)
+	failed := jobFailed(job)
+	backupID := job.GetAnnotations()[request.annotation]
+
+	if status != nil && status.ID == backupID {
+		if completed {
+			meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
+				ObservedGeneration: postgresCluster.GetGeneration(),
+				Type:               request.condition,
+				Status:             metav1.ConditionTrue,
+				Reason:             request.reason + "Complete",
+				Message:            request.completeMessage,
+			})
+		} else if failed {
+			meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
+				ObservedGeneration: postgresCluster.GetGeneration(),
+				Type:               request.condition,
+				Status:             metav1.ConditionFalse,
+				Reason:             request.reason + "Failed",
+				Message:            request.failedMessage,
+			})
+		}
+
+		// update the status based on the current status of the backup Job
+		status.StartTime = job.Status.StartTime
+		status.CompletionTime = job.Status.CompletionTime
+		status.Succeeded = job.Status.Succeeded
+		status.Failed = job.Status.Failed
+		status.Active = jobActive(job)
+		if completed || failed {
+			status.Finished = true
+		}
+	}
+
+	if (completed || failed) && request.id != "" && backupID != request.id {
+		return job, true, errors.WithStack(r.Client.Delete(ctx, job,
+			client.PropagationPolicy(metav1.DeletePropagationBackground)))
+	}
+
+	return job, false, nil
+}
+
+// reconcileBackupRequestJob creates the Job for a requested backup (or expire) once the cluster
+// is ready for it, i.e. once the dedicated repository host (if enabled) is ready, the replica
+// create backup is complete, and a stanza has been created for the repo.  Only one backup runs at a time for the
+// cluster, so the backup lock is acquired before the Job is created.  An existing Job is not
+// updated.
+func (r *Reconciler) reconcileBackupRequestJob(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, currentBackupJob *batchv1.Job,
+	serviceAccount *v1.ServiceAccount, instances *observedInstances,
+	request backupRequest) error {
+
 	// determine if the dedicated repository host is ready (if enabled) using the repo host ready
 	// condition, and return if not
 	if pgbackrest.DedicatedRepoHostEnabled(postgresCluster) {
@@ -1863,43 +4600,48 @@ func (r *Reconciler) reconcileManualBackup(ctx context.Context,
 		return nil
 	}
 
-	// Verify that status exists for the repo configured for the manual backup, and that a stanza
-	// has been created, before proceeding.  If either conditions are not true, then simply return
+	// Verify that status exists for the repo configured for the backup, and that a stanza has
+	// been created, before proceeding.  If either conditions are not true, then simply return
 	// without requeuing and record and event (subsequent events, e.g. successful stanza creation,
 	// writing of the proper repo status, adding a missing repo, etc. will trigger the reconciles
 	// needed to try again).
 	var statusFound, stanzaCreated bool
-	repoName := postgresCluster.Spec.Backups.PGBackRest.Manual.RepoName
 	for _, repo := range postgresCluster.Status.PGBackRest.Repos {
-		if repo.Name == repoName {
+		if repo.Name == request.repoName {
 			statusFound = true
 			stanzaCreated = repo.StanzaCreated
 		}
 	}
 	if !statusFound {
 		r.Recorder.Eventf(postgresCluster, v1.EventTypeWarning, "InvalidBackupRepo",
-			"Unable to find status for %q as configured for a manual backup.  Please ensure "+
-				"this repo is defined in the spec.", repoName)
+			"Unable to find status for %q as configured for a %s backup.  Please ensure "+
+				"this repo is defined in the spec.", request.repoName, request.jobType)
 		return nil
 	}
 	if !stanzaCreated {
 		r.Recorder.Eventf(postgresCluster, v1.EventTypeWarning, "StanzaNotCreated",
-			"Stanza not created for %q as specified for a manual backup", repoName)
+			"Stanza not created for %q as specified for a %s backup", request.repoName,
+			request.jobType)
 		return nil
 	}
 
-	// Users should specify the repo for the command using the "manual.repoName" field in the spec,
-	// and not using the "--repo" option in the "manual.options" field.  Therefore, record a
-	// warning event and return if a "--repo" option is found.  Reconciliation will then be
-	// reattempted when "--repo" is removed from "manual.options" and the spec is updated.
-	backupOpts := postgresCluster.Spec.Backups.PGBackRest.Manual.Options
-	for _, opt := range backupOpts {
-		if strings.Contains(opt, "--repo") {
-			r.Recorder.Eventf(postgresCluster, v1.EventTypeWarning, "InvalidManualBackup",
-				"Option '--repo' is not allowed: please use the 'repoName' field instead.",
-				repoName)
-			return nil
+	// Only one backup runs at a time for the cluster, so wait for any other backup Job holding
+	// the backup lock to finish.  The completion of that Job triggers the reconcile needed to
+	// then proceed with the backup.
+	lockHolder := postgresCluster.GetAnnotations()[naming.PGBackRestBackupLock]
+	if lockHolder != "" &&
+		(currentBackupJob == nil || currentBackupJob.GetName() != lockHolder) {
+		return nil
+	}
+
+	// An existing Job is left as is, since the Pod template of a Job cannot be changed.  Any
+	// changes to the backup (e.g. to its annotations, excluded paths or resources) only apply to
+	// the Jobs of later backups.  Only ensure the Job holds the backup lock.
+	if currentBackupJob != nil {
+		if lockHolder != currentBackupJob.GetName() {
+			return r.setBackupLock(ctx, postgresCluster, currentBackupJob.GetName())
 		}
+		return nil
 	}
 
 	// get pod name and container name as needed to exec into the proper pod and create
@@ -1909,10 +4651,8 @@ func (r *Reconciler) reconcileManualBackup(ctx context.Context,
 		return errors.WithStack(err)
 	}
 
-	// Leverage the observedInstances to determine the current primary.  This is needed to
-	// mount the proper configuration file to the backup when running without a dedicated repo
-	// host.  And even when running with a repo host, this ensure a backup is only attempted
-	// when the primary can be properly identified.
+	// Leverage the observedInstances to determine the current primary.  This ensures the
+	// backup is only attempted when the primary can be properly identified.
 	var primaryInstance string
 	for _, instance := range instances.forCluster {
 		if isPrimary, _ := instance.IsPrimary(); isPrimary {
@@ -1925,60 +4665,280 @@ func (r *Reconciler) reconcileManualBackup(ctx context.Context,
 		// case leader election does not result in the event needed to trigger an another
 		// reconcile.  Once we ensure proper reconciliation following leader election, nil can be
 		// returned instead.
-		return errors.WithStack(
-			errors.New("unable to find primary when reconciling manual pgBackRest backup Job"))
+		return errors.Errorf(
+			"unable to find primary when reconciling %s pgBackRest backup Job", request.jobType)
 	}
 	// set the name of the pgbackrest config file that will be mounted to the backup Job
-	configName := primaryInstance + ".conf"
-	if pgbackrest.DedicatedRepoHostEnabled(postgresCluster) {
-		configName = pgbackrest.CMRepoKey
+	configName := backupJobConfigName(postgresCluster, instances, nil)
+
+	// create the backup Job
+	backupJob := &batchv1.Job{}
+	backupJob.ObjectMeta = naming.PGBackRestBackupJob(postgresCluster)
+
+	var labels, annotations map[string]string
+	labels = naming.Merge(postgresCluster.Spec.Metadata.GetLabelsOrNil(),
+		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
+		r.PGBackRestSelectorLabels,
+		naming.PGBackRestBackupJobLabels(postgresCluster.GetName(), request.repoName,
+			request.jobType),
+		request.labels)
+	annotations = naming.Merge(postgresCluster.Spec.Metadata.GetAnnotationsOrNil(),
+		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil(),
+		map[string]string{
+			request.annotation: request.id,
+		})
+	// The backup type is only added to the labels of the Job itself (and not to those of its
+	// Pod template), since the Pod template of an existing Job cannot be changed.
+	backupJob.ObjectMeta.Labels = naming.Merge(labels, backupTypeLabels(request.options))
+	backupJob.ObjectMeta.Annotations = annotations
+
+	generateJobSpec := generateBackupJobSpecIntent
+	if request.jobSpec != nil {
+		generateJobSpec = request.jobSpec
+	}
+	spec, err := generateJobSpec(postgresCluster, selector.String(), containerName,
+		request.repoName, serviceAccount.GetName(), configName, labels, annotations,
+		request.options...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	backupJob.Spec = *spec
+
+	// set gvk and ownership refs
+	backupJob.SetGroupVersionKind(batchv1.SchemeGroupVersion.WithKind("Job"))
+	if err := controllerutil.SetControllerReference(postgresCluster, backupJob,
+		r.Client.Scheme()); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// acquire the backup lock prior to creating the backup Job
+	if err := r.setBackupLock(ctx, postgresCluster, backupJob.GetName()); err != nil {
+		return err
+	}
+
+	// server-side apply the backup Job intent
+	return errors.WithStack(r.apply(ctx, backupJob))
+}
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;patch;delete
+
+// reconcileFollowedBackups is responsible for reconciling the backups of any repos that follow
+// the schedules of another repo, e.g. a repo in another region used for disaster recovery.  Once
+// the most recent scheduled backup of the followed repo completes, a backup of the same type is
+// taken in each repo following it.  These backups are labeled in the same manner as the backups
+// created by the backup CronJobs, which means they are reported in the status of scheduled
+// backups alongside the backups of the followed repo.
+func (r *Reconciler) reconcileFollowedBackups(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, scheduledBackupJobs []*batchv1.Job,
+	serviceAccount *v1.ServiceAccount, instances *observedInstances) error {
+
+	// Find the most recent completed backup created by the CronJobs of each repo.  The backups
+	// taken in following repos are never followed themselves, which avoids cycles.
+	latest := make(map[string]*batchv1.Job)
+	for _, job := range scheduledBackupJobs {
+		if job.GetAnnotations()[naming.PGBackRestBackupSource] != "" || !jobCompleted(job) {
+			continue
+		}
+		repoName := job.GetLabels()[naming.LabelPGBackRestRepo]
+		if latest[repoName] == nil ||
+			latest[repoName].Status.CompletionTime.Before(job.Status.CompletionTime) {
+			latest[repoName] = job
+		}
+	}
+
+	// Find the first repo that has not yet taken the most recent backup of the repo it follows.
+	// Only one backup runs at a time, so the backups of any other following repos are
+	// reconciled once this backup is complete.
+	var repoName string
+	var source *batchv1.Job
+	for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+		if repo.FollowSchedulesOf == "" || repo.FollowSchedulesOf == repo.Name ||
+			latest[repo.FollowSchedulesOf] == nil {
+			continue
+		}
+		followed := false
+		for _, job := range scheduledBackupJobs {
+			if job.GetLabels()[naming.LabelPGBackRestRepo] == repo.Name &&
+				job.GetAnnotations()[naming.PGBackRestBackupSource] ==
+					latest[repo.FollowSchedulesOf].GetName() {
+				followed = true
+				break
+			}
+		}
+		if !followed {
+			repoName, source = repo.Name, latest[repo.FollowSchedulesOf]
+			break
+		}
+	}
+	if source == nil {
+		return nil
+	}
+
+	// Do not start a backup when the backup CronJobs are suspended, or while an in-place
+	// restore is in progress.  Similarly, wait for the next backup window to open (which also
+	// triggers a reconcile).
+	windowOpen, _ := scheduledBackupWindow(postgresCluster, time.Now())
+	if (postgresCluster.Spec.Standby != nil && postgresCluster.Spec.Standby.Enabled) ||
+		finalBackupComplete(postgresCluster) || restoringInPlace(postgresCluster) || !windowOpen {
+		return nil
+	}
+
+	// pgBackRest connects to a PostgreSQL instance that is not in recovery to
+	// initiate a backup. Similar to "writable" but not exactly.
+	clusterWritable := false
+	for _, instance := range instances.forCluster {
+		writable, known := instance.IsWritable()
+		if writable && known {
+			clusterWritable = true
+			break
+		}
+	}
+	if !clusterWritable {
+		return nil
+	}
+
+	// Only the latest backup of a following repo is needed to determine whether the backup of
+	// the followed repo has been taken, so delete any other finished backups of the repo
+	for _, job := range scheduledBackupJobs {
+		if job.GetLabels()[naming.LabelPGBackRestRepo] == repoName &&
+			job.GetAnnotations()[naming.PGBackRestBackupSource] != "" &&
+			(jobCompleted(job) || jobFailed(job)) {
+			if err := client.IgnoreNotFound(r.Client.Delete(ctx, job,
+				client.PropagationPolicy(
+					cleanupPropagationPolicy(postgresCluster, job.GetLabels())))); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+
+	// Take a backup of the same type as the backup being followed.  The backup is created in the
+	// same manner as any other requested backup, which means it waits for a stanza to be created
+	// for the following repo, and for any other backup Job holding the backup lock to finish.
+	backupType := source.GetLabels()[naming.LabelPGBackRestCronJob]
+	return r.reconcileBackupRequestJob(ctx, postgresCluster, nil, serviceAccount, instances,
+		backupRequest{
+			jobType:    naming.BackupScheduled,
+			annotation: naming.PGBackRestBackupSource,
+			id:         source.GetName(),
+			repoName:   repoName,
+			options:    []string{"--type=" + backupType},
+			labels:     map[string]string{naming.LabelPGBackRestCronJob: backupType},
+		})
+}
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;patch;delete
+
+// reconcileExpire is responsible for reconciling a Job that runs the pgBackRest "expire" command
+// as initiated by the end-user via annotation.  This expires backups according to the retention
+// configured for a repo without taking a new backup, e.g. after retention has been lowered.
+func (r *Reconciler) reconcileExpire(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, expireJobs []*batchv1.Job,
+	serviceAccount *v1.ServiceAccount, instances *observedInstances) error {
+
+	expireAnnotation := postgresCluster.GetAnnotations()[naming.PGBackRestExpire]
+	expireStatus := postgresCluster.Status.PGBackRest.Expire
+	expire := postgresCluster.Spec.Backups.PGBackRest.Expire
+
+	request := backupRequest{
+		jobType:         naming.BackupExpire,
+		annotation:      naming.PGBackRestExpire,
+		id:              expireAnnotation,
+		condition:       ConditionExpireSuccessful,
+		reason:          "Expire",
+		completeMessage: "Expire completed successfully",
+		failedMessage:   "Expire did not complete successfully",
+		jobSpec:         generateExpireJobSpecIntent,
+	}
+
+	// first update status and cleanup according to any existing expire Jobs observed in the
+	// environment
+	currentJob, deleted, err := r.observeBackupRequestJob(ctx, postgresCluster, expireJobs,
+		expireStatus, request)
+	if deleted || err != nil {
+		return err
+	}
+
+	// nothing to reconcile if an expire has not been requested
+	if expireAnnotation == "" || expire == nil {
+		return nil
+	}
+
+	// if there is an existing status, see if a new expire id has been provided, and if so reset
+	// the status and proceed with reconciling a new expire
+	if expireStatus == nil || expireStatus.ID != expireAnnotation {
+		expireStatus = &v1beta1.PGBackRestJobStatus{
+			ID: expireAnnotation,
+		}
+		// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+		if len(postgresCluster.Status.Conditions) > 0 {
+			// Remove an existing expire condition if present.  It will be created again as
+			// needed based on the newly reconciled expire Job.
+			meta.RemoveStatusCondition(&postgresCluster.Status.Conditions,
+				ConditionExpireSuccessful)
+		}
+		postgresCluster.Status.PGBackRest.Expire = expireStatus
 	}
 
-	// create the backup Job
-	backupJob := &batchv1.Job{}
-	backupJob.ObjectMeta = naming.PGBackRestBackupJob(postgresCluster)
-	if currentBackupJob != nil {
-		backupJob.ObjectMeta.Name = currentBackupJob.ObjectMeta.Name
+	// if the status shows the Job is no longer in progress, then simply exit (which means a Job
+	// that has reached a "completed" or "failed" status is no longer reconciled)
+	if expireStatus.Finished {
+		return nil
 	}
 
-	var labels, annotations map[string]string
-	labels = naming.Merge(postgresCluster.Spec.Metadata.GetLabelsOrNil(),
-		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
-		naming.PGBackRestBackupJobLabels(postgresCluster.GetName(), repoName,
-			naming.BackupManual))
-	annotations = naming.Merge(postgresCluster.Spec.Metadata.GetAnnotationsOrNil(),
-		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil(),
-		map[string]string{
-			naming.PGBackRestBackup: manualAnnotation,
-		})
-	backupJob.ObjectMeta.Labels = labels
-	backupJob.ObjectMeta.Annotations = annotations
-
-	spec, err := generateBackupJobSpecIntent(postgresCluster, selector.String(), containerName,
-		repoName, serviceAccount.GetName(), configName, labels, annotations, backupOpts...)
-	if err != nil {
-		return errors.WithStack(err)
+	// the pgBackRest configuration mounted to the Job is determined using the current primary,
+	// which must therefore be running
+	clusterWritable := false
+	for _, instance := range instances.forCluster {
+		writable, known := instance.IsWritable()
+		if writable && known {
+			clusterWritable = true
+			break
+		}
+	}
+	if !clusterWritable {
+		return nil
 	}
-	backupJob.Spec = *spec
 
-	// set gvk and ownership refs
-	backupJob.SetGroupVersionKind(batchv1.SchemeGroupVersion.WithKind("Job"))
-	if err := controllerutil.SetControllerReference(postgresCluster, backupJob,
-		r.Client.Scheme()); err != nil {
-		return errors.WithStack(err)
+	// Do not expire backups while an in-place restore is in progress, since the backup being
+	// restored could be removed.
+	if restoringInPlace(postgresCluster) {
+		return nil
 	}
 
-	// server-side apply the backup Job intent
-	if err := r.apply(ctx, backupJob); err != nil {
-		return errors.WithStack(err)
+	// Similar to manual backups, the repo is specified using the "expire.repoName" field in the
+	// spec, and not using the "--repo" option in the "expire.options" field.
+	request.repoName = expire.RepoName
+	request.options = expire.Options
+	for _, opt := range request.options {
+		if strings.Contains(opt, "--repo") {
+			r.Recorder.Event(postgresCluster, v1.EventTypeWarning, "InvalidExpire",
+				"Option '--repo' is not allowed: please use the 'repoName' field instead.")
+			return nil
+		}
 	}
 
-	return nil
+	// An expire cannot run alongside a backup, so it is created in the same manner as a
+	// requested backup, i.e. once the backup lock is held by the expire Job
+	return r.reconcileBackupRequestJob(ctx, postgresCluster, currentJob, serviceAccount,
+		instances, request)
+}
+
+// finalBackupComplete returns true if a final backup has been requested via annotation for the
+// PostgresCluster provided, and the backup Job for the current backup ID completed successfully.
+// Scheduled backups are suspended for as long as this remains true.
+func finalBackupComplete(postgresCluster *v1beta1.PostgresCluster) bool {
+	finalAnnotation := postgresCluster.GetAnnotations()[naming.PGBackRestFinalBackup]
+	if finalAnnotation == "" || postgresCluster.Status.PGBackRest == nil {
+		return false
+	}
+	finalStatus := postgresCluster.Status.PGBackRest.FinalBackup
+	return finalStatus != nil && finalStatus.ID == finalAnnotation &&
+		finalStatus.Finished && finalStatus.Succeeded > 0
 }
 
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;patch;delete
 
-// reconcileReplicaCreateBackup is responsible for reconciling a full pgBackRest backup for the
+// reconcileReplicaCreateBackup is responsible for reconciling a pgBackRest backup for the
 // cluster as required to create replicas
 func (r *Reconciler) reconcileReplicaCreateBackup(ctx context.Context,
 	postgresCluster *v1beta1.PostgresCluster, instances *observedInstances,
@@ -1993,6 +4953,12 @@ func (r *Reconciler) reconcileReplicaCreateBackup(ctx context.Context,
 		}
 	}
 
+	// The reason and message used in the condition when replica creation is not yet possible.
+	// These are updated below to distinguish a backup that is intentionally paused from one that
+	// is blocked, e.g. waiting for the cluster or repo to become ready.
+	notPossibleReason := "RepoBackupNotComplete"
+	notPossibleMessage := "pgBackRest replica creation is not currently possible"
+
 	// ensure condition is set before returning as needed by subsequent reconcile functions
 	defer func() {
 		replicaCreate := metav1.Condition{
@@ -2009,9 +4975,8 @@ func (r *Reconciler) reconcileReplicaCreateBackup(ctx context.Context,
 			replicaCreate.Message = "pgBackRest replica creation is now possible"
 		} else {
 			replicaCreate.Status = metav1.ConditionFalse
-			replicaCreate.Reason = "RepoBackupNotComplete"
-			replicaCreate.Message = "pgBackRest replica creation is not currently " +
-				"possible"
+			replicaCreate.Reason = notPossibleReason
+			replicaCreate.Message = notPossibleMessage
 		}
 		meta.SetStatusCondition(&postgresCluster.Status.Conditions, replicaCreate)
 	}()
@@ -2027,11 +4992,37 @@ func (r *Reconciler) reconcileReplicaCreateBackup(ctx context.Context,
 		}
 	}
 
+	if !clusterWritable {
+		notPossibleReason, notPossibleMessage = replicaCreateNotWritable(postgresCluster)
+	}
+
+	// Once completion of the backup has been recorded in the status, the successful Job is no
+	// longer needed, so delete it rather than leaving it in place until it is otherwise cleaned up
+	if replicaCreateRepoStatus != nil && replicaCreateRepoStatus.ReplicaCreateBackupComplete {
+		for _, job := range replicaCreateBackupJobs {
+			if !jobCompleted(job) {
+				continue
+			}
+			if err := client.IgnoreNotFound(r.Client.Delete(ctx, job,
+				client.PropagationPolicy(metav1.DeletePropagationBackground))); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+
 	// return early when there is no postgres, no repo, or the backup is already complete.
 	if !clusterWritable || replicaCreateRepoStatus == nil || replicaCreateRepoStatus.ReplicaCreateBackupComplete {
 		return nil
 	}
 
+	// do not start a backup while an in-place restore is in progress
+	if restoringInPlace(postgresCluster) {
+		notPossibleReason = ReasonBackupSuspended
+		notPossibleMessage = "pgBackRest replica creation is suspended while an in-place " +
+			"restore is in progress"
+		return nil
+	}
+
 	// determine if the replica create repo is ready using the "PGBackRestReplicaRepoReady" condition
 	var replicaRepoReady bool
 	condition := meta.FindStatusCondition(postgresCluster.Status.Conditions, ConditionReplicaRepoReady)
@@ -2046,7 +5037,7 @@ func (r *Reconciler) reconcileReplicaCreateBackup(ctx context.Context,
 		return errors.WithStack(err)
 	}
 
-	// Find the name of the current primary.  Only proceed if/when the primary can be identified
+	// Only proceed if/when the current primary can be identified
 	pods := &v1.PodList{}
 	if err := r.Client.List(ctx, pods, client.InNamespace(postgresCluster.GetNamespace()),
 		client.MatchingLabelsSelector{Selector: selector}); err != nil {
@@ -2057,13 +5048,6 @@ func (r *Reconciler) reconcileReplicaCreateBackup(ctx context.Context,
 			errors.New("invalid number of Pods found when attempting to create replica create " +
 				"backup"))
 	}
-	primaryInstance := pods.Items[0].GetLabels()[naming.LabelInstance]
-
-	// set the name of the pgbackrest config file that will be mounted to the backup Job
-	configName := primaryInstance + ".conf"
-	if pgbackrest.DedicatedRepoHostEnabled(postgresCluster) {
-		configName = pgbackrest.CMRepoKey
-	}
 
 	// determine if the dedicated repository host is ready using the repo host ready status
 	var dedicatedRepoReady bool
@@ -2076,8 +5060,25 @@ func (r *Reconciler) reconcileReplicaCreateBackup(ctx context.Context,
 	// PostgresCluster status as required
 	var job *batchv1.Job
 	if len(replicaCreateBackupJobs) > 0 {
+		// Only the most recent Job is relevant, so delete any others that have accumulated
+		// (e.g. if Jobs were rapidly recreated)
+		sort.Slice(replicaCreateBackupJobs, func(i, j int) bool {
+			return replicaCreateBackupJobs[j].CreationTimestamp.Before(
+				&replicaCreateBackupJobs[i].CreationTimestamp)
+		})
+		for _, extra := range replicaCreateBackupJobs[1:] {
+			if err := client.IgnoreNotFound(r.Client.Delete(ctx, extra,
+				client.PropagationPolicy(metav1.DeletePropagationBackground))); err != nil {
+				return errors.WithStack(err)
+			}
+		}
 		job = replicaCreateBackupJobs[0]
+	}
+
+	// set the name of the pgbackrest config file that will be mounted to the backup Job
+	configName := backupJobConfigName(postgresCluster, instances, job)
 
+	if job != nil {
 		failed := jobFailed(job)
 		completed := jobCompleted(job)
 
@@ -2091,9 +5092,9 @@ func (r *Reconciler) reconcileReplicaCreateBackup(ctx context.Context,
 		// - The job has failed.  The Job will be deleted and recreated to try again.
 		// - The replica creation repo has changed since the Job was created.  Delete and recreate
 		//   with the Job with the proper repo configured.
-		// - The "config" annotation has changed, indicating there is a new primary.  Delete and
-		//   recreate the Job with the proper config mounted (applicable when a dedicated repo
-		//   host is not enabled).
+		// - The "config" annotation has changed, indicating the instance whose config is mounted
+		//   no longer exists, or that a dedicated repo host has been enabled or disabled.  Delete
+		//   and recreate the Job with the proper config mounted.
 		// - The "config hash" annotation has changed, indicating a configuration change has been
 		//   made in the spec (specifically a change to the config for an external repo).  Delete
 		//   and recreate the Job with proper hash per the current config.
@@ -2112,38 +5113,51 @@ func (r *Reconciler) reconcileReplicaCreateBackup(ctx context.Context,
 			replicaCreateRepoStatus.ReplicaCreateBackupComplete = true
 			return nil
 		}
+
+		// Otherwise the Job is still running and is left as is.  The Pod template of a Job
+		// cannot be changed, so any other changes to the backup (e.g. to its annotations,
+		// excluded paths or resources) only apply to Jobs created once this one is replaced.
+		return nil
 	}
 
 	dedicatedEnabled := pgbackrest.DedicatedRepoHostEnabled(postgresCluster)
-	// return if no job has been created and the replica repo or the dedicated repo host  is not
-	// ready
-	if job == nil && ((dedicatedEnabled && !dedicatedRepoReady) || !replicaRepoReady) {
+	// return if the replica repo or the dedicated repo host is not ready.  Note that the replica
+	// repo is not ready while its stanza is being created again, e.g. for changed configuration,
+	// as determined by reconcileStanzaCreate.
+	if (dedicatedEnabled && !dedicatedRepoReady) || !replicaRepoReady {
+		notPossibleReason = ReasonRepoNotReady
+		notPossibleMessage = "pgBackRest replica creation is waiting for the replica create " +
+			"repo to become ready"
 		return nil
 	}
 
-	// create the backup Job, and populate ObjectMeta based on whether or not a Job already exists
+	// determine the type of backup to take
+	backupType := replicaCreateBackupType(postgresCluster, replicaCreateRepoStatus)
+
+	// create the backup Job
 	backupJob := &batchv1.Job{}
 	backupJob.ObjectMeta = naming.PGBackRestBackupJob(postgresCluster)
-	if job != nil {
-		backupJob.ObjectMeta.Name = job.ObjectMeta.Name
-	}
 
 	var labels, annotations map[string]string
 	labels = naming.Merge(postgresCluster.Spec.Metadata.GetLabelsOrNil(),
 		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
+		r.PGBackRestSelectorLabels,
 		naming.PGBackRestBackupJobLabels(postgresCluster.GetName(),
-			postgresCluster.Spec.Backups.PGBackRest.Repos[0].Name, naming.BackupReplicaCreate))
+			replicaCreateRepoName, naming.BackupReplicaCreate))
 	annotations = naming.Merge(postgresCluster.Spec.Metadata.GetAnnotationsOrNil(),
 		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil(),
 		map[string]string{
 			naming.PGBackRestCurrentConfig: configName,
 			naming.PGBackRestConfigHash:    configHash,
+			naming.PGBackRestBackupType:    backupType,
 		})
-	backupJob.ObjectMeta.Labels = labels
+	backupOpts := []string{"--type=" + backupType}
+	backupJob.ObjectMeta.Labels = naming.Merge(labels, backupTypeLabels(backupOpts))
 	backupJob.ObjectMeta.Annotations = annotations
 
 	spec, err := generateBackupJobSpecIntent(postgresCluster, selector.String(), containerName,
-		replicaCreateRepoName, serviceAccount.GetName(), configName, labels, annotations)
+		replicaCreateRepoName, serviceAccount.GetName(), configName, labels, annotations,
+		backupOpts...)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -2163,41 +5177,169 @@ func (r *Reconciler) reconcileReplicaCreateBackup(ctx context.Context,
 	return nil
 }
 
+// replicaCreateBackupType returns the type of backup to take in the replica create repo as
+// needed to create replicas.  The type configured for the repo is used when set.  Otherwise a
+// full backup is taken, unless the backup counts in the status of the repo show that it already
+// contains a full backup, e.g. because an existing repo was attached to the cluster.  In that
+// case, an incremental backup is sufficient.
+func replicaCreateBackupType(postgresCluster *v1beta1.PostgresCluster,
+	repoStatus *v1beta1.RepoStatus) string {
+
+	for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+		if repo.Name == repoStatus.Name && repo.ReplicaCreateBackupType != "" {
+			return repo.ReplicaCreateBackupType
+		}
+	}
+	if repoStatus.BackupCounts != nil && repoStatus.BackupCounts.Full > 0 {
+		return incremental
+	}
+	return full
+}
+
+// backupJobConfigName returns the name of the pgBackRest configuration file to mount to a backup
+// (or expire) Job.  A dedicated repository host always uses its own configuration, and otherwise
+// the shared Job configuration is used, which does not depend on the current primary.  Since the
+// Pod template of a Job cannot be updated, this avoids recreating a Job simply because the
+// primary changed (e.g. following a failover) since the Job was created.  For the same reason, an
+// existing Job that mounts the configuration of an instance that still exists (as Jobs did prior
+// to the shared configuration) keeps that configuration.
+func backupJobConfigName(postgresCluster *v1beta1.PostgresCluster,
+	instances *observedInstances, job *batchv1.Job) string {
+
+	if pgbackrest.DedicatedRepoHostEnabled(postgresCluster) {
+		return pgbackrest.CMRepoKey
+	}
+
+	if current := jobConfigName(postgresCluster, job); current != "" {
+		instance := strings.TrimSuffix(current, ".conf")
+		if _, ok := instances.byName[instance]; ok && instance != current {
+			return current
+		}
+	}
+
+	return pgbackrest.CMJobKey
+}
+
+// jobConfigName returns the name of the pgBackRest configuration file, as generated by the
+// operator, that is mounted to the Job provided.  An empty string is returned if the Job is nil
+// or does not mount a generated configuration file.
+func jobConfigName(postgresCluster *v1beta1.PostgresCluster, job *batchv1.Job) string {
+	if job == nil {
+		return ""
+	}
+	configMapName := naming.PGBackRestConfig(postgresCluster).Name
+	for _, volume := range job.Spec.Template.Spec.Volumes {
+		if volume.Name != pgbackrest.ConfigVol || volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.ConfigMap == nil || source.ConfigMap.Name != configMapName {
+				continue
+			}
+			for _, item := range source.ConfigMap.Items {
+				if item.Key != pgbackrest.ConfigHashKey {
+					return item.Key
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// replicaCreateNotWritable returns the reason and message used within ConditionReplicaCreate
+// when the replica create backup cannot run because the cluster is not writable.  This
+// distinguishes clusters where backups are intentionally paused (i.e. shutdown or standby
+// clusters) from clusters that are not yet ready for a backup.
+func replicaCreateNotWritable(cluster *v1beta1.PostgresCluster) (string, string) {
+	switch {
+	case cluster.Spec.Shutdown != nil && *cluster.Spec.Shutdown:
+		return ReasonBackupSuspended,
+			"pgBackRest replica creation is suspended while the cluster is shutdown"
+	case cluster.Spec.Standby != nil && cluster.Spec.Standby.Enabled:
+		return ReasonBackupSuspended,
+			"pgBackRest replica creation is suspended while the cluster is a standby"
+	case !patroni.ClusterBootstrapped(cluster):
+		return ReasonClusterNotBootstrapped,
+			"pgBackRest replica creation is waiting for the cluster to be bootstrapped"
+	default:
+		return "RepoBackupNotComplete",
+			"pgBackRest replica creation is waiting for a writable primary"
+	}
+}
+
 // reconcileRepos is responsible for reconciling any pgBackRest repositories configured
-// for the cluster
+// for the cluster.  Since the repository volumes are independent of one another, they are
+// reconciled concurrently, with the number of repos reconciled at any one time bounded by the
+// number of repo workers configured for the Reconciler.  The volumes of any unowned repos
+// provided are not reconciled, ensuring they are never adopted.
 func (r *Reconciler) reconcileRepos(ctx context.Context,
-	postgresCluster *v1beta1.PostgresCluster, extConfigHashes map[string]string) (string, error) {
+	postgresCluster *v1beta1.PostgresCluster, extConfigHashes map[string]string,
+	unownedRepos map[string]bool) (string, error) {
 
 	log := logging.FromContext(ctx).WithValues("reconcileResource", "repoVolume")
 
 	errors := []error{}
 	errMsg := "reconciling repository volume"
-	repoVols := []*v1.PersistentVolumeClaim{}
+	repos := postgresCluster.Spec.Backups.PGBackRest.Repos
 	var replicaCreateRepoName string
-	for i, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
-		// the repo at index 0 is the replica creation repo
-		if i == 0 {
-			replicaCreateRepoName = repo.Name
-		}
-		// we only care about reconciling repo volumes, so ignore everything else
-		if repo.Volume == nil {
+	// the repo at index 0 is the replica creation repo
+	if len(repos) > 0 {
+		replicaCreateRepoName = repos[0].Name
+	}
+
+	// apply the intent for each repo volume, storing the results by repo index to ensure
+	// consistent ordering regardless of the order in which the workers complete
+	repoVols := make([]*v1.PersistentVolumeClaim, len(repos))
+	applyErrs := make([]error, len(repos))
+	workers := make(chan struct{}, r.repoWorkers())
+	var wg sync.WaitGroup
+	for i := range repos {
+		// we only care about reconciling repo volumes, so ignore everything else, as well as any
+		// existing volumes that are not owned by the cluster (and therefore must not be adopted)
+		if repos[i].Volume == nil || unownedRepos[repos[i].Name] {
 			continue
 		}
-		repo, err := r.applyRepoVolumeIntent(ctx, postgresCluster, &repo.Volume.VolumeClaimSpec,
-			repo.Name)
-		if err != nil {
-			log.Error(err, errMsg)
-			errors = append(errors, err)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+			repoVols[i], applyErrs[i] = r.applyRepoVolumeIntent(ctx, postgresCluster,
+				&repos[i].Volume.VolumeClaimSpec, repos[i].Name)
+		}(i)
+	}
+	wg.Wait()
+
+	// Handle any errors once all workers are done.  This is done serially since handling a
+	// volume error can update the status of the PostgresCluster.
+	reconciledVols := []*v1.PersistentVolumeClaim{}
+	for i := range repos {
+		if applyErrs[i] != nil {
+			err := r.handlePersistentVolumeClaimError(postgresCluster, applyErrs[i])
+			if err != nil {
+				log.Error(err, errMsg)
+				errors = append(errors, err)
+			}
 			continue
 		}
-		if repo != nil {
-			repoVols = append(repoVols, repo)
+		if repoVols[i] != nil {
+			reconciledVols = append(reconciledVols, repoVols[i])
 		}
 	}
 
+	// Let users know when reordering the repos has changed the replica creation repo, since the
+	// status of the previous replica create backup is reset below, triggering a new backup.
+	if previous := previousReplicaCreateRepo(postgresCluster.Status.PGBackRest.Repos,
+		replicaCreateRepoName); previous != "" {
+		r.Recorder.Eventf(postgresCluster, v1.EventTypeWarning, EventReplicaCreateRepoChanged,
+			"The replica creation repo changed from %s to %s because the first repo in the spec "+
+				"changed; a new backup of %s will be taken for replica creation",
+			previous, replicaCreateRepoName, replicaCreateRepoName)
+	}
+
 	postgresCluster.Status.PGBackRest.Repos =
-		getRepoVolumeStatus(postgresCluster.Status.PGBackRest.Repos, repoVols, extConfigHashes,
-			replicaCreateRepoName)
+		getRepoVolumeStatus(postgresCluster.Status.PGBackRest.Repos, reconciledVols,
+			extConfigHashes, replicaCreateRepoName)
 
 	if len(errors) > 0 {
 		return "", utilerrors.NewAggregate(errors)
@@ -2206,6 +5348,24 @@ func (r *Reconciler) reconcileRepos(ctx context.Context,
 	return replicaCreateRepoName, nil
 }
 
+// repoWorkers returns the maximum number of pgBackRest repositories that can be reconciled
+// concurrently for a single PostgresCluster
+func (r *Reconciler) repoWorkers() int {
+	if r.RepoWorkers > 0 {
+		return r.RepoWorkers
+	}
+	return defaultRepoWorkers
+}
+
+// configHashMismatchInterval returns how long to wait before reattempting stanza creation
+// following a pgBackRest config hash mismatch
+func (r *Reconciler) configHashMismatchInterval() time.Duration {
+	if r.ConfigHashMismatchInterval > 0 {
+		return r.ConfigHashMismatchInterval
+	}
+	return defaultConfigHashMismatchInterval
+}
+
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list
 // +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
 
@@ -2214,11 +5374,19 @@ func (r *Reconciler) reconcileRepos(ctx context.Context,
 // function is false, this indicates that a pgBackRest config hash mismatch was identified that
 // prevented the "pgbackrest stanza-create" command from running (with a config has mismatch
 // indicating that pgBackRest configuration as stored in the pgBackRest ConfigMap has not yet
-// propagated to the Pod).
+// propagated to the Pod).  When stanzas are managed externally, stanza-create is never run and
+// the stanzas of every repo are simply reported as created.
 func (r *Reconciler) reconcileStanzaCreate(ctx context.Context,
 	postgresCluster *v1beta1.PostgresCluster,
 	instances *observedInstances, configHash string) (bool, error) {
 
+	// tracks any error returned by the stanza-create command, as reported in status
+	var stanzaCreateErr error
+
+	// stanzas are managed by the operator unless explicitly disabled
+	manageStanza := postgresCluster.Spec.Backups.PGBackRest.ManageStanza == nil ||
+		*postgresCluster.Spec.Backups.PGBackRest.ManageStanza
+
 	// ensure conditions are set before returning as needed by subsequent reconcile functions
 	defer func() {
 		var replicaCreateRepoStatus *v1beta1.RepoStatus
@@ -2241,6 +5409,11 @@ func (r *Reconciler) reconcileStanzaCreate(ctx context.Context,
 			replicaCreateRepoReady.Status = metav1.ConditionUnknown
 			replicaCreateRepoReady.Reason = "RepoStatusMissing"
 			replicaCreateRepoReady.Message = "Status is missing for the replica creation repo"
+		} else if !manageStanza {
+			replicaCreateRepoReady.Status = metav1.ConditionTrue
+			replicaCreateRepoReady.Reason = "StanzaExternallyManaged"
+			replicaCreateRepoReady.Message = "pgBackRest replica create repo stanza is " +
+				"managed externally"
 		} else if replicaCreateRepoStatus.StanzaCreated {
 			replicaCreateRepoReady.Status = metav1.ConditionTrue
 			replicaCreateRepoReady.Reason = "StanzaCreated"
@@ -2249,12 +5422,21 @@ func (r *Reconciler) reconcileStanzaCreate(ctx context.Context,
 		} else {
 			replicaCreateRepoReady.Status = metav1.ConditionFalse
 			replicaCreateRepoReady.Reason = "StanzaNotCreated"
-			replicaCreateRepoReady.Message = "pgBackRest replica create repo is not ready " +
-				"for backups"
+			replicaCreateRepoReady.Message = withCommandOutput("pgBackRest replica create "+
+				"repo is not ready for backups", stanzaCreateErr)
 		}
 		meta.SetStatusCondition(&postgresCluster.Status.Conditions, replicaCreateRepoReady)
 	}()
 
+	// When stanzas are managed externally, they are assumed to exist for every repo so that
+	// backups can proceed, and stanza-create is never run.
+	if !manageStanza {
+		for i := range postgresCluster.Status.PGBackRest.Repos {
+			postgresCluster.Status.PGBackRest.Repos[i].StanzaCreated = true
+		}
+		return false, nil
+	}
+
 	// determine if the cluster has been initialized. pgBackRest compares the
 	// local PostgreSQL data directory to information it sees in a PostgreSQL
 	// instance that is not in recovery. Similar to "writable" but not exactly.
@@ -2274,73 +5456,488 @@ func (r *Reconciler) reconcileStanzaCreate(ctx context.Context,
 		dedicatedRepoReady = (condition.Status == metav1.ConditionTrue)
 	}
 
+	// repos excluded from stanza creation do not cause stanzas to be created, and their status
+	// is left as-is until they are no longer excluded
+	excluded := stanzaCreateExcludedRepos(postgresCluster)
+
 	stanzasCreated := true
 	for _, repoStatus := range postgresCluster.Status.PGBackRest.Repos {
-		if !repoStatus.StanzaCreated {
+		if !repoStatus.StanzaCreated && !excluded[repoStatus.Name] {
 			stanzasCreated = false
 			break
 		}
 	}
 
+	// Stanzas only need to be created again if the configuration changed since they were last
+	// created successfully (e.g. if custom configuration files were updated).  If no hash has
+	// been recorded yet (e.g. for stanzas created by a previous version of the operator), then
+	// simply record the current hash.
+	if stanzasCreated {
+		stanzaConfigHash := postgresCluster.Status.PGBackRest.StanzaConfigHash
+		if stanzaConfigHash == "" {
+			postgresCluster.Status.PGBackRest.StanzaConfigHash = configHash
+		}
+		stanzasCreated = (stanzaConfigHash == "" || stanzaConfigHash == configHash)
+	}
+
+	// Stanza creation is also forced when the "pgbackrest-stanza-create" annotation has a value
+	// for which stanzas have not yet been created, e.g. to immediately retry stanza creation
+	// after fixing custom configuration.
+	stanzaCreateID := postgresCluster.GetAnnotations()[naming.PGBackRestStanzaCreate]
+	if stanzaCreateID != "" && stanzaCreateID != postgresCluster.Status.PGBackRest.StanzaCreateID {
+		stanzasCreated = false
+	}
+
+	// Backups must not start until stanza creation has fully succeeded for their repos.  Since
+	// stanzas are created for every repo at once, whenever stanzas need to be created again (e.g.
+	// for a new repo or changed configuration), every repo is marked as not having a stanza
+	// until stanza creation succeeds.  This ensures backups of any type wait for the stanza of
+	// their repo, rather than contending with stanza creation.
+	if !stanzasCreated {
+		for i := range postgresCluster.Status.PGBackRest.Repos {
+			if !excluded[postgresCluster.Status.PGBackRest.Repos[i].Name] {
+				postgresCluster.Status.PGBackRest.Repos[i].StanzaCreated = false
+			}
+		}
+	}
+
 	// return if the cluster has not yet been initialized, or if it has been initialized and
-	// all stanzas have already been created successfully
+	// all stanzas have already been created successfully for the current configuration, which
+	// avoids exec'ing into a Pod when nothing has changed
 	if !clusterWritable || !dedicatedRepoReady || stanzasCreated {
 		return false, nil
 	}
 
+	// Stanzas cannot be created while the cluster is shutdown (or shutting down), since the Pods
+	// needed to create them are being removed.  Stanza creation will simply proceed once the
+	// cluster is started again.
+	if postgresCluster.Spec.Shutdown != nil && *postgresCluster.Spec.Shutdown {
+		return false, nil
+	}
+
 	// get pod name and container name as needed to exec into the proper pod and create
 	// pgBackRest stanzas
 	selector, containerName, err := getPGBackRestExecSelector(postgresCluster)
 	if err != nil {
 		return false, errors.WithStack(err)
 	}
-
+
+	pods := &v1.PodList{}
+	if err := r.Client.List(ctx, pods, client.InNamespace(postgresCluster.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return false, err
+	}
+
+	// TODO(andrewlecuyer): Returning an error to address an out-of-sync cache (e.g, if the
+	// expected Pods are not found) is a symptom of a missed event. Consider watching Pods instead
+	// instead to ensure the these events are not missed
+	candidates := execPods(pods.Items)
+	if len(candidates) == 0 {
+		return false, errors.WithStack(
+			errors.New("no Pods found when attempting to create stanzas"))
+	}
+
+	// the paths of any cloud repos (i.e. Azure, GCS or S3) might not yet be available for new
+	// buckets or prefixes, so provide them to ensure they are available before the stanza is
+	// created
+	var cloudRepoIndexes []string
+	for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+		if repo.Volume == nil && !excluded[repo.Name] {
+			cloudRepoIndexes = append(cloudRepoIndexes, regexRepoIndex.FindString(repo.Name))
+		}
+	}
+
+	// existing stanzas are only adopted when requested, and when they belong to this cluster
+	adopt := postgresCluster.Spec.Backups.PGBackRest.AdoptExistingStanzas != nil &&
+		*postgresCluster.Spec.Backups.PGBackRest.AdoptExistingStanzas
+	var systemIdentifier string
+	if postgresCluster.Status.Patroni != nil {
+		systemIdentifier = postgresCluster.Status.Patroni.SystemIdentifier
+	}
+
+	// create a pgBackRest executor for each candidate Pod and attempt stanza creation, moving
+	// on to the next Pod if stanza creation fails (e.g. because the Pod is unhealthy)
+	var adopted, configHashMismatch bool
+	for i := range candidates {
+		podName := candidates[i].GetName()
+		exec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			return r.PodExec(postgresCluster.GetNamespace(), podName, containerName,
+				stdin, stdout, stderr, command...)
+		}
+		if adopt {
+			adopted, configHashMismatch, err = adoptStanzas(ctx, pgbackrest.Executor(exec),
+				postgresCluster, configHash, systemIdentifier)
+			if adopted || configHashMismatch {
+				break
+			}
+			// any error is logged, and stanza creation then reports the underlying issue
+			if err != nil {
+				logging.FromContext(ctx).V(1).Info("unable to adopt existing stanzas",
+					"pod", podName, "error", err.Error())
+			}
+		}
+		configHashMismatch, err = pgbackrest.Executor(exec).StanzaCreate(ctx, configHash,
+			cloudRepoIndexes...)
+		if err == nil {
+			break
+		}
+		if i < len(candidates)-1 {
+			logging.FromContext(ctx).V(1).Info("unable to create stanzas, trying the next Pod",
+				"pod", podName, "error", err.Error())
+		}
+	}
+	if err != nil {
+		// record and log any errors resulting from running the stanza-create command
+		stanzaCreateErr = err
+		r.Recorder.Event(postgresCluster, v1.EventTypeWarning, EventUnableToCreateStanzas,
+			err.Error())
+
+		// The stanza-create command creates the stanza in every repo, so it fails while any
+		// excluded repo is unreachable.  Rather than retrying continuously, wait for the next
+		// change to the cluster (e.g. removing the exclusion) to try again.
+		if len(excluded) > 0 {
+			return false, nil
+		}
+		return false, errors.WithStack(err)
+	}
+	// Don't record event or return an error if configHashMismatch is true, since this just means
+	// configuration changes in ConfigMaps/Secrets have not yet propagated to the container.
+	// Therefore, just log an an info message and return an error to requeue and try again.
+	if configHashMismatch {
+
+		return true, nil
+	}
+
+	// record an event indicating successful stanza creation (or adoption)
+	if adopted {
+		r.Recorder.Event(postgresCluster, v1.EventTypeNormal, EventStanzasAdopted,
+			"existing pgBackRest stanzas adopted successfully")
+	} else {
+		r.Recorder.Event(postgresCluster, v1.EventTypeNormal, EventStanzasCreated,
+			"pgBackRest stanza creation completed successfully")
+	}
+
+	// if no errors then stanza(s) created successfully
+	now := metav1.Now()
+	for i := range postgresCluster.Status.PGBackRest.Repos {
+		postgresCluster.Status.PGBackRest.Repos[i].StanzaCreated = true
+		postgresCluster.Status.PGBackRest.Repos[i].StanzaCreatedAt = &now
+		postgresCluster.Status.PGBackRest.Repos[i].StanzaConfigHash = configHash
+	}
+	postgresCluster.Status.PGBackRest.StanzaConfigHash = configHash
+	postgresCluster.Status.PGBackRest.StanzaCreateID = stanzaCreateID
+
+	return false, nil
+}
+
+// stanzaCreateExcludedRepos returns the names of the repos that are temporarily excluded from
+// stanza creation using the "pgbackrest-stanza-create-exclude" annotation
+func stanzaCreateExcludedRepos(postgresCluster *v1beta1.PostgresCluster) map[string]bool {
+	excluded := make(map[string]bool)
+	for _, name := range strings.Split(
+		postgresCluster.GetAnnotations()[naming.PGBackRestStanzaCreateExclude], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			excluded[name] = true
+		}
+	}
+	return excluded
+}
+
+// adoptStanzas determines whether or not every pgBackRest repo defined for the PostgresCluster
+// already contains a valid stanza for the PostgreSQL system identifier provided, in which case
+// those stanzas can be adopted instead of being created.  The second bool returned is true when
+// a pgBackRest config hash mismatch prevented checking the repos.
+func adoptStanzas(ctx context.Context, exec pgbackrest.Executor,
+	postgresCluster *v1beta1.PostgresCluster, configHash,
+	systemIdentifier string) (bool, bool, error) {
+
+	valid, configHashMismatch, err := exec.ValidStanzaRepos(ctx, configHash, systemIdentifier)
+	if err != nil || configHashMismatch {
+		return false, configHashMismatch, err
+	}
+
+	repos := postgresCluster.Spec.Backups.PGBackRest.Repos
+	for _, repo := range repos {
+		if !valid[regexRepoIndex.FindString(repo.Name)] {
+			return false, false, nil
+		}
+	}
+	return len(repos) > 0, false, nil
+}
+
+// backupCountsRefresh returns how long until the backup counts of each pgBackRest repository
+// should be refreshed, which is immediately if they have never been counted or if a backup has
+// completed since they were last counted.  Otherwise they are refreshed once backupCountsInterval
+// has passed, e.g. to reflect backups expired outside of the operator.
+func backupCountsRefresh(postgresCluster *v1beta1.PostgresCluster, now time.Time) time.Duration {
+	updated := postgresCluster.Status.PGBackRest.BackupCountsUpdateTime
+	if updated == nil {
+		return 0
+	}
+	for _, backup := range postgresCluster.Status.PGBackRest.LatestBackups {
+		if backup.CompletionTime != nil && updated.Before(backup.CompletionTime) {
+			return 0
+		}
+	}
+	return updated.Add(backupCountsInterval).Sub(now)
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+
+// reconcileBackupCounts updates the number of full, differential and incremental backups within
+// each pgBackRest repository using the pgBackRest "info" command, as needed to monitor backup
+// retention.  Backup counts are only updated once stanzas have been created for every repository,
+// and according to backupCountsRefresh.  The duration returned is how long until the next refresh.
+func (r *Reconciler) reconcileBackupCounts(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster) (time.Duration, error) {
+
+	repos := postgresCluster.Status.PGBackRest.Repos
+	if len(repos) == 0 {
+		return 0, nil
+	}
+	for _, repo := range repos {
+		if !repo.StanzaCreated {
+			return 0, nil
+		}
+	}
+	if postgresCluster.Spec.Shutdown != nil && *postgresCluster.Spec.Shutdown {
+		return 0, nil
+	}
+	if refresh := backupCountsRefresh(postgresCluster, time.Now()); refresh > 0 {
+		return refresh, nil
+	}
+
+	selector, containerName, err := getPGBackRestExecSelector(postgresCluster)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	pods := &v1.PodList{}
+	if err := r.Client.List(ctx, pods, client.InNamespace(postgresCluster.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	candidates := execPods(pods.Items)
+	if len(candidates) == 0 {
+		return 0, errors.WithStack(
+			errors.New("no Pods found when attempting to count backups"))
+	}
+
+	exec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+		command ...string) error {
+		return r.PodExec(postgresCluster.GetNamespace(), candidates[0].GetName(), containerName,
+			stdin, stdout, stderr, command...)
+	}
+	counts, err := pgbackrest.Executor(exec).BackupCounts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range repos {
+		repos[i].BackupCounts = nil
+		if repoCounts, ok := counts[regexRepoIndex.FindString(repos[i].Name)]; ok {
+			repos[i].BackupCounts = &repoCounts
+		}
+	}
+	now := metav1.Now()
+	postgresCluster.Status.PGBackRest.BackupCountsUpdateTime = &now
+
+	return backupCountsInterval, nil
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=patch
+
+// reconcileBackupSets annotates each of the latest backup Jobs that has completed successfully
+// with the label and size of the pgBackRest backup set it created, as determined using the
+// pgBackRest "info" command, and then records that label (along with the size and throughput of
+// the backup) in the status of the latest backups.  This maps each backup Job to the exact backup
+// set that can be restored.
+func (r *Reconciler) reconcileBackupSets(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, repoResources *RepoResources) error {
+
+	jobs := map[string]*batchv1.Job{}
+	for _, job := range backupJobs(repoResources) {
+		jobs[job.GetName()] = job
+	}
+
+	latestBackups := postgresCluster.Status.PGBackRest.LatestBackups
+	var pending []*batchv1.Job
+	for _, backup := range latestBackups {
+		if job, ok := jobs[backup.JobName]; ok && backup.BackupSet == "" && jobCompleted(job) {
+			pending = append(pending, job)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	if postgresCluster.Spec.Shutdown != nil && *postgresCluster.Spec.Shutdown {
+		return nil
+	}
+
+	selector, containerName, err := getPGBackRestExecSelector(postgresCluster)
+	if err != nil {
+		return errors.WithStack(err)
+	}
 	pods := &v1.PodList{}
 	if err := r.Client.List(ctx, pods, client.InNamespace(postgresCluster.GetNamespace()),
 		client.MatchingLabelsSelector{Selector: selector}); err != nil {
-		return false, err
+		return errors.WithStack(err)
 	}
-
-	// TODO(andrewlecuyer): Returning an error to address an out-of-sync cache (e.g, if the
-	// expected Pods are not found) is a symptom of a missed event. Consider watching Pods instead
-	// instead to ensure the these events are not missed
-	if len(pods.Items) != 1 {
-		return false, errors.WithStack(
-			errors.New("invalid number of Pods found when attempting to create stanzas"))
+	candidates := execPods(pods.Items)
+	if len(candidates) == 0 {
+		return errors.WithStack(
+			errors.New("no Pods found when attempting to identify backup sets"))
 	}
 
-	// create a pgBackRest executor and attempt stanza creation
 	exec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
 		command ...string) error {
-		return r.PodExec(postgresCluster.GetNamespace(), pods.Items[0].GetName(), containerName,
+		return r.PodExec(postgresCluster.GetNamespace(), candidates[0].GetName(), containerName,
 			stdin, stdout, stderr, command...)
 	}
-	configHashMismatch, err := pgbackrest.Executor(exec).StanzaCreate(ctx, configHash)
+	sets, err := pgbackrest.Executor(exec).BackupSets(ctx)
 	if err != nil {
-		// record and log any errors resulting from running the stanza-create command
-		r.Recorder.Event(postgresCluster, v1.EventTypeWarning, EventUnableToCreateStanzas,
-			err.Error())
+		return err
+	}
 
-		return false, errors.WithStack(err)
+	for _, job := range pending {
+		set, found := createdBackupSet(job, sets)
+		if !found {
+			continue
+		}
+
+		before := job.DeepCopy()
+		// Make another copy so that Patch doesn't write back to the Job.
+		intent := before.DeepCopy()
+		initialize.Annotations(intent)
+		intent.Annotations[naming.PGBackRestBackupSet] = set.Label
+		intent.Annotations[naming.PGBackRestBackupSize] = strconv.FormatInt(set.Size, 10)
+		if err := errors.WithStack(r.patch(ctx, intent, client.MergeFrom(before))); err != nil {
+			return err
+		}
+		job.SetAnnotations(intent.GetAnnotations())
+
+		for i := range latestBackups {
+			if latestBackups[i].JobName == job.GetName() {
+				latestBackups[i].BackupSet = set.Label
+				setBackupMetrics(&latestBackups[i], job)
+			}
+		}
 	}
-	// Don't record event or return an error if configHashMismatch is true, since this just means
-	// configuration changes in ConfigMaps/Secrets have not yet propagated to the container.
-	// Therefore, just log an an info message and return an error to requeue and try again.
-	if configHashMismatch {
 
-		return true, nil
+	return nil
+}
+
+// createdBackupSet returns the pgBackRest backup set created by the completed backup Job
+// provided, i.e. the most recent backup set within the repo of the Job that both started and
+// stopped while the Job was running.  The bool returned is false when no such backup set exists.
+func createdBackupSet(job *batchv1.Job,
+	sets []pgbackrest.BackupSet) (pgbackrest.BackupSet, bool) {
+
+	var created pgbackrest.BackupSet
+	if job.Status.StartTime == nil || job.Status.CompletionTime == nil {
+		return created, false
 	}
 
-	// record an event indicating successful stanza creation
-	r.Recorder.Event(postgresCluster, v1.EventTypeNormal, EventStanzasCreated,
-		"pgBackRest stanza creation completed successfully")
+	repoIndex := regexRepoIndex.FindString(job.GetLabels()[naming.LabelPGBackRestRepo])
+	// the times reported by pgBackRest are only precise to the second
+	start := job.Status.StartTime.Truncate(time.Second)
+	stop := job.Status.CompletionTime.Time
 
-	// if no errors then stanza(s) created successfully
-	for i := range postgresCluster.Status.PGBackRest.Repos {
-		postgresCluster.Status.PGBackRest.Repos[i].StanzaCreated = true
+	var found bool
+	for _, set := range sets {
+		if set.RepoIndex != repoIndex || set.Start.Before(start) || set.Stop.After(stop) {
+			continue
+		}
+		if !found || set.Stop.After(created.Stop) {
+			created, found = set, true
+		}
 	}
 
-	return false, nil
+	return created, found
+}
+
+// withCommandOutput appends the end of the output of the pgBackRest command that failed with err
+// (if any) to a status message, so that the cause of the failure is visible in the status of the
+// PostgresCluster rather than only in the logs of a Pod.
+func withCommandOutput(message string, err error) string {
+	var commandErr *pgbackrest.CommandError
+	if errors.As(err, &commandErr) {
+		if tail := commandErr.Tail(); tail != "" {
+			return message + ": " + tail
+		}
+	}
+	return message
+}
+
+// execPods returns the Pods that can be used to run pgBackRest commands (e.g. to create
+// stanzas), in the order they should be tried.  Pods that are being deleted are skipped, and
+// Ready Pods are tried before any Pods that are not Ready, e.g. so that stanza creation is not
+// stuck on an unhealthy Pod that still matches the selector.
+func execPods(pods []v1.Pod) []v1.Pod {
+	var ready, notReady []v1.Pod
+	for i := range pods {
+		if pods[i].GetDeletionTimestamp() != nil {
+			continue
+		}
+		isReady := false
+		for _, condition := range pods[i].Status.Conditions {
+			if condition.Type == v1.PodReady {
+				isReady = (condition.Status == v1.ConditionTrue)
+			}
+		}
+		if isReady {
+			ready = append(ready, pods[i])
+		} else {
+			notReady = append(notReady, pods[i])
+		}
+	}
+	return append(ready, notReady...)
+}
+
+// setConfigHashMismatchCondition sets or removes ConditionConfigHashMismatch according to whether
+// or not a pgBackRest config hash mismatch was detected.  The condition's transition time tracks
+// how long the mismatch has persisted, and once it exceeds configHashMismatchThreshold the
+// condition's reason is updated and a Warning event is recorded, since this typically means the
+// configuration is not propagating to the Pod (e.g. due to a stuck ConfigMap mount).
+func (r *Reconciler) setConfigHashMismatchCondition(postgresCluster *v1beta1.PostgresCluster,
+	configHashMismatch bool) {
+
+	if !configHashMismatch {
+		// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+		if len(postgresCluster.Status.Conditions) > 0 {
+			meta.RemoveStatusCondition(&postgresCluster.Status.Conditions,
+				ConditionConfigHashMismatch)
+		}
+		return
+	}
+
+	condition := metav1.Condition{
+		ObservedGeneration: postgresCluster.GetGeneration(),
+		Type:               ConditionConfigHashMismatch,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ConfigHashMismatch",
+		Message:            "pgBackRest configuration has not yet propagated",
+	}
+
+	existing := meta.FindStatusCondition(postgresCluster.Status.Conditions,
+		ConditionConfigHashMismatch)
+	if existing != nil && existing.Status == metav1.ConditionTrue &&
+		time.Since(existing.LastTransitionTime.Time) > configHashMismatchThreshold {
+		condition.Reason = EventConfigNotPropagating
+		condition.Message = fmt.Sprintf("pgBackRest configuration has not propagated for "+
+			"more than %s", configHashMismatchThreshold)
+
+		// only record an event the first time the threshold is exceeded
+		if existing.Reason != EventConfigNotPropagating {
+			r.Recorder.Event(postgresCluster, v1.EventTypeWarning, EventConfigNotPropagating,
+				condition.Message)
+		}
+	}
+
+	meta.SetStatusCondition(&postgresCluster.Status.Conditions, condition)
 }
 
 // getPGBackRestExecSelector returns a selector and container name that allows the proper
@@ -2397,6 +5994,42 @@ func getRepoHostStatus(repoHost *appsv1.StatefulSet) *v1beta1.RepoHostStatus {
 	return repoHostStatus
 }
 
+// setRepoVolumeStorageStatus updates the provided repo status with the name, StorageClass and
+// capacity of the volume bound to the provided repo volume (PVC)
+func setRepoVolumeStorageStatus(repoStatus *v1beta1.RepoStatus,
+	repoVolume *v1.PersistentVolumeClaim) {
+
+	repoStatus.VolumeName = repoVolume.Spec.VolumeName
+	repoStatus.StorageClassName = ""
+	if repoVolume.Spec.StorageClassName != nil {
+		repoStatus.StorageClassName = *repoVolume.Spec.StorageClassName
+	}
+	repoStatus.RequestedCapacity = ""
+	if capacity, ok := repoVolume.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+		repoStatus.RequestedCapacity = capacity.String()
+	}
+	repoStatus.ActualCapacity = ""
+	if capacity, ok := repoVolume.Status.Capacity[v1.ResourceStorage]; ok {
+		repoStatus.ActualCapacity = capacity.String()
+	}
+}
+
+// previousReplicaCreateRepo returns the name of the repo that previously completed a replica
+// create backup according to the provided status, if that repo is no longer the current
+// replica create repo.  An empty string is returned otherwise.
+func previousReplicaCreateRepo(repoStatus []v1beta1.RepoStatus,
+	replicaCreateRepoName string) string {
+	if replicaCreateRepoName == "" {
+		return ""
+	}
+	for _, rs := range repoStatus {
+		if rs.ReplicaCreateBackupComplete && rs.Name != replicaCreateRepoName {
+			return rs.Name
+		}
+	}
+	return ""
+}
+
 // getRepoVolumeStatus is responsible for creating an array of repo statuses based on the
 // existing/current status for any repos in the cluster, the repository volumes
 // (i.e. PVCs) reconciled  for the cluster, and the hashes calculated for the configuration for any
@@ -2428,17 +6061,19 @@ func getRepoVolumeStatus(repoStatus []v1beta1.RepoStatus, repoVolumes []*v1.Pers
 				if rs.Bound != (rv.Status.Phase == v1.ClaimBound) {
 					rs.Bound = (rv.Status.Phase == v1.ClaimBound)
 				}
+				setRepoVolumeStorageStatus(&rs, rv)
 
 				updatedRepoStatus = append(updatedRepoStatus, rs)
 				break
 			}
 		}
 		if newRepoVolStatus {
-			updatedRepoStatus = append(updatedRepoStatus, v1beta1.RepoStatus{
-				Bound:      (rv.Status.Phase == v1.ClaimBound),
-				Name:       repoName,
-				VolumeName: rv.Spec.VolumeName,
-			})
+			rs := v1beta1.RepoStatus{
+				Bound: (rv.Status.Phase == v1.ClaimBound),
+				Name:  repoName,
+			}
+			setRepoVolumeStorageStatus(&rs, rv)
+			updatedRepoStatus = append(updatedRepoStatus, rs)
 		}
 	}
 
@@ -2489,6 +6124,77 @@ func getRepoVolumeStatus(repoStatus []v1beta1.RepoStatus, repoVolumes []*v1.Pers
 	return updatedRepoStatus
 }
 
+// scheduledBackupsDeferral returns the amount of time remaining before scheduled backups can be
+// enabled for the PostgresCluster, according to the delay configured in the spec and the time
+// the cluster was bootstrapped.  Zero is returned once scheduled backups can be enabled.
+func scheduledBackupsDeferral(cluster *v1beta1.PostgresCluster, now time.Time) time.Duration {
+	delay := cluster.Spec.Backups.PGBackRest.ScheduledBackupDelaySeconds
+	if delay == nil || *delay <= 0 ||
+		cluster.Status.Patroni == nil || cluster.Status.Patroni.BootstrappedAt == nil {
+		return 0
+	}
+
+	enabled := cluster.Status.Patroni.BootstrappedAt.Add(time.Duration(*delay) * time.Second)
+	if remaining := enabled.Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// scheduledBackupWindow returns whether or not scheduled backups are allowed at the time provided
+// according to the backup windows defined in the spec, along with the amount of time remaining
+// until the next window opens or closes.  Scheduled backups are always allowed (and zero is
+// returned) when no windows are defined.
+func scheduledBackupWindow(cluster *v1beta1.PostgresCluster, now time.Time) (bool, time.Duration) {
+	windows := cluster.Spec.Backups.PGBackRest.ScheduledBackupWindows
+	if len(windows) == 0 {
+		return true, 0
+	}
+
+	const day = 24 * time.Hour
+	now = now.UTC()
+	sinceMidnight := now.Sub(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))
+
+	// untilNext returns the time remaining until the provided time of day next occurs
+	untilNext := func(timeOfDay time.Duration) time.Duration {
+		if remaining := (timeOfDay - sinceMidnight + day) % day; remaining > 0 {
+			return remaining
+		}
+		return day
+	}
+
+	var open bool
+	next := day
+	for _, window := range windows {
+		start, startErr := time.Parse("15:04", window.Start)
+		end, endErr := time.Parse("15:04", window.End)
+		if startErr != nil || endErr != nil {
+			continue
+		}
+		opens := time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute
+		closes := time.Duration(end.Hour())*time.Hour + time.Duration(end.Minute())*time.Minute
+
+		switch {
+		case opens == closes:
+			// a window that closes when it opens spans the entire day
+			open = true
+		case opens < closes:
+			open = open || (sinceMidnight >= opens && sinceMidnight < closes)
+		default:
+			// the window spans midnight
+			open = open || sinceMidnight >= opens || sinceMidnight < closes
+		}
+
+		if remaining := untilNext(opens); remaining < next {
+			next = remaining
+		}
+		if remaining := untilNext(closes); remaining < next {
+			next = remaining
+		}
+	}
+	return open, next
+}
+
 // reconcileScheduledBackups is responsible for reconciling pgBackRest backup
 // schedules configured in the cluster definition
 func (r *Reconciler) reconcileScheduledBackups(
@@ -2500,8 +6206,9 @@ func (r *Reconciler) reconcileScheduledBackups(
 	var requeue bool
 
 	for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
-		// if the repo level backup schedules block has not been created,
-		// there are no schedules defined
+		// if the repo level backup schedules block has not been created, the operator's
+		// default schedules (if any) apply
+		repo.BackupSchedules = r.backupSchedules(repo)
 		if repo.BackupSchedules != nil {
 			// next if the repo level schedule is not nil, create the CronJob.
 			if repo.BackupSchedules.Full != nil {
@@ -2529,9 +6236,153 @@ func (r *Reconciler) reconcileScheduledBackups(
 			}
 		}
 	}
+
+	if err := r.setSchedulesReadyCondition(ctx, cluster); err != nil {
+		log.Error(err, "unable to determine whether pgBackRest backup schedules are ready")
+		requeue = true
+	}
+	r.setFullScheduleMissingCondition(cluster)
 	return requeue
 }
 
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get
+
+// setSchedulesReadyCondition sets the SchedulesReady condition according to whether or not a
+// CronJob exists with the proper schedule for every backup schedule defined for the cluster
+// (including any default schedules), e.g. to indicate when changes to the schedules in the spec
+// have been applied.  The condition is removed when no backup schedules are defined.
+func (r *Reconciler) setSchedulesReadyCondition(ctx context.Context,
+	cluster *v1beta1.PostgresCluster) error {
+
+	var expected, pending []string
+	for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
+		schedules := r.backupSchedules(repo)
+		if schedules == nil {
+			continue
+		}
+		for _, backup := range []struct {
+			backupType string
+			schedule   *string
+		}{
+			{full, schedules.Full},
+			{differential, schedules.Differential},
+			{incremental, schedules.Incremental},
+		} {
+			if backup.schedule == nil {
+				continue
+			}
+			cronJob := &batchv1beta1.CronJob{
+				ObjectMeta: naming.PGBackRestCronJob(cluster, backup.backupType, repo.Name),
+			}
+			expected = append(expected, cronJob.GetName())
+
+			err := r.Client.Get(ctx, client.ObjectKeyFromObject(cronJob), cronJob)
+			if client.IgnoreNotFound(err) != nil {
+				return errors.WithStack(err)
+			}
+			if err != nil || cronJob.Spec.Schedule != *backup.schedule {
+				pending = append(pending, cronJob.GetName())
+			}
+		}
+	}
+
+	if len(expected) == 0 {
+		// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+		if len(cluster.Status.Conditions) > 0 {
+			meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionSchedulesReady)
+		}
+		return nil
+	}
+
+	condition := metav1.Condition{
+		ObservedGeneration: cluster.GetGeneration(),
+		Type:               ConditionSchedulesReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "SchedulesReconciled",
+		Message:            "All pgBackRest backup schedules are reconciled",
+	}
+	if len(pending) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "SchedulesNotReconciled"
+		condition.Message = "pgBackRest backup CronJobs missing or not yet updated: " +
+			strings.Join(pending, ", ")
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get
+
+// fullBackupInProgress returns true when a Job created by the full backup CronJob for the
+// provided repo is currently running.
+func (r *Reconciler) fullBackupInProgress(ctx context.Context,
+	cluster *v1beta1.PostgresCluster, repoName string) (bool, error) {
+
+	cronJob := &batchv1beta1.CronJob{ObjectMeta: naming.PGBackRestCronJob(cluster, full, repoName)}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(cronJob), cronJob); err != nil {
+		return false, errors.WithStack(client.IgnoreNotFound(err))
+	}
+	return len(cronJob.Status.Active) > 0, nil
+}
+
+// repoFullBackupMissing returns true when the status of the provided repo shows that it does not
+// contain a full backup, i.e. when neither the backup counts last reported by pgBackRest nor the
+// most recent backup include one.  False is returned when the backups within the repo are not
+// yet known.
+func repoFullBackupMissing(cluster *v1beta1.PostgresCluster, repoName string) bool {
+	if cluster.Status.PGBackRest == nil {
+		return false
+	}
+	for _, repoStatus := range cluster.Status.PGBackRest.Repos {
+		if repoStatus.Name == repoName {
+			return repoStatus.BackupCounts != nil && repoStatus.BackupCounts.Full == 0 &&
+				repoStatus.LastBackupType != full
+		}
+	}
+	return false
+}
+
+// setFullScheduleMissingCondition sets the FullScheduleMissing condition whenever differential
+// or incremental backups are scheduled for a repo without also scheduling full backups.  Without
+// regular full backups, these backups chain indefinitely from the last full backup (or are
+// promoted to full backups by pgBackRest when none exists).  An event is recorded whenever the
+// condition changes, and the condition is removed once every such repo schedules full backups.
+func (r *Reconciler) setFullScheduleMissingCondition(cluster *v1beta1.PostgresCluster) {
+	var repos []string
+	for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
+		schedules := r.backupSchedules(repo)
+		if schedules != nil && schedules.Full == nil &&
+			(schedules.Differential != nil || schedules.Incremental != nil) {
+			repos = append(repos, repo.Name)
+		}
+	}
+
+	if len(repos) == 0 {
+		// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+		if len(cluster.Status.Conditions) > 0 {
+			meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionFullScheduleMissing)
+		}
+		return
+	}
+
+	message := fmt.Sprintf("Differential or incremental backups are scheduled without a full "+
+		"backup schedule for repo(s) %s", strings.Join(repos, ", "))
+
+	condition := meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionFullScheduleMissing)
+	if condition == nil || condition.Message != message {
+		r.Recorder.Event(cluster, v1.EventTypeWarning, EventInvalidBackupSchedule, message)
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		ObservedGeneration: cluster.GetGeneration(),
+		Type:               ConditionFullScheduleMissing,
+		Status:             metav1.ConditionTrue,
+		Reason:             EventInvalidBackupSchedule,
+		Message:            message,
+	})
+}
+
 // +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=create;patch
 
 // reconcilePGBackRestCronJob creates the CronJob for the given repo, pgBackRest
@@ -2550,12 +6401,24 @@ func (r *Reconciler) reconcilePGBackRestCronJob(
 	labels := naming.Merge(
 		cluster.Spec.Metadata.GetLabelsOrNil(),
 		cluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
+		r.PGBackRestSelectorLabels,
 		naming.PGBackRestCronJobLabels(cluster.Name, repo.Name, backupType),
 	)
 	objectmeta := naming.PGBackRestCronJob(cluster, backupType, repo.Name)
 	objectmeta.Labels = labels
 	objectmeta.Annotations = annotations
 
+	// Suspend any existing CronJob while an in-place restore is in progress to ensure backups
+	// and restores never overlap.  The CronJob is reconciled again per the spec once the restore
+	// is complete.  Similarly, simply suspend any existing CronJob while the cluster is shut
+	// down, since the primary needed to generate the backup Job no longer exists.
+	if restoringInPlace(cluster) || (cluster.Spec.Shutdown != nil && *cluster.Spec.Shutdown) {
+		cronJob := &batchv1beta1.CronJob{ObjectMeta: naming.PGBackRestCronJob(cluster,
+			backupType, repo.Name)}
+		patch := client.RawPatch(client.Merge.Type(), []byte(`{"spec":{"suspend":true}}`))
+		return errors.WithStack(client.IgnoreNotFound(r.patch(ctx, cronJob, patch)))
+	}
+
 	// if the cluster isn't bootstrapped, return
 	if !patroni.ClusterBootstrapped(cluster) {
 		return nil
@@ -2585,7 +6448,13 @@ func (r *Reconciler) reconcilePGBackRestCronJob(
 		r.Recorder.Eventf(cluster, v1.EventTypeWarning, "InvalidBackupRepo",
 			"Unable to find status for %q as configured for a scheduled backup.  Please ensure "+
 				"this repo is defined in the spec.", repo.Name)
-		return nil
+
+		// Also suspend any existing CronJob, since its backups cannot succeed without the repo,
+		// e.g. while the volume of the repo is not owned by the cluster.
+		cronJob := &batchv1beta1.CronJob{ObjectMeta: naming.PGBackRestCronJob(cluster,
+			backupType, repo.Name)}
+		patch := client.RawPatch(client.Merge.Type(), []byte(`{"spec":{"suspend":true}}`))
+		return errors.WithStack(client.IgnoreNotFound(r.patch(ctx, cronJob, patch)))
 	}
 	if !stanzaCreated {
 		r.Recorder.Eventf(cluster, v1.EventTypeWarning, "StanzaNotCreated",
@@ -2603,10 +6472,8 @@ func (r *Reconciler) reconcilePGBackRestCronJob(
 		return errors.WithStack(err)
 	}
 
-	// Leverage the observedInstances to determine the current primary.  This is needed to
-	// mount the proper configuration file to the backup when running without a dedicated repo
-	// host.  And even when running with a repo host, this ensure a backup is only attempted
-	// when the primary can be properly identified.
+	// Leverage the observedInstances to determine the current primary.  This ensures the
+	// backup is only attempted when the primary can be properly identified.
 	var primaryInstance string
 	for _, instance := range instances.forCluster {
 		if isPrimary, _ := instance.IsPrimary(); isPrimary {
@@ -2623,38 +6490,76 @@ func (r *Reconciler) reconcilePGBackRestCronJob(
 			errors.New("unable to find primary when reconciling scheduled pgBackRest backup Job"))
 	}
 	// set the name of the pgbackrest config file that will be mounted to the backup Job
-	configName := primaryInstance + ".conf"
-	if pgbackrest.DedicatedRepoHostEnabled(cluster) {
-		configName = pgbackrest.CMRepoKey
-	}
+	configName := backupJobConfigName(cluster, instances, nil)
+
+	// label the Jobs created by the CronJob as scheduled backups of the scheduled type
+	jobLabels := naming.Merge(labels, map[string]string{
+		naming.LabelPGBackRestBackup: string(naming.BackupScheduled),
+	}, backupTypeLabels(backupOpts))
 
 	jobSpec, err := generateBackupJobSpecIntent(cluster, selector.String(), containerName,
-		repo.Name, serviceAccount.GetName(), configName, labels, annotations, backupOpts...)
+		repo.Name, serviceAccount.GetName(), configName, jobLabels, annotations, backupOpts...)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	// Suspend cronjobs when shutdown or read-only. Any jobs that have already
-	// started will continue.
+	// Suspend cronjobs when shutdown or read-only, or once a final backup has completed. Any
+	// jobs that have already started will continue.
 	// - https://docs.k8s.io/reference/kubernetes-api/workload-resources/cron-job-v1beta1/#CronJobSpec
+	// Suspend the CronJob while the backup lock is held by a backup Job it did not create (e.g.
+	// a manual backup), since only one backup runs at a time for the cluster.  The Jobs created
+	// by a CronJob are named using the name of the CronJob as a prefix.
+	lockHolder := cluster.GetAnnotations()[naming.PGBackRestBackupLock]
+	locked := lockHolder != "" && !strings.HasPrefix(lockHolder, objectmeta.Name+"-")
+
+	// Also suspend differential and incremental backups while a full backup for the same repo
+	// is running, since only one backup can run at a time.  The update to the status of the full
+	// backup CronJob once that backup completes triggers the reconcile needed to resume them.
+	var fullBackupActive bool
+	if backupType != full {
+		if fullBackupActive, err = r.fullBackupInProgress(ctx, cluster, repo.Name); err != nil {
+			return err
+		}
+	}
+	// Differential and incremental backups build upon the most recent full backup, so when
+	// full backups are scheduled for the repo, suspend them until the repo contains a full
+	// backup.  Otherwise pgBackRest would promote each of them to a full backup, competing with
+	// the full backup schedule.
+	fullBackupMissing := backupType != full && repo.BackupSchedules != nil &&
+		repo.BackupSchedules.Full != nil && repoFullBackupMissing(cluster, repo.Name)
+
+	// Finally, suspend the CronJob outside of the backup windows defined in the spec.
+	windowOpen, _ := scheduledBackupWindow(cluster, time.Now())
 	suspend := (cluster.Spec.Shutdown != nil && *cluster.Spec.Shutdown) ||
-		(cluster.Spec.Standby != nil && cluster.Spec.Standby.Enabled)
+		(cluster.Spec.Standby != nil && cluster.Spec.Standby.Enabled) ||
+		finalBackupComplete(cluster) || fullBackupActive || fullBackupMissing || locked ||
+		!windowOpen
 
 	pgBackRestCronJob := &batchv1beta1.CronJob{
 		ObjectMeta: objectmeta,
 		Spec: batchv1beta1.CronJobSpec{
 			Schedule: *schedule,
 			Suspend:  &suspend,
+			// Set the concurrency policy explicitly (rather than relying on its default) so that
+			// the apply takes ownership of it, and any value set previously is replaced.
+			ConcurrencyPolicy: batchv1beta1.AllowConcurrent,
 			JobTemplate: batchv1beta1.JobTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: annotations,
-					Labels:      labels,
+					Labels:      jobLabels,
 				},
 				Spec: *jobSpec,
 			},
 		},
 	}
 
+	// When backup windows are defined, skip any backup that could not start on schedule, e.g.
+	// because the CronJob was suspended outside of a window at the time.
+	if len(cluster.Spec.Backups.PGBackRest.ScheduledBackupWindows) > 0 {
+		pgBackRestCronJob.Spec.StartingDeadlineSeconds =
+			initialize.Int64(backupWindowStartingDeadline)
+	}
+
 	// Set the image pull secrets, if any exist.
 	// This is set here rather than using the service account due to the lack
 	// of propagation to existing pods when the CRD is updated:
@@ -2677,3 +6582,157 @@ func (r *Reconciler) reconcilePGBackRestCronJob(
 	}
 	return err
 }
+
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=create;patch
+
+// reconcilePGBackRestCheck creates the CronJob that runs the pgBackRest "check" command against
+// all repos according to the schedule defined in the spec, and then reports the result of the
+// most recent check Job using the PGBackRestCheck condition.  The condition is removed when no
+// check schedule is defined (in which case any existing CronJob is removed during cleanup).
+func (r *Reconciler) reconcilePGBackRestCheck(ctx context.Context,
+	cluster *v1beta1.PostgresCluster, checkJobs []*batchv1.Job,
+	instances *observedInstances, serviceAccount *v1.ServiceAccount) error {
+
+	check := cluster.Spec.Backups.PGBackRest.Check
+	if check == nil {
+		// TODO: remove guard with move to controller-runtime 0.9.0 https://issue.k8s.io/99714
+		if len(cluster.Status.Conditions) > 0 {
+			meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionPGBackRestCheck)
+		}
+		return nil
+	}
+
+	r.setPGBackRestCheckCondition(cluster, checkJobs)
+
+	// Suspend any existing CronJob while the cluster is shut down or an in-place restore is in
+	// progress, since the Pods needed to run the check no longer exist.
+	if restoringInPlace(cluster) || (cluster.Spec.Shutdown != nil && *cluster.Spec.Shutdown) {
+		cronJob := &batchv1beta1.CronJob{ObjectMeta: naming.PGBackRestCheckCronJob(cluster)}
+		patch := client.RawPatch(client.Merge.Type(), []byte(`{"spec":{"suspend":true}}`))
+		return errors.WithStack(client.IgnoreNotFound(r.patch(ctx, cronJob, patch)))
+	}
+
+	// The check requires a running cluster and a stanza in every repo.  Subsequent events (e.g.
+	// successful stanza creation) trigger the reconciles needed to try again.
+	if !patroni.ClusterBootstrapped(cluster) {
+		return nil
+	}
+	for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
+		var stanzaCreated bool
+		for _, repoStatus := range cluster.Status.PGBackRest.Repos {
+			if repoStatus.Name == repo.Name {
+				stanzaCreated = repoStatus.StanzaCreated
+			}
+		}
+		if !stanzaCreated {
+			return nil
+		}
+	}
+
+	selector, containerName, err := getPGBackRestExecSelector(cluster)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// only run the check once the primary can be properly identified
+	var primaryInstance string
+	for _, instance := range instances.forCluster {
+		if isPrimary, _ := instance.IsPrimary(); isPrimary {
+			primaryInstance = instance.Name
+			break
+		}
+	}
+	if primaryInstance == "" {
+		return errors.WithStack(
+			errors.New("unable to find primary when reconciling pgBackRest check CronJob"))
+	}
+	configName := backupJobConfigName(cluster, instances, nil)
+
+	annotations := naming.Merge(
+		cluster.Spec.Metadata.GetAnnotationsOrNil(),
+		cluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil())
+	labels := naming.Merge(
+		cluster.Spec.Metadata.GetLabelsOrNil(),
+		cluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
+		r.PGBackRestSelectorLabels,
+		naming.PGBackRestCheckLabels(cluster.Name),
+	)
+	objectmeta := naming.PGBackRestCheckCronJob(cluster)
+	objectmeta.Labels = labels
+	objectmeta.Annotations = annotations
+
+	jobSpec, err := generateCheckJobSpecIntent(cluster, selector.String(), containerName,
+		serviceAccount.GetName(), configName, labels, annotations)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	jobSpec.Template.Spec.ImagePullSecrets = cluster.Spec.ImagePullSecrets
+
+	// A standby cluster does not archive WAL, so the check would always fail
+	suspend := cluster.Spec.Standby != nil && cluster.Spec.Standby.Enabled
+
+	cronJob := &batchv1beta1.CronJob{
+		ObjectMeta: objectmeta,
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule: check.Schedule,
+			Suspend:  &suspend,
+			// there is no reason to run more than one check at a time
+			ConcurrencyPolicy: batchv1beta1.ForbidConcurrent,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: annotations, Labels: labels},
+				Spec:       *jobSpec,
+			},
+		},
+	}
+	cronJob.SetGroupVersionKind(batchv1beta1.SchemeGroupVersion.WithKind("CronJob"))
+	err = errors.WithStack(r.setControllerReference(cluster, cronJob))
+	if err == nil {
+		err = r.apply(ctx, cronJob)
+	}
+	if err != nil {
+		r.Recorder.Event(cluster, v1.EventTypeWarning, EventUnableToCreatePGBackRestCronJob,
+			err.Error())
+	}
+	return err
+}
+
+// setPGBackRestCheckCondition sets the PGBackRestCheck condition according to the most recent
+// pgBackRest check Job that has finished, if any.  An event is recorded as each Job finishes.
+func (r *Reconciler) setPGBackRestCheckCondition(cluster *v1beta1.PostgresCluster,
+	checkJobs []*batchv1.Job) {
+
+	var latest *batchv1.Job
+	for _, job := range checkJobs {
+		if !jobCompleted(job) && !jobFailed(job) {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&job.CreationTimestamp) {
+			latest = job
+		}
+	}
+	if latest == nil {
+		return
+	}
+
+	condition := metav1.Condition{
+		ObservedGeneration: cluster.GetGeneration(),
+		Type:               ConditionPGBackRestCheck,
+		Status:             metav1.ConditionTrue,
+		Reason:             EventPGBackRestCheckSucceeded,
+		Message:            fmt.Sprintf("pgBackRest check Job %s succeeded", latest.GetName()),
+	}
+	eventType := v1.EventTypeNormal
+	if jobFailed(latest) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = EventPGBackRestCheckFailed
+		condition.Message = fmt.Sprintf("pgBackRest check Job %s failed", latest.GetName())
+		eventType = v1.EventTypeWarning
+	}
+
+	// the message identifies the Job, so only record an event when a different Job finishes
+	if previous := meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionPGBackRestCheck); previous == nil || previous.Message != condition.Message {
+		r.Recorder.Event(cluster, eventType, condition.Reason, condition.Message)
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+}
@@ -47,7 +47,9 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -525,6 +527,14 @@ func TestReconcilePGBackRest(t *testing.T) {
 			"pgbackrest")
 		assert.Assert(t, returnedCronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].SecurityContext != &corev1.SecurityContext{})
 
+		// the Jobs created by the CronJob are run like all other backup Jobs, and are labeled
+		// as scheduled backups for the repo so that they are tracked and cleaned up accordingly
+		assert.Equal(t, returnedCronJob.Spec.JobTemplate.Spec.Template.Spec.RestartPolicy,
+			corev1.RestartPolicyNever)
+		jobLabels := returnedCronJob.Spec.JobTemplate.Labels
+		assert.Equal(t, jobLabels[naming.LabelPGBackRestBackup], string(naming.BackupScheduled))
+		assert.Equal(t, jobLabels[naming.LabelPGBackRestRepo], "repo1")
+		assert.Equal(t, jobLabels[naming.LabelCluster], postgresCluster.Name)
 	})
 
 	t.Run("verify pgbackrest schedule found", func(t *testing.T) {
@@ -584,6 +594,25 @@ func TestReconcilePGBackRest(t *testing.T) {
 			assert.Assert(t, *returnedCronJob.Spec.Suspend)
 		})
 
+		t.Run("shutdown without primary", func(t *testing.T) {
+			*postgresCluster.Spec.Shutdown = false
+			assert.Assert(t, !r.reconcileScheduledBackups(ctx,
+				postgresCluster, instances, serviceAccount))
+
+			// the instances no longer exist once the cluster is shut down
+			*postgresCluster.Spec.Shutdown = true
+			requeue := r.reconcileScheduledBackups(ctx,
+				postgresCluster, &observedInstances{}, serviceAccount)
+			assert.Assert(t, !requeue)
+
+			assert.NilError(t, tClient.Get(ctx, types.NamespacedName{
+				Name:      postgresCluster.Name + "-pgbackrest-repo1-full",
+				Namespace: postgresCluster.GetNamespace(),
+			}, returnedCronJob))
+
+			assert.Assert(t, *returnedCronJob.Spec.Suspend)
+		})
+
 		t.Run("standby", func(t *testing.T) {
 			*postgresCluster.Spec.Shutdown = false
 			postgresCluster.Spec.Standby = &v1beta1.PostgresStandbySpec{
@@ -601,6 +630,144 @@ func TestReconcilePGBackRest(t *testing.T) {
 
 			assert.Assert(t, *returnedCronJob.Spec.Suspend)
 		})
+
+		t.Run("no full backup", func(t *testing.T) {
+			postgresCluster.Spec.Standby = nil
+			repoStatus := &postgresCluster.Status.PGBackRest.Repos[0]
+			repoStatus.BackupCounts = &v1beta1.RepoBackupCounts{Incremental: 1}
+			t.Cleanup(func() { repoStatus.BackupCounts = nil })
+
+			assert.Assert(t, !r.reconcileScheduledBackups(ctx,
+				postgresCluster, instances, serviceAccount))
+
+			suspended := func(backupType string) bool {
+				cronJob := &batchv1beta1.CronJob{}
+				assert.NilError(t, tClient.Get(ctx, types.NamespacedName{
+					Name:      postgresCluster.Name + "-pgbackrest-repo1-" + backupType,
+					Namespace: postgresCluster.GetNamespace(),
+				}, cronJob))
+				return *cronJob.Spec.Suspend
+			}
+
+			// only the full backup runs while the repo does not contain a full backup
+			assert.Assert(t, !suspended("full"))
+			assert.Assert(t, suspended("diff"))
+			assert.Assert(t, suspended("incr"))
+
+			// the others resume once the repo contains a full backup
+			repoStatus.BackupCounts.Full = 1
+			assert.Assert(t, !r.reconcileScheduledBackups(ctx,
+				postgresCluster, instances, serviceAccount))
+
+			assert.Assert(t, !suspended("diff"))
+			assert.Assert(t, !suspended("incr"))
+		})
+
+		t.Run("full backup in progress", func(t *testing.T) {
+			postgresCluster.Spec.Standby = nil
+
+			fullCronJob := &batchv1beta1.CronJob{}
+			assert.NilError(t, tClient.Get(ctx, types.NamespacedName{
+				Name:      postgresCluster.Name + "-pgbackrest-repo1-full",
+				Namespace: postgresCluster.GetNamespace(),
+			}, fullCronJob))
+			fullCronJob.Status.Active = []corev1.ObjectReference{{
+				Kind: "Job", Name: "full-backup", Namespace: postgresCluster.GetNamespace(),
+			}}
+			assert.NilError(t, tClient.Status().Update(ctx, fullCronJob))
+
+			requeue := r.reconcileScheduledBackups(ctx,
+				postgresCluster, instances, serviceAccount)
+			assert.Assert(t, !requeue)
+
+			assert.NilError(t, tClient.Get(ctx, types.NamespacedName{
+				Name:      postgresCluster.Name + "-pgbackrest-repo1-full",
+				Namespace: postgresCluster.GetNamespace(),
+			}, returnedCronJob))
+			assert.Assert(t, !*returnedCronJob.Spec.Suspend)
+
+			for _, backupType := range []string{"diff", "incr"} {
+				assert.NilError(t, tClient.Get(ctx, types.NamespacedName{
+					Name:      postgresCluster.Name + "-pgbackrest-repo1-" + backupType,
+					Namespace: postgresCluster.GetNamespace(),
+				}, returnedCronJob))
+				assert.Assert(t, *returnedCronJob.Spec.Suspend, backupType)
+			}
+
+			// resume once the full backup is no longer running
+			fullCronJob.Status.Active = nil
+			assert.NilError(t, tClient.Status().Update(ctx, fullCronJob))
+
+			requeue = r.reconcileScheduledBackups(ctx,
+				postgresCluster, instances, serviceAccount)
+			assert.Assert(t, !requeue)
+
+			for _, backupType := range []string{"diff", "incr"} {
+				assert.NilError(t, tClient.Get(ctx, types.NamespacedName{
+					Name:      postgresCluster.Name + "-pgbackrest-repo1-" + backupType,
+					Namespace: postgresCluster.GetNamespace(),
+				}, returnedCronJob))
+				assert.Assert(t, !*returnedCronJob.Spec.Suspend, backupType)
+			}
+		})
+
+		t.Run("missing repo status", func(t *testing.T) {
+			// e.g. while the volume of the repo is not owned by the cluster
+			cluster := postgresCluster.DeepCopy()
+			cluster.Status.PGBackRest.Repos = nil
+
+			requeue := r.reconcileScheduledBackups(ctx, cluster, instances, serviceAccount)
+			assert.Assert(t, !requeue)
+
+			assert.NilError(t, tClient.Get(ctx, types.NamespacedName{
+				Name:      postgresCluster.Name + "-pgbackrest-repo1-full",
+				Namespace: postgresCluster.GetNamespace(),
+			}, returnedCronJob))
+			assert.Assert(t, *returnedCronJob.Spec.Suspend)
+
+			// the CronJob resumes once the repo has status again
+			assert.Assert(t, !r.reconcileScheduledBackups(ctx,
+				postgresCluster, instances, serviceAccount))
+			assert.NilError(t, tClient.Get(ctx, types.NamespacedName{
+				Name:      postgresCluster.Name + "-pgbackrest-repo1-full",
+				Namespace: postgresCluster.GetNamespace(),
+			}, returnedCronJob))
+			assert.Assert(t, !*returnedCronJob.Spec.Suspend)
+		})
+	})
+
+	t.Run("incremental schedule without full schedule", func(t *testing.T) {
+		cluster := postgresCluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+			Name: "repo1",
+			BackupSchedules: &v1beta1.PGBackRestBackupSchedules{
+				Incremental: initialize.String("@monthly"),
+			},
+		}}
+
+		requeue := r.reconcileScheduledBackups(ctx, cluster, instances, serviceAccount)
+		assert.Assert(t, !requeue)
+
+		// the CronJob is still reconciled, and the missing full schedule is reported
+		cronJob := &batchv1beta1.CronJob{}
+		assert.NilError(t, tClient.Get(ctx, types.NamespacedName{
+			Name:      cluster.Name + "-pgbackrest-repo1-incr",
+			Namespace: cluster.GetNamespace(),
+		}, cronJob))
+		assert.Equal(t, cronJob.Spec.Schedule, "@monthly")
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions,
+			ConditionFullScheduleMissing)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionTrue)
+		assert.Assert(t, strings.Contains(condition.Message, "repo1"))
+
+		// the condition is removed once full backups are scheduled
+		cluster.Spec.Backups.PGBackRest.Repos[0].BackupSchedules.Full =
+			initialize.String("@yearly")
+		assert.Assert(t, !r.reconcileScheduledBackups(ctx, cluster, instances, serviceAccount))
+		assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions,
+			ConditionFullScheduleMissing) == nil)
 	})
 }
 
@@ -637,6 +804,8 @@ func TestReconcilePGBackRestRBAC(t *testing.T) {
 	postgresCluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
 		Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: false}},
 	}
+	postgresCluster.Spec.Backups.PGBackRest.ServiceAccountImagePullSecrets =
+		[]corev1.LocalObjectReference{{Name: "registry-credentials"}}
 
 	serviceAccount, err := r.reconcilePGBackRestRBAC(ctx, postgresCluster)
 	assert.NilError(t, err)
@@ -649,6 +818,8 @@ func TestReconcilePGBackRestRBAC(t *testing.T) {
 		Namespace: postgresCluster.GetNamespace(),
 	}, sa)
 	assert.NilError(t, err)
+	assert.DeepEqual(t, sa.ImagePullSecrets,
+		[]corev1.LocalObjectReference{{Name: "registry-credentials"}})
 
 	role := &rbacv1.Role{}
 	err = tClient.Get(ctx, types.NamespacedName{
@@ -778,6 +949,55 @@ func TestReconcileStanzaCreate(t *testing.T) {
 	// status should indicate stanzas were created
 	for _, r := range postgresCluster.Status.PGBackRest.Repos {
 		assert.Assert(t, r.StanzaCreated)
+		assert.Assert(t, r.StanzaCreatedAt != nil)
+		assert.Equal(t, r.StanzaConfigHash, "abcde12345")
+	}
+	assert.Equal(t, postgresCluster.Status.PGBackRest.StanzaConfigHash, "abcde12345")
+
+	// stanza creation should be skipped entirely (i.e. without exec'ing) while the config hash
+	// is unchanged
+	r.PodExec = stanzaCreateFail
+	configHashMistmatch, err = r.reconcileStanzaCreate(ctx, postgresCluster, instances, "abcde12345")
+	assert.NilError(t, err)
+	assert.Assert(t, !configHashMistmatch)
+
+	// stanza creation is forced by a new value for the stanza create annotation
+	postgresCluster.SetAnnotations(map[string]string{naming.PGBackRestStanzaCreate: "retry"})
+	r.PodExec = stanzaCreateSuccess
+	configHashMistmatch, err = r.reconcileStanzaCreate(ctx, postgresCluster, instances, "abcde12345")
+	assert.NilError(t, err)
+	assert.Assert(t, !configHashMistmatch)
+	assert.Equal(t, postgresCluster.Status.PGBackRest.StanzaCreateID, "retry")
+
+	// and is skipped again once stanzas are created for that value
+	r.PodExec = stanzaCreateFail
+	configHashMistmatch, err = r.reconcileStanzaCreate(ctx, postgresCluster, instances, "abcde12345")
+	assert.NilError(t, err)
+	assert.Assert(t, !configHashMistmatch)
+
+	// when the configuration changes, no repo is ready for backups until its stanza is created
+	// again, e.g. while the new configuration propagates
+	r.PodExec = func(namespace, pod, container string, stdin io.Reader, stdout,
+		stderr io.Writer, command ...string) error {
+		_, _ = stderr.Write([]byte("postgres operator error: pgBackRest config hash mismatch"))
+		return errors.New("exit status 1")
+	}
+	configHashMistmatch, err = r.reconcileStanzaCreate(ctx, postgresCluster, instances, "fghij67890")
+	assert.NilError(t, err)
+	assert.Assert(t, configHashMistmatch)
+	for _, r := range postgresCluster.Status.PGBackRest.Repos {
+		assert.Assert(t, !r.StanzaCreated)
+	}
+	assert.Equal(t, meta.FindStatusCondition(postgresCluster.Status.Conditions,
+		ConditionReplicaRepoReady).Status, metav1.ConditionFalse)
+
+	r.PodExec = stanzaCreateSuccess
+	configHashMistmatch, err = r.reconcileStanzaCreate(ctx, postgresCluster, instances, "fghij67890")
+	assert.NilError(t, err)
+	assert.Assert(t, !configHashMistmatch)
+	for _, r := range postgresCluster.Status.PGBackRest.Repos {
+		assert.Assert(t, r.StanzaCreated)
+		assert.Equal(t, r.StanzaConfigHash, "fghij67890")
 	}
 
 	// now verify failure event
@@ -822,163 +1042,3218 @@ func TestReconcileStanzaCreate(t *testing.T) {
 	// status should indicate stanza were not created
 	for _, r := range postgresCluster.Status.PGBackRest.Repos {
 		assert.Assert(t, !r.StanzaCreated)
+		assert.Assert(t, r.StanzaCreatedAt == nil)
+		assert.Equal(t, r.StanzaConfigHash, "")
 	}
-}
-
-func TestGetPGBackRestExecSelector(t *testing.T) {
 
-	testCases := []struct {
-		cluster           *v1beta1.PostgresCluster
-		desc              string
-		expectedSelector  string
-		expectedContainer string
-	}{{
-		desc: "dedicated repo host enabled",
-		cluster: &v1beta1.PostgresCluster{
-			ObjectMeta: metav1.ObjectMeta{Name: "hippo"},
-			Spec: v1beta1.PostgresClusterSpec{
-				Backups: v1beta1.Backups{
-					PGBackRest: v1beta1.PGBackRestArchive{
-						RepoHost: &v1beta1.PGBackRestRepoHost{
-							Dedicated: &v1beta1.DedicatedRepo{},
-						},
-					},
-				},
-			},
-		},
-		expectedSelector: "postgres-operator.crunchydata.com/cluster=hippo," +
-			"postgres-operator.crunchydata.com/pgbackrest=," +
-			"postgres-operator.crunchydata.com/pgbackrest-dedicated=," +
-			"postgres-operator.crunchydata.com/pgbackrest-host=",
-		expectedContainer: "pgbackrest",
-	}, {
-		desc: "repo host enabled",
-		cluster: &v1beta1.PostgresCluster{
-			ObjectMeta: metav1.ObjectMeta{Name: "hippo"},
-			Spec: v1beta1.PostgresClusterSpec{
-				Backups: v1beta1.Backups{
-					PGBackRest: v1beta1.PGBackRestArchive{
-						RepoHost: &v1beta1.PGBackRestRepoHost{},
-					},
-				},
-			},
-		},
-		expectedSelector: "postgres-operator.crunchydata.com/cluster=hippo," +
-			"postgres-operator.crunchydata.com/instance," +
-			"postgres-operator.crunchydata.com/role=master",
-		expectedContainer: "pgbackrest",
-	}, {
-		desc: "no repo host enabled",
-		cluster: &v1beta1.PostgresCluster{
-			ObjectMeta: metav1.ObjectMeta{Name: "hippo"},
-			Spec: v1beta1.PostgresClusterSpec{
-				Backups: v1beta1.Backups{
-					PGBackRest: v1beta1.PGBackRestArchive{},
-				},
-			},
-		},
-		expectedSelector: "postgres-operator.crunchydata.com/cluster=hippo," +
-			"postgres-operator.crunchydata.com/instance," +
-			"postgres-operator.crunchydata.com/role=master",
-		expectedContainer: "database",
-	}}
+	// stanza creation is skipped without error while the cluster is shutdown
+	postgresCluster.Spec.Shutdown = initialize.Bool(true)
+	configHashMismatch, err = r.reconcileStanzaCreate(ctx, postgresCluster, instances, "abcde12345")
+	assert.NilError(t, err)
+	assert.Assert(t, !configHashMismatch)
 
-	for _, tc := range testCases {
-		t.Run(tc.desc, func(t *testing.T) {
-			selector, container, err := getPGBackRestExecSelector(tc.cluster)
-			assert.NilError(t, err)
-			assert.Assert(t, selector.String() == tc.expectedSelector)
-			assert.Assert(t, container == tc.expectedContainer)
-		})
+	// stanza-create is never run when stanzas are managed externally, and the repos are
+	// reported as ready for backups
+	postgresCluster.Spec.Shutdown = nil
+	postgresCluster.Spec.Backups.PGBackRest.ManageStanza = initialize.Bool(false)
+	r.PodExec = func(namespace, pod, container string, stdin io.Reader, stdout,
+		stderr io.Writer, command ...string) error {
+		return errors.New("stanza-create should not run")
+	}
+	configHashMismatch, err = r.reconcileStanzaCreate(ctx, postgresCluster, instances, "abcde12345")
+	assert.NilError(t, err)
+	assert.Assert(t, !configHashMismatch)
+	for _, r := range postgresCluster.Status.PGBackRest.Repos {
+		assert.Assert(t, r.StanzaCreated)
+		assert.Assert(t, r.StanzaCreatedAt == nil)
 	}
+	condition := meta.FindStatusCondition(postgresCluster.Status.Conditions,
+		ConditionReplicaRepoReady)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, "StanzaExternallyManaged")
 }
 
-func TestReconcileReplicaCreateBackup(t *testing.T) {
+func TestAdoptStanzas(t *testing.T) {
+	ctx := context.Background()
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+		{Name: "repo1"}, {Name: "repo3"},
+	}
 
-	// setup the test environment and ensure a clean teardown
-	tEnv, tClient, cfg := setupTestEnv(t, ControllerName)
-	t.Cleanup(func() { teardownTestEnv(t, tEnv) })
-	r := &Reconciler{}
-	ctx, cancel := setupManager(t, cfg, func(mgr manager.Manager) {
-		r = &Reconciler{
-			Client:   mgr.GetClient(),
-			Recorder: mgr.GetEventRecorderFor(ControllerName),
-			Tracer:   otel.Tracer(ControllerName),
-			Owner:    ControllerName,
+	info := func(output string) pgbackrest.Executor {
+		return func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			_, _ = stdout.Write([]byte(output))
+			return nil
 		}
-	})
-	t.Cleanup(func() { teardownManager(cancel, t) })
+	}
+	repos := func(keys ...string) string {
+		var db, repo []string
+		for _, key := range keys {
+			db = append(db, `{"id":1,"repo-key":`+key+`,"system-id":123}`)
+			repo = append(repo, `{"key":`+key+`,"status":{"code":0}}`)
+		}
+		return `[{"name":"db","db":[` + strings.Join(db, ",") + `],"repo":[` +
+			strings.Join(repo, ",") + `]}]`
+	}
 
-	clusterName := "hippocluster"
-	clusterUID := "hippouid"
+	adopted, mismatch, err := adoptStanzas(ctx, info(repos("1", "3")), cluster, "hash", "123")
+	assert.NilError(t, err)
+	assert.Assert(t, adopted)
+	assert.Assert(t, !mismatch)
 
-	ns := &v1.Namespace{}
-	ns.GenerateName = "postgres-operator-test-"
-	assert.NilError(t, tClient.Create(ctx, ns))
-	t.Cleanup(func() { assert.Check(t, tClient.Delete(ctx, ns)) })
-	namespace := ns.Name
+	// every repo must contain a valid stanza
+	adopted, _, err = adoptStanzas(ctx, info(repos("1")), cluster, "hash", "123")
+	assert.NilError(t, err)
+	assert.Assert(t, !adopted)
 
-	// create a PostgresCluster to test with
-	postgresCluster := fakePostgresCluster(clusterName, ns.GetName(), clusterUID, true)
-	// set status for the "replica create" repo, e.g. the repo ad index 0
-	postgresCluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
-		Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: false}},
-	}
-	instances := newObservedInstances(postgresCluster, nil, []corev1.Pod{{
-		ObjectMeta: metav1.ObjectMeta{
-			Annotations: map[string]string{"status": `"role":"master"`},
-		},
-	}})
+	// for the same PostgreSQL cluster
+	adopted, _, err = adoptStanzas(ctx, info(repos("1", "3")), cluster, "hash", "456")
+	assert.NilError(t, err)
+	assert.Assert(t, !adopted)
+
+	// a config hash mismatch is reported
+	adopted, mismatch, err = adoptStanzas(ctx, func(ctx context.Context, stdin io.Reader,
+		stdout, stderr io.Writer, command ...string) error {
+		_, _ = stderr.Write([]byte("postgres operator error: pgBackRest config hash mismatch"))
+		return errors.New("exit status 1")
+	}, cluster, "hash", "123")
+	assert.NilError(t, err)
+	assert.Assert(t, !adopted)
+	assert.Assert(t, mismatch)
+}
 
-	// first add a fake dedicated repo pod to the env
-	repoHost := &corev1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "fake-repo-host",
-			Namespace: namespace,
-			Labels:    naming.PGBackRestDedicatedLabels(clusterName),
-		},
-		Spec: v1.PodSpec{
-			Containers: []v1.Container{{Name: "test", Image: "test"}},
-		},
-	}
-	assert.NilError(t, r.Client.Create(ctx, repoHost))
+func TestBackupCountsRefresh(t *testing.T) {
+	now := time.Now()
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
 
-	err := wait.Poll(time.Second/2, time.Second*3, func() (bool, error) {
-		if err := r.Client.Get(ctx,
-			client.ObjectKeyFromObject(repoHost), &corev1.Pod{}); err != nil {
-			return false, nil
-		}
-		return true, nil
-	})
-	assert.NilError(t, err)
+	// counted immediately when never counted
+	assert.Equal(t, backupCountsRefresh(cluster, now), time.Duration(0))
 
-	meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
-		ObservedGeneration: postgresCluster.GetGeneration(),
-		Type:               ConditionRepoHostReady,
-		Status:             metav1.ConditionTrue,
-		Reason:             "RepoHostReady",
-		Message:            "pgBackRest dedicated repository host is ready",
-	})
-	meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
-		ObservedGeneration: postgresCluster.GetGeneration(),
-		Type:               ConditionReplicaRepoReady,
-		Status:             metav1.ConditionTrue,
-		Reason:             "StanzaCreated",
-		Message:            "pgBackRest replica create repo is ready for backups",
-	})
-	postgresCluster.Status.Patroni = &v1beta1.PatroniStatus{
-		SystemIdentifier: "6952526174828511264",
+	// otherwise counted periodically
+	updated := metav1.NewTime(now.Add(-10 * time.Minute))
+	cluster.Status.PGBackRest.BackupCountsUpdateTime = &updated
+	assert.Equal(t, backupCountsRefresh(cluster, now), 50*time.Minute)
+
+	// or once a backup completes
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+	later := metav1.NewTime(now.Add(-time.Minute))
+	cluster.Status.PGBackRest.LatestBackups = []v1beta1.PGBackRestLatestBackupStatus{
+		{Type: "scheduled", CompletionTime: &earlier},
 	}
+	assert.Equal(t, backupCountsRefresh(cluster, now), 50*time.Minute)
+	cluster.Status.PGBackRest.LatestBackups = append(cluster.Status.PGBackRest.LatestBackups,
+		v1beta1.PGBackRestLatestBackupStatus{Type: "manual", CompletionTime: &later})
+	assert.Equal(t, backupCountsRefresh(cluster, now), time.Duration(0))
+}
 
-	replicaCreateRepo := postgresCluster.Spec.Backups.PGBackRest.Repos[0].Name
-	configHash := "abcde12345"
+func TestReconcileBackupCounts(t *testing.T) {
+	ctx := context.Background()
 
-	sa := &corev1.ServiceAccount{
-		ObjectMeta: metav1.ObjectMeta{Name: "hippo-sa"},
-	}
+	scheme := runtime.NewScheme()
+	assert.NilError(t, corev1.AddToScheme(scheme))
 
-	err = r.reconcileReplicaCreateBackup(ctx, postgresCluster, instances,
-		[]*batchv1.Job{}, sa, configHash, replicaCreateRepo)
+	repoHost := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name: "hippo-repo-host-0", Namespace: "ns",
+		Labels: naming.PGBackRestDedicatedLabels("hippo"),
+	}}
+
+	var execs int
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(repoHost).Build(),
+		PodExec: func(namespace, pod, container string, stdin io.Reader, stdout,
+			stderr io.Writer, command ...string) error {
+			execs++
+			assert.Equal(t, pod, "hippo-repo-host-0")
+			_, _ = stdout.Write([]byte(`[{"name":"db","backup":[
+				{"database":{"id":1,"repo-key":1},"type":"full"}],"repo":[{"key":1}]}]`))
+			return nil
+		},
+	}
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{
+		Dedicated: &v1beta1.DedicatedRepo{},
+	}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{Name: "repo1"}}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{{Name: "repo1"}},
+	}
+
+	// nothing is counted until stanzas are created
+	refresh, err := r.reconcileBackupCounts(ctx, cluster)
+	assert.NilError(t, err)
+	assert.Equal(t, refresh, time.Duration(0))
+	assert.Equal(t, execs, 0)
+
+	cluster.Status.PGBackRest.Repos[0].StanzaCreated = true
+	refresh, err = r.reconcileBackupCounts(ctx, cluster)
+	assert.NilError(t, err)
+	assert.Equal(t, refresh, backupCountsInterval)
+	assert.Equal(t, execs, 1)
+	assert.DeepEqual(t, cluster.Status.PGBackRest.Repos[0].BackupCounts,
+		&v1beta1.RepoBackupCounts{Full: 1})
+	assert.Assert(t, cluster.Status.PGBackRest.BackupCountsUpdateTime != nil)
+
+	// and not counted again until the next refresh
+	refresh, err = r.reconcileBackupCounts(ctx, cluster)
+	assert.NilError(t, err)
+	assert.Assert(t, refresh > 0 && refresh <= backupCountsInterval)
+	assert.Equal(t, execs, 1)
+}
+
+func TestCreatedBackupSet(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, time.January, 1, hour, minute, 0, 0, time.UTC)
+	}
+	job := &batchv1.Job{}
+	job.Labels = map[string]string{naming.LabelPGBackRestRepo: "repo1"}
+
+	sets := []pgbackrest.BackupSet{
+		{Label: "previous", RepoIndex: "1", Start: at(11, 0), Stop: at(11, 5)},
+		{Label: "other-repo", RepoIndex: "2", Start: at(12, 1), Stop: at(12, 8)},
+		{Label: "created", RepoIndex: "1", Start: at(12, 1), Stop: at(12, 9), Size: 1024},
+	}
+
+	// nothing until the Job has completed
+	_, found := createdBackupSet(job, sets)
+	assert.Assert(t, !found)
+
+	start, completion := metav1.NewTime(at(12, 0)), metav1.NewTime(at(12, 10))
+	job.Status.StartTime, job.Status.CompletionTime = &start, &completion
+	set, found := createdBackupSet(job, sets)
+	assert.Assert(t, found)
+	assert.Equal(t, set.Label, "created")
+	assert.Equal(t, set.Size, int64(1024))
+
+	// only backup sets in the repo of the Job are considered
+	job.Labels[naming.LabelPGBackRestRepo] = "repo3"
+	_, found = createdBackupSet(job, sets)
+	assert.Assert(t, !found)
+}
+
+func TestBackupTypeLabels(t *testing.T) {
+	assert.Assert(t, backupTypeLabels(nil) == nil)
+	assert.Assert(t, backupTypeLabels([]string{"--start-fast=y"}) == nil)
+	assert.Assert(t, backupTypeLabels([]string{"--type=bogus value"}) == nil)
+
+	assert.DeepEqual(t, backupTypeLabels([]string{"--type=full"}),
+		map[string]string{naming.LabelPGBackRestBackupType: "full"})
+
+	// the last type provided is used
+	assert.DeepEqual(t, backupTypeLabels([]string{"--type=full", "--start-fast=y", "--type=diff"}),
+		map[string]string{naming.LabelPGBackRestBackupType: "diff"})
+}
+
+func TestSetBackupMetrics(t *testing.T) {
+	job := &batchv1.Job{}
+	backup := v1beta1.PGBackRestLatestBackupStatus{}
+
+	// nothing until the Job has completed
+	start := metav1.NewTime(time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC))
+	job.Status.StartTime = &start
+	setBackupMetrics(&backup, job)
+	assert.DeepEqual(t, backup, v1beta1.PGBackRestLatestBackupStatus{})
+
+	// the duration is known once the Job completes
+	completion := metav1.NewTime(start.Add(10 * time.Minute))
+	job.Status.CompletionTime = &completion
+	setBackupMetrics(&backup, job)
+	assert.Equal(t, backup.DurationSeconds, int64(600))
+	assert.Equal(t, backup.BackupSizeBytes, int64(0))
+	assert.Equal(t, backup.ThroughputBytesPerSecond, int64(0))
+
+	// the size and throughput are known once the size of the backup set is recorded
+	job.Annotations = map[string]string{naming.PGBackRestBackupSize: "62914560"}
+	setBackupMetrics(&backup, job)
+	assert.Equal(t, backup.BackupSizeBytes, int64(62914560))
+	assert.Equal(t, backup.ThroughputBytesPerSecond, int64(104857))
+
+	// backups shorter than a second are treated as taking a full second
+	job.Status.CompletionTime = &start
+	setBackupMetrics(&backup, job)
+	assert.Equal(t, backup.DurationSeconds, int64(0))
+	assert.Equal(t, backup.ThroughputBytesPerSecond, int64(62914560))
+}
+
+func TestReconcileBackupSets(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, corev1.AddToScheme(scheme))
+	assert.NilError(t, batchv1.AddToScheme(scheme))
+
+	repoHost := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name: "hippo-repo-host-0", Namespace: "ns",
+		Labels: naming.PGBackRestDedicatedLabels("hippo"),
+	}}
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Name: "hippo-backup-abcd", Namespace: "ns",
+		Labels: map[string]string{naming.LabelPGBackRestRepo: "repo1"},
+	}}
+	start := metav1.NewTime(time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC))
+	completion := metav1.NewTime(start.Add(10 * time.Minute))
+	job.Status.StartTime = &start
+	job.Status.Conditions = []batchv1.JobCondition{{
+		Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+	}}
+
+	var execs int
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(repoHost, job).Build(),
+		PodExec: func(namespace, pod, container string, stdin io.Reader, stdout,
+			stderr io.Writer, command ...string) error {
+			execs++
+			assert.Equal(t, pod, "hippo-repo-host-0")
+			_, _ = stdout.Write([]byte(`[{"name":"db","backup":[
+				{"database":{"id":1,"repo-key":1},"info":{"delta":31457280},
+				"label":"20240101-120100F",
+				"timestamp":{"start":1704110460,"stop":1704110520},"type":"full"}],
+				"repo":[{"key":1}]}]`))
+			return nil
+		},
+	}
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{
+		Dedicated: &v1beta1.DedicatedRepo{},
+	}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		LatestBackups: []v1beta1.PGBackRestLatestBackupStatus{{
+			Type: string(naming.BackupManual), JobName: job.Name, Finished: true,
+		}},
+	}
+	repoResources := &RepoResources{manualBackupJobs: []*batchv1.Job{job}}
+
+	// the backup set is not found until the Job has a completion time
+	assert.NilError(t, r.reconcileBackupSets(ctx, cluster, repoResources))
+	assert.Equal(t, execs, 1)
+	assert.Equal(t, cluster.Status.PGBackRest.LatestBackups[0].BackupSet, "")
+
+	job.Status.CompletionTime = &completion
+	assert.NilError(t, r.reconcileBackupSets(ctx, cluster, repoResources))
+	assert.Equal(t, execs, 2)
+	assert.Equal(t, cluster.Status.PGBackRest.LatestBackups[0].BackupSet, "20240101-120100F")
+	assert.Equal(t, job.GetAnnotations()[naming.PGBackRestBackupSet], "20240101-120100F")
+	assert.Equal(t, job.GetAnnotations()[naming.PGBackRestBackupSize], "31457280")
+	assert.Equal(t, cluster.Status.PGBackRest.LatestBackups[0].DurationSeconds, int64(600))
+	assert.Equal(t, cluster.Status.PGBackRest.LatestBackups[0].BackupSizeBytes, int64(31457280))
+	assert.Equal(t, cluster.Status.PGBackRest.LatestBackups[0].ThroughputBytesPerSecond,
+		int64(52428))
+
+	stored := &batchv1.Job{}
+	assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(job), stored))
+	assert.Equal(t, stored.GetAnnotations()[naming.PGBackRestBackupSet], "20240101-120100F")
+
+	// nothing is run once the backup set is known
+	assert.NilError(t, r.reconcileBackupSets(ctx, cluster, repoResources))
+	assert.Equal(t, execs, 2)
+}
+
+func TestWithCommandOutput(t *testing.T) {
+	assert.Equal(t, withCommandOutput("not ready", nil), "not ready")
+	assert.Equal(t, withCommandOutput("not ready", errors.New("boom")), "not ready")
+
+	err := &pgbackrest.CommandError{
+		Err: errors.New("exit status 1"), Stderr: "ERROR: [039]: repo1: unable to reach\n",
+	}
+	assert.Equal(t, withCommandOutput("not ready", err),
+		"not ready: ERROR: [039]: repo1: unable to reach")
+}
+
+func TestExecPods(t *testing.T) {
+	pod := func(name string, ready v1.ConditionStatus) v1.Pod {
+		p := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if ready != "" {
+			p.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: ready}}
+		}
+		return p
+	}
+	names := func(pods []v1.Pod) []string {
+		var out []string
+		for _, p := range pods {
+			out = append(out, p.Name)
+		}
+		return out
+	}
+
+	assert.Assert(t, len(execPods(nil)) == 0)
+
+	// a single Pod is always tried, even when it is not Ready
+	assert.DeepEqual(t, names(execPods([]v1.Pod{pod("a", "")})), []string{"a"})
+
+	// Ready Pods are tried first, otherwise preserving order
+	assert.DeepEqual(t, names(execPods([]v1.Pod{
+		pod("a", v1.ConditionFalse), pod("b", ""), pod("c", v1.ConditionTrue),
+		pod("d", v1.ConditionTrue),
+	})), []string{"c", "d", "a", "b"})
+
+	// Pods being deleted are skipped
+	deleting := pod("e", v1.ConditionTrue)
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	assert.DeepEqual(t, names(execPods([]v1.Pod{deleting, pod("f", v1.ConditionFalse)})),
+		[]string{"f"})
+	assert.Assert(t, len(execPods([]v1.Pod{deleting})) == 0)
+}
+
+func TestRepoHostMinReady(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{}
+	assert.Equal(t, repoHostMinReady(cluster), time.Duration(0))
+
+	cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{
+		Dedicated: &v1beta1.DedicatedRepo{},
+	}
+	assert.Equal(t, repoHostMinReady(cluster), time.Duration(0))
+
+	cluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.MinReadySeconds = initialize.Int32(30)
+	assert.Equal(t, repoHostMinReady(cluster), 30*time.Second)
+}
+
+func TestPodsMinReadyRemaining(t *testing.T) {
+	now := time.Now()
+	pod := func(status v1.ConditionStatus, since time.Duration) v1.Pod {
+		p := v1.Pod{}
+		p.Status.Conditions = []v1.PodCondition{{
+			Type: v1.PodReady, Status: status,
+			LastTransitionTime: metav1.NewTime(now.Add(-since)),
+		}}
+		return p
+	}
+
+	// the full minimum remains without any ready Pods
+	assert.Equal(t, podsMinReadyRemaining(time.Minute, nil, now), time.Minute)
+	assert.Equal(t, podsMinReadyRemaining(time.Minute,
+		[]v1.Pod{pod(v1.ConditionFalse, time.Hour)}, now), time.Minute)
+
+	// a Pod that only recently became ready must remain ready for the rest of the minimum
+	assert.Equal(t, podsMinReadyRemaining(time.Minute,
+		[]v1.Pod{pod(v1.ConditionTrue, 20*time.Second)}, now), 40*time.Second)
+
+	// nothing remains once a Pod has been ready for the minimum
+	assert.Equal(t, podsMinReadyRemaining(time.Minute, []v1.Pod{
+		pod(v1.ConditionTrue, 20*time.Second), pod(v1.ConditionTrue, 2*time.Minute),
+	}, now), time.Duration(0))
+}
+
+func TestCleanupRepoResourcesRetainRepoVolumes(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, corev1.AddToScheme(scheme))
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns", UID: "hippo-uid"},
+	}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+		Name: "repo1", Volume: &v1beta1.RepoPVC{},
+	}}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: true}},
+	}
+
+	// a volume for a repo that has been removed from the spec
+	volume := func() unstructured.Unstructured {
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "hippo-repo2", Namespace: "ns",
+				Labels: naming.PGBackRestRepoVolumeLabels("hippo", "repo2"),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: v1beta1.GroupVersion.String(), Kind: "PostgresCluster",
+					Name: "hippo", UID: "hippo-uid", Controller: initialize.Bool(true),
+				}},
+			},
+		}
+		pvc.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"))
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pvc)
+		assert.NilError(t, err)
+		return unstructured.Unstructured{Object: content}
+	}
+
+	t.Run("Deleted", func(t *testing.T) {
+		owned := volume()
+		recorder := record.NewFakeRecorder(10)
+		r := &Reconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(&owned).Build(),
+			Owner:    client.FieldOwner(t.Name()),
+			Recorder: recorder,
+		}
+
+		remaining, _, err := r.cleanupRepoResources(ctx, cluster,
+			[]unstructured.Unstructured{owned})
+		assert.NilError(t, err)
+		assert.Equal(t, len(remaining), 0)
+		assert.Equal(t, len(recorder.Events), 0)
+
+		err = r.Client.Get(ctx, client.ObjectKeyFromObject(&owned),
+			&corev1.PersistentVolumeClaim{})
+		assert.Assert(t, kerr.IsNotFound(err), "expected NotFound, got %v", err)
+	})
+
+	t.Run("Blocked", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Status.PGBackRest.Repos = []v1beta1.RepoStatus{
+			{Name: "repo1", StanzaCreated: false},
+			{Name: "repo2", StanzaCreated: true},
+		}
+
+		owned := volume()
+		recorder := record.NewFakeRecorder(10)
+		r := &Reconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(&owned).Build(),
+			Owner:    client.FieldOwner(t.Name()),
+			Recorder: recorder,
+		}
+
+		// the volume is kept while no repo in the spec has a stanza
+		remaining, _, err := r.cleanupRepoResources(ctx, cluster,
+			[]unstructured.Unstructured{owned})
+		assert.NilError(t, err)
+		assert.Equal(t, len(remaining), 1)
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(&owned),
+			&corev1.PersistentVolumeClaim{}))
+
+		// and is deleted once the removal is confirmed
+		cluster.Annotations = map[string]string{naming.PGBackRestConfirmRepoRemoval: ""}
+		remaining, _, err = r.cleanupRepoResources(ctx, cluster,
+			[]unstructured.Unstructured{owned})
+		assert.NilError(t, err)
+		assert.Equal(t, len(remaining), 0)
+		assert.Equal(t, len(recorder.Events), 0)
+
+		err = r.Client.Get(ctx, client.ObjectKeyFromObject(&owned),
+			&corev1.PersistentVolumeClaim{})
+		assert.Assert(t, kerr.IsNotFound(err), "expected NotFound, got %v", err)
+	})
+
+	t.Run("NoRepos", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = nil
+		cluster.Annotations = map[string]string{naming.PGBackRestConfirmRepoRemoval: ""}
+
+		owned := volume()
+		r := &Reconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(&owned).Build(),
+			Owner:    client.FieldOwner(t.Name()),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		// the volume of the last repo is kept, even when its removal is confirmed
+		remaining, _, err := r.cleanupRepoResources(ctx, cluster,
+			[]unstructured.Unstructured{owned})
+		assert.NilError(t, err)
+		assert.Equal(t, len(remaining), 1)
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(&owned),
+			&corev1.PersistentVolumeClaim{}))
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Annotations = map[string]string{naming.PGBackRestDryRun: ""}
+
+		owned := volume()
+		recorder := record.NewFakeRecorder(10)
+		r := &Reconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(&owned).Build(),
+			Owner:    client.FieldOwner(t.Name()),
+			Recorder: recorder,
+		}
+
+		// the deletion is returned rather than made
+		remaining, changes, err := r.cleanupRepoResources(ctx, cluster,
+			[]unstructured.Unstructured{owned})
+		assert.NilError(t, err)
+		assert.Equal(t, len(remaining), 0)
+		assert.DeepEqual(t, changes, []string{"delete PersistentVolumeClaim hippo-repo2"})
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(&owned),
+			&corev1.PersistentVolumeClaim{}))
+		assert.Equal(t, len(recorder.Events), 0)
+
+		// nor is a blocked removal reported
+		blocked := cluster.DeepCopy()
+		blocked.Status.PGBackRest.Repos[0].StanzaCreated = false
+		remaining, changes, err = r.cleanupRepoResources(ctx, blocked,
+			[]unstructured.Unstructured{owned})
+		assert.NilError(t, err)
+		assert.Equal(t, len(remaining), 1)
+		assert.Equal(t, len(changes), 0)
+		assert.Equal(t, len(recorder.Events), 0)
+
+		// the volume is deleted once the dry run ends
+		cluster.Annotations = nil
+		_, changes, err = r.cleanupRepoResources(ctx, cluster,
+			[]unstructured.Unstructured{owned})
+		assert.NilError(t, err)
+		assert.Equal(t, len(changes), 0)
+		err = r.Client.Get(ctx, client.ObjectKeyFromObject(&owned),
+			&corev1.PersistentVolumeClaim{})
+		assert.Assert(t, kerr.IsNotFound(err), "expected NotFound, got %v", err)
+	})
+
+	t.Run("Retained", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Cleanup = &v1beta1.PGBackRestCleanup{
+			RetainRepoVolumes: initialize.Bool(true),
+		}
+
+		owned := volume()
+		recorder := record.NewFakeRecorder(10)
+		r := &Reconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(&owned).Build(),
+			Owner:    client.FieldOwner(t.Name()),
+			Recorder: recorder,
+		}
+
+		remaining, _, err := r.cleanupRepoResources(ctx, cluster,
+			[]unstructured.Unstructured{owned})
+		assert.NilError(t, err)
+		assert.Equal(t, len(remaining), 0)
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(&owned), pvc))
+		assert.Equal(t, len(pvc.GetOwnerReferences()), 0)
+
+		assert.Equal(t, len(recorder.Events), 1)
+		event := <-recorder.Events
+		assert.Assert(t, strings.Contains(event, EventRepoVolumeRetained), event)
+		assert.Assert(t, strings.Contains(event, "retained repo volume hippo-repo2"), event)
+	})
+
+	t.Run("Archived", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Cleanup = &v1beta1.PGBackRestCleanup{
+			RetainRepoVolumes:  initialize.Bool(true),
+			ArchiveRepoVolumes: initialize.Bool(true),
+		}
+
+		owned := volume()
+		recorder := record.NewFakeRecorder(10)
+		r := &Reconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(&owned).Build(),
+			Owner:    client.FieldOwner(t.Name()),
+			Recorder: recorder,
+		}
+
+		remaining, _, err := r.cleanupRepoResources(ctx, cluster,
+			[]unstructured.Unstructured{owned})
+		assert.NilError(t, err)
+		assert.Equal(t, len(remaining), 0)
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(&owned), pvc))
+		assert.Equal(t, len(pvc.GetOwnerReferences()), 0)
+
+		// the volume is no longer selected as a pgBackRest resource of the cluster
+		assert.Assert(t, !naming.PGBackRestLabels("hippo").AsSelector().Matches(
+			labels.Set(pvc.GetLabels())))
+		assert.DeepEqual(t, pvc.GetLabels(), map[string]string{
+			naming.LabelPGBackRestArchived: "",
+			naming.LabelPGBackRestRepo:     "repo2",
+		})
+		assert.Equal(t, pvc.GetAnnotations()[naming.PGBackRestArchivedCluster], "hippo")
+		_, err = time.Parse(time.RFC3339, pvc.GetAnnotations()[naming.PGBackRestArchivedTime])
+		assert.NilError(t, err)
+
+		assert.Equal(t, len(recorder.Events), 1)
+		event := <-recorder.Events
+		assert.Assert(t, strings.Contains(event, EventRepoVolumeArchived), event)
+		assert.Assert(t, strings.Contains(event, "archived repo volume hippo-repo2"), event)
+	})
+}
+
+func TestCleanupPropagationPolicy(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{}
+	volume := map[string]string{naming.LabelPGBackRestRepoVolume: ""}
+	repoHost := map[string]string{naming.LabelPGBackRestRepoHost: ""}
+	dedicated := map[string]string{
+		naming.LabelPGBackRestRepoHost: "", naming.LabelPGBackRestDedicated: ""}
+	cronJob := map[string]string{naming.LabelPGBackRestCronJob: "full"}
+	restore := map[string]string{naming.LabelPGBackRestRestore: ""}
+
+	// defaults to background deletion
+	for _, labels := range []map[string]string{volume, repoHost, dedicated, cronJob, restore} {
+		assert.Equal(t, cleanupPropagationPolicy(cluster, labels),
+			metav1.DeletePropagationBackground)
+	}
+
+	cluster.Spec.Backups.PGBackRest.Cleanup = &v1beta1.PGBackRestCleanup{
+		RepoVolumes: metav1.DeletePropagationForeground,
+		RepoHost:    metav1.DeletePropagationOrphan,
+	}
+	assert.Equal(t, cleanupPropagationPolicy(cluster, volume),
+		metav1.DeletePropagationForeground)
+	assert.Equal(t, cleanupPropagationPolicy(cluster, repoHost), metav1.DeletePropagationOrphan)
+	assert.Equal(t, cleanupPropagationPolicy(cluster, dedicated), metav1.DeletePropagationOrphan)
+
+	// types without a configured policy still use background deletion
+	assert.Equal(t, cleanupPropagationPolicy(cluster, cronJob),
+		metav1.DeletePropagationBackground)
+
+	cluster.Spec.Backups.PGBackRest.Cleanup.Jobs = metav1.DeletePropagationForeground
+	assert.Equal(t, cleanupPropagationPolicy(cluster, cronJob),
+		metav1.DeletePropagationForeground)
+	assert.Equal(t, cleanupPropagationPolicy(cluster, restore),
+		metav1.DeletePropagationForeground)
+}
+
+func TestGetPGBackRestExecSelector(t *testing.T) {
+
+	testCases := []struct {
+		cluster           *v1beta1.PostgresCluster
+		desc              string
+		expectedSelector  string
+		expectedContainer string
+	}{{
+		desc: "dedicated repo host enabled",
+		cluster: &v1beta1.PostgresCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "hippo"},
+			Spec: v1beta1.PostgresClusterSpec{
+				Backups: v1beta1.Backups{
+					PGBackRest: v1beta1.PGBackRestArchive{
+						RepoHost: &v1beta1.PGBackRestRepoHost{
+							Dedicated: &v1beta1.DedicatedRepo{},
+						},
+					},
+				},
+			},
+		},
+		expectedSelector: "postgres-operator.crunchydata.com/cluster=hippo," +
+			"postgres-operator.crunchydata.com/pgbackrest=," +
+			"postgres-operator.crunchydata.com/pgbackrest-dedicated=," +
+			"postgres-operator.crunchydata.com/pgbackrest-host=",
+		expectedContainer: "pgbackrest",
+	}, {
+		desc: "repo host enabled",
+		cluster: &v1beta1.PostgresCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "hippo"},
+			Spec: v1beta1.PostgresClusterSpec{
+				Backups: v1beta1.Backups{
+					PGBackRest: v1beta1.PGBackRestArchive{
+						RepoHost: &v1beta1.PGBackRestRepoHost{},
+					},
+				},
+			},
+		},
+		expectedSelector: "postgres-operator.crunchydata.com/cluster=hippo," +
+			"postgres-operator.crunchydata.com/instance," +
+			"postgres-operator.crunchydata.com/role=master",
+		expectedContainer: "pgbackrest",
+	}, {
+		desc: "no repo host enabled",
+		cluster: &v1beta1.PostgresCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "hippo"},
+			Spec: v1beta1.PostgresClusterSpec{
+				Backups: v1beta1.Backups{
+					PGBackRest: v1beta1.PGBackRestArchive{},
+				},
+			},
+		},
+		expectedSelector: "postgres-operator.crunchydata.com/cluster=hippo," +
+			"postgres-operator.crunchydata.com/instance," +
+			"postgres-operator.crunchydata.com/role=master",
+		expectedContainer: "database",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			selector, container, err := getPGBackRestExecSelector(tc.cluster)
+			assert.NilError(t, err)
+			assert.Assert(t, selector.String() == tc.expectedSelector)
+			assert.Assert(t, container == tc.expectedContainer)
+		})
+	}
+}
+
+func TestBackupSchedules(t *testing.T) {
+
+	defaults := &v1beta1.PGBackRestBackupSchedules{Full: initialize.String("@daily")}
+	repoSchedules := &v1beta1.PGBackRestBackupSchedules{Full: initialize.String("@weekly")}
+
+	r := &Reconciler{}
+	assert.Assert(t, r.backupSchedules(v1beta1.PGBackRestRepo{Name: "repo1"}) == nil)
+
+	r.DefaultBackupSchedules = defaults
+	assert.Equal(t, r.backupSchedules(v1beta1.PGBackRestRepo{Name: "repo1"}), defaults)
+
+	// schedules defined for the repo override the defaults, even when empty
+	assert.Equal(t, r.backupSchedules(v1beta1.PGBackRestRepo{
+		Name: "repo1", BackupSchedules: repoSchedules,
+	}), repoSchedules)
+	assert.Equal(t, *r.backupSchedules(v1beta1.PGBackRestRepo{
+		Name: "repo1", BackupSchedules: &v1beta1.PGBackRestBackupSchedules{},
+	}), v1beta1.PGBackRestBackupSchedules{})
+}
+
+func TestPGBackRestSelector(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Name = "hippo"
+
+	r := &Reconciler{}
+	assert.Equal(t, r.pgBackRestSelector(cluster).String(),
+		naming.PGBackRestSelector("hippo").String())
+
+	// additional labels are required, but cannot override those of the cluster
+	r.PGBackRestSelectorLabels = map[string]string{
+		"tenant":               "a",
+		naming.LabelCluster:    "other",
+		naming.LabelPGBackRest: "other",
+	}
+	assert.Equal(t, r.pgBackRestSelector(cluster).String(),
+		"postgres-operator.crunchydata.com/cluster=hippo,"+
+			"postgres-operator.crunchydata.com/pgbackrest=,tenant=a")
+}
+
+func TestPGBackRestSelectorLabelsApplied(t *testing.T) {
+	// Garbage collector cleans up test resources before the test completes
+	if strings.EqualFold(os.Getenv("USE_EXISTING_CLUSTER"), "true") {
+		t.Skip("USE_EXISTING_CLUSTER: Test fails due to garbage collection")
+	}
+
+	// setup the test environment and ensure a clean teardown
+	tEnv, tClient, cfg := setupTestEnv(t, ControllerName)
+	t.Cleanup(func() { teardownTestEnv(t, tEnv) })
+	r := &Reconciler{}
+	ctx, cancel := setupManager(t, cfg, func(mgr manager.Manager) {
+		r = &Reconciler{
+			Client:   mgr.GetClient(),
+			Recorder: mgr.GetEventRecorderFor(ControllerName),
+			Tracer:   otel.Tracer(ControllerName),
+			Owner:    ControllerName,
+
+			PGBackRestSelectorLabels: map[string]string{"tenant": "a"},
+		}
+	})
+	t.Cleanup(func() { teardownManager(cancel, t) })
+
+	ns := &v1.Namespace{}
+	ns.GenerateName = "postgres-operator-test-"
+	assert.NilError(t, tClient.Create(ctx, ns))
+	t.Cleanup(func() { assert.Check(t, tClient.Delete(ctx, ns)) })
+
+	cluster := fakePostgresCluster("hippocluster", ns.Name, "hippouid", true)
+	repoHostName := "hippocluster-repo-host"
+
+	_, err := r.applyRepoHostIntent(ctx, cluster, repoHostName, false, nil)
+	assert.NilError(t, err)
+	_, err = r.applyRepoVolumeIntent(ctx, cluster,
+		&cluster.Spec.Backups.PGBackRest.Repos[0].Volume.VolumeClaimSpec, "repo1")
+	assert.NilError(t, err)
+	assert.NilError(t, r.reconcilePGBackRestConfig(ctx, cluster, nil, repoHostName, "hash",
+		naming.ClusterPodService(cluster).Name, ns.Name, []string{}, nil))
+
+	// the resources created by the reconciler must be found using its own selector
+	resources, err := r.getPGBackRestResources(ctx, cluster)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources.hosts), 1)
+	assert.Equal(t, len(resources.pvcs), 1)
+	assert.Assert(t, resources.sshConfig != nil)
+	assert.Assert(t, resources.sshSecret != nil)
+
+	for _, object := range []metav1.Object{resources.hosts[0], resources.pvcs[0],
+		resources.sshConfig, resources.sshSecret} {
+		assert.Equal(t, object.GetLabels()["tenant"], "a", "%s", object.GetName())
+	}
+}
+
+func TestRepoWorkers(t *testing.T) {
+
+	t.Run("default", func(t *testing.T) {
+		r := &Reconciler{}
+		assert.Equal(t, r.repoWorkers(), defaultRepoWorkers)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		r := &Reconciler{RepoWorkers: -1}
+		assert.Equal(t, r.repoWorkers(), defaultRepoWorkers)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		r := &Reconciler{RepoWorkers: 4}
+		assert.Equal(t, r.repoWorkers(), 4)
+	})
+}
+
+func TestConfigHashMismatchInterval(t *testing.T) {
+
+	t.Run("default", func(t *testing.T) {
+		r := &Reconciler{}
+		assert.Equal(t, r.configHashMismatchInterval(), defaultConfigHashMismatchInterval)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		r := &Reconciler{ConfigHashMismatchInterval: -time.Second}
+		assert.Equal(t, r.configHashMismatchInterval(), defaultConfigHashMismatchInterval)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		r := &Reconciler{ConfigHashMismatchInterval: time.Minute}
+		assert.Equal(t, r.configHashMismatchInterval(), time.Minute)
+	})
+}
+
+func TestReconcileFinalBackupLock(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, v1beta1.AddToScheme(scheme))
+	assert.NilError(t, batchv1.AddToScheme(scheme))
+
+	newCluster := func() *v1beta1.PostgresCluster {
+		cluster := &v1beta1.PostgresCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "hippo", Namespace: "ns",
+				Annotations: map[string]string{naming.PGBackRestFinalBackup: "one"},
+			},
+		}
+		cluster.Spec.Backups.PGBackRest.FinalBackup = &v1beta1.PGBackRestFinalBackup{
+			RepoName: "repo1",
+		}
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+			Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: true}},
+		}
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type: ConditionReplicaCreate, Status: metav1.ConditionTrue, Reason: "test",
+		})
+		return cluster
+	}
+	instances := &observedInstances{forCluster: []*Instance{{
+		Name: "instance1",
+		Pods: []*corev1.Pod{{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"status": `{"role":"master"}`},
+			Labels:      map[string]string{naming.LabelRole: naming.RolePatroniLeader},
+		}}},
+	}}}
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "hippo-sa"}}
+
+	t.Run("Held", func(t *testing.T) {
+		cluster := newCluster()
+		cluster.Annotations[naming.PGBackRestBackupLock] = "other"
+		r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(cluster).Build()}
+
+		// the final backup waits for the Job holding the lock to finish
+		assert.NilError(t, r.reconcileFinalBackup(ctx, cluster, nil, sa, instances))
+		assert.Equal(t, cluster.GetAnnotations()[naming.PGBackRestBackupLock], "other")
+
+		jobs := &batchv1.JobList{}
+		assert.NilError(t, r.Client.List(ctx, jobs))
+		assert.Equal(t, len(jobs.Items), 0)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		cluster := newCluster()
+		r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+		// the Job is not created when the lock cannot be acquired
+		err := r.reconcileFinalBackup(ctx, cluster, nil, sa, instances)
+		assert.Assert(t, kerr.IsNotFound(err), "expected NotFound, got %v", err)
+		assert.Equal(t, cluster.GetAnnotations()[naming.PGBackRestBackupLock], "")
+
+		jobs := &batchv1.JobList{}
+		assert.NilError(t, r.Client.List(ctx, jobs))
+		assert.Equal(t, len(jobs.Items), 0)
+	})
+}
+
+func TestObserveBackupRequestJob(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, batchv1.AddToScheme(scheme))
+
+	request := backupRequest{
+		jobType:         naming.BackupFinal,
+		annotation:      naming.PGBackRestFinalBackup,
+		id:              "two",
+		condition:       ConditionFinalBackupSuccessful,
+		reason:          "FinalBackup",
+		completeMessage: "complete",
+		failedMessage:   "failed",
+	}
+	newJob := func(id string) *batchv1.Job {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name: "hippo-backup-abcd", Namespace: "ns",
+			Annotations: map[string]string{naming.PGBackRestFinalBackup: id},
+		}}
+		job.Status.Succeeded = 1
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+		}}
+		return job
+	}
+
+	t.Run("NoJobs", func(t *testing.T) {
+		r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+		cluster := &v1beta1.PostgresCluster{}
+
+		job, deleted, err := r.observeBackupRequestJob(ctx, cluster, nil, nil, request)
+		assert.NilError(t, err)
+		assert.Assert(t, job == nil)
+		assert.Assert(t, !deleted)
+	})
+
+	t.Run("Current", func(t *testing.T) {
+		current := newJob("two")
+		r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(current).Build()}
+		cluster := &v1beta1.PostgresCluster{}
+		status := &v1beta1.PGBackRestJobStatus{ID: "two"}
+
+		job, deleted, err := r.observeBackupRequestJob(ctx, cluster,
+			[]*batchv1.Job{current}, status, request)
+		assert.NilError(t, err)
+		assert.Equal(t, job, current)
+		assert.Assert(t, !deleted)
+
+		assert.Assert(t, status.Finished)
+		assert.Equal(t, status.Succeeded, int32(1))
+		condition := meta.FindStatusCondition(cluster.Status.Conditions,
+			ConditionFinalBackupSuccessful)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionTrue)
+		assert.Equal(t, condition.Reason, "FinalBackupComplete")
+		assert.Equal(t, condition.Message, "complete")
+	})
+
+	t.Run("Previous", func(t *testing.T) {
+		previous := newJob("one")
+		r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(previous).Build()}
+		cluster := &v1beta1.PostgresCluster{}
+		status := &v1beta1.PGBackRestJobStatus{ID: "two"}
+
+		// the finished Job of a previous backup is deleted
+		_, deleted, err := r.observeBackupRequestJob(ctx, cluster,
+			[]*batchv1.Job{previous}, status, request)
+		assert.NilError(t, err)
+		assert.Assert(t, deleted)
+		assert.Assert(t, !status.Finished)
+		assert.Equal(t, len(cluster.Status.Conditions), 0)
+
+		err = r.Client.Get(ctx, client.ObjectKeyFromObject(previous), &batchv1.Job{})
+		assert.Assert(t, kerr.IsNotFound(err), "expected NotFound, got %v", err)
+	})
+}
+
+func TestFinalBackupComplete(t *testing.T) {
+
+	for _, tc := range []struct {
+		desc        string
+		annotation  string
+		status      *v1beta1.PGBackRestJobStatus
+		expectation bool
+	}{{
+		desc:        "no annotation",
+		status:      &v1beta1.PGBackRestJobStatus{ID: "a", Finished: true, Succeeded: 1},
+		expectation: false,
+	}, {
+		desc:        "no status",
+		annotation:  "a",
+		expectation: false,
+	}, {
+		desc:        "different id",
+		annotation:  "b",
+		status:      &v1beta1.PGBackRestJobStatus{ID: "a", Finished: true, Succeeded: 1},
+		expectation: false,
+	}, {
+		desc:        "not finished",
+		annotation:  "a",
+		status:      &v1beta1.PGBackRestJobStatus{ID: "a", Active: 1},
+		expectation: false,
+	}, {
+		desc:        "failed",
+		annotation:  "a",
+		status:      &v1beta1.PGBackRestJobStatus{ID: "a", Finished: true, Failed: 1},
+		expectation: false,
+	}, {
+		desc:        "succeeded",
+		annotation:  "a",
+		status:      &v1beta1.PGBackRestJobStatus{ID: "a", Finished: true, Succeeded: 1},
+		expectation: true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cluster := &v1beta1.PostgresCluster{}
+			if tc.annotation != "" {
+				cluster.SetAnnotations(map[string]string{
+					naming.PGBackRestFinalBackup: tc.annotation,
+				})
+			}
+			cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{FinalBackup: tc.status}
+			assert.Equal(t, finalBackupComplete(cluster), tc.expectation)
+		})
+	}
+}
+
+func TestBackupInProgress(t *testing.T) {
+	running := &batchv1.Job{Status: batchv1.JobStatus{Active: 1}}
+	completed := &batchv1.Job{Status: batchv1.JobStatus{
+		Conditions: []batchv1.JobCondition{{
+			Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+		}},
+	}}
+
+	assert.Assert(t, !backupInProgress(&RepoResources{}))
+	assert.Assert(t, !backupInProgress(&RepoResources{
+		manualBackupJobs:    []*batchv1.Job{completed},
+		scheduledBackupJobs: []*batchv1.Job{completed},
+	}))
+
+	assert.Assert(t, backupInProgress(&RepoResources{
+		manualBackupJobs: []*batchv1.Job{completed, running},
+	}))
+	assert.Assert(t, backupInProgress(&RepoResources{
+		replicaCreateBackupJobs: []*batchv1.Job{running},
+	}))
+	assert.Assert(t, backupInProgress(&RepoResources{
+		scheduledBackupJobs: []*batchv1.Job{running},
+	}))
+	assert.Assert(t, backupInProgress(&RepoResources{
+		finalBackupJobs: []*batchv1.Job{running},
+	}))
+}
+
+func TestBackupDiskFull(t *testing.T) {
+	terminated := func(exitCode int32, message string) corev1.ContainerState {
+		return corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			ExitCode: exitCode, Message: message,
+		}}
+	}
+	enospc := "ERROR: [047]: unable to write '/pgbackrest/repo1/backup.info': " +
+		"[28] No space left on device"
+
+	pod := corev1.Pod{}
+	assert.Assert(t, !backupDiskFull(nil))
+	assert.Assert(t, !backupDiskFull([]corev1.Pod{pod}))
+
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{State: terminated(1, "ERROR: [082]")}}
+	assert.Assert(t, !backupDiskFull([]corev1.Pod{pod}))
+
+	pod.Status.ContainerStatuses[0].State = terminated(1, enospc)
+	assert.Assert(t, backupDiskFull([]corev1.Pod{pod}))
+
+	// the backup runs within an init container when verification is enabled
+	pod.Status.ContainerStatuses = nil
+	pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{
+		LastTerminationState: terminated(1, enospc),
+	}}
+	assert.Assert(t, backupDiskFull([]corev1.Pod{pod}))
+}
+
+func TestReconcileRepoDiskFull(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, corev1.AddToScheme(scheme))
+
+	failedPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name: "failed-abcd", Namespace: "ns", Labels: map[string]string{"job-name": "failed"},
+	}}
+	failedPod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			ExitCode: 1, Message: "[28] No space left on device",
+		}},
+	}}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(failedPod).Build(),
+		Recorder: recorder,
+	}
+
+	started := metav1.Now()
+	cluster := &v1beta1.PostgresCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns"}}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		LatestBackups: []v1beta1.PGBackRestLatestBackupStatus{{
+			Type: "manual", JobName: "failed", RepoName: "repo1",
+			StartTime: &started, Failed: 1, Finished: true,
+		}},
+	}
+
+	// a backup that failed because the repo is full sets the condition and records an event once
+	assert.NilError(t, r.reconcileRepoDiskFull(ctx, cluster))
+	assert.NilError(t, r.reconcileRepoDiskFull(ctx, cluster))
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionRepoDiskFull)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Assert(t, strings.Contains(condition.Message, "repo1"))
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Assert(t, strings.HasPrefix(<-recorder.Events, "Warning "+EventRepoDiskFull))
+
+	// the condition is removed once a subsequent backup to the repo succeeds
+	completed := metav1.NewTime(started.Add(time.Hour))
+	cluster.Status.PGBackRest.LatestBackups = append(cluster.Status.PGBackRest.LatestBackups,
+		v1beta1.PGBackRestLatestBackupStatus{
+			Type: "scheduled", JobName: "succeeded", RepoName: "repo1",
+			StartTime: &completed, CompletionTime: &completed, Succeeded: 1, Finished: true,
+		})
+	assert.NilError(t, r.reconcileRepoDiskFull(ctx, cluster))
+	assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionRepoDiskFull) == nil)
+}
+
+func TestSetSchedulesReadyCondition(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, batchv1beta1.AddToScheme(scheme))
+
+	hourly, daily := "0 * * * *", "0 0 * * *"
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+		Name: "repo1",
+		BackupSchedules: &v1beta1.PGBackRestBackupSchedules{
+			Full: &daily, Incremental: &hourly,
+		},
+	}, {
+		Name: "repo2",
+	}}
+
+	full := &batchv1beta1.CronJob{
+		ObjectMeta: naming.PGBackRestCronJob(cluster, "full", "repo1"),
+		Spec:       batchv1beta1.CronJobSpec{Schedule: daily},
+	}
+	incr := &batchv1beta1.CronJob{
+		ObjectMeta: naming.PGBackRestCronJob(cluster, "incr", "repo1"),
+		Spec:       batchv1beta1.CronJobSpec{Schedule: daily},
+	}
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(full, incr).Build(),
+	}
+
+	// the incremental schedule has not been applied to its CronJob
+	assert.NilError(t, r.setSchedulesReadyCondition(ctx, cluster))
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionSchedulesReady)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Assert(t, strings.Contains(condition.Message, incr.GetName()))
+	assert.Assert(t, !strings.Contains(condition.Message, full.GetName()))
+
+	incr.Spec.Schedule = hourly
+	assert.NilError(t, r.Client.Update(ctx, incr))
+	assert.NilError(t, r.setSchedulesReadyCondition(ctx, cluster))
+	condition = meta.FindStatusCondition(cluster.Status.Conditions, ConditionSchedulesReady)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+
+	// default schedules apply to repos without their own schedules
+	r.DefaultBackupSchedules = &v1beta1.PGBackRestBackupSchedules{Full: &daily}
+	assert.NilError(t, r.setSchedulesReadyCondition(ctx, cluster))
+	condition = meta.FindStatusCondition(cluster.Status.Conditions, ConditionSchedulesReady)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Assert(t, strings.Contains(condition.Message, "hippo-pgbackrest-repo2-full"))
+
+	// the condition is removed when there are no schedules
+	r.DefaultBackupSchedules = nil
+	cluster.Spec.Backups.PGBackRest.Repos = cluster.Spec.Backups.PGBackRest.Repos[1:]
+	assert.NilError(t, r.setSchedulesReadyCondition(ctx, cluster))
+	assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionSchedulesReady) == nil)
+}
+
+func TestReconcilePGBackRestNoRepos(t *testing.T) {
+	ctx := context.Background()
+
+	// no client is needed since nothing is reconciled without repos
+	r := &Reconciler{}
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+
+	result, err := r.reconcilePGBackRest(ctx, cluster, &observedInstances{})
+	assert.NilError(t, err)
+	assert.Equal(t, result, reconcile.Result{})
+
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionReposMissing)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, "NoReposDefined")
+}
+
+func TestSetRepoVolumeRemovalBlockedCondition(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{Recorder: recorder}
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+		Name: "repo1", Volume: &v1beta1.RepoPVC{},
+	}}
+
+	volume := func(repoName string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name: "hippo-" + repoName, Namespace: "ns",
+			Labels: naming.PGBackRestRepoVolumeLabels("hippo", repoName),
+		}}
+	}
+	pvcs := []*corev1.PersistentVolumeClaim{volume("repo1"), volume("repo2")}
+
+	// the volume of a repo removed from the spec that was kept is reported
+	r.setRepoVolumeRemovalBlockedCondition(cluster, pvcs)
+	condition := meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionRepoVolumeRemovalBlocked)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, EventRepoVolumeRemovalBlocked)
+	assert.Assert(t, strings.Contains(condition.Message, "hippo-repo2"))
+	assert.Assert(t, !strings.Contains(condition.Message, "hippo-repo1"))
+	assert.Assert(t, strings.Contains(condition.Message, naming.PGBackRestConfirmRepoRemoval))
+
+	assert.Equal(t, len(recorder.Events), 1)
+	event := <-recorder.Events
+	assert.Assert(t, strings.HasPrefix(event, "Warning "+EventRepoVolumeRemovalBlocked), event)
+
+	// an event is only recorded when the condition changes
+	r.setRepoVolumeRemovalBlockedCondition(cluster, pvcs)
+	assert.Equal(t, len(recorder.Events), 0)
+
+	// the condition is removed once the volume is gone
+	r.setRepoVolumeRemovalBlockedCondition(cluster, pvcs[:1])
+	assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionRepoVolumeRemovalBlocked) == nil)
+}
+
+func TestReconcilePGBackRestDryRun(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, corev1.AddToScheme(scheme))
+	assert.NilError(t, appsv1.AddToScheme(scheme))
+	assert.NilError(t, batchv1.AddToScheme(scheme))
+	assert.NilError(t, batchv1beta1.AddToScheme(scheme))
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "hippo", Namespace: "ns", UID: "hippo-uid",
+			Annotations: map[string]string{naming.PGBackRestDryRun: ""},
+		},
+	}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+		Name: "repo1", Volume: &v1beta1.RepoPVC{},
+	}}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: true}},
+	}
+	owner := []metav1.OwnerReference{{
+		APIVersion: v1beta1.GroupVersion.String(), Kind: "PostgresCluster",
+		Name: "hippo", UID: "hippo-uid", Controller: initialize.Bool(true),
+	}}
+
+	// resources of several kinds that no longer match the spec
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+		Name: "hippo-repo2", Namespace: "ns", OwnerReferences: owner,
+		Labels: naming.PGBackRestRepoVolumeLabels("hippo", "repo2"),
+	}}
+	host := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{
+		Name: "hippo-repo-host", Namespace: "ns", OwnerReferences: owner,
+		Labels: naming.PGBackRestDedicatedLabels("hippo"),
+	}}
+	cronJob := &batchv1beta1.CronJob{ObjectMeta: metav1.ObjectMeta{
+		Name: "hippo-repo1-full", Namespace: "ns", OwnerReferences: owner,
+		Labels: naming.PGBackRestCronJobLabels("hippo", "repo1", "full"),
+	}}
+	manual := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Name: "hippo-backup-repo3", Namespace: "ns", OwnerReferences: owner,
+		Labels: naming.PGBackRestBackupJobLabels("hippo", "repo3", naming.BackupManual),
+	}}
+
+	// failed Jobs created by a scheduled backup CronJob, the oldest of which is stale
+	now := time.Now()
+	scheduled := func(name string, age time.Duration) *batchv1.Job {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "ns",
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			Labels:            naming.PGBackRestCronJobLabels("hippo", "repo1", "full"),
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "batch/v1beta1", Kind: "CronJob", Name: "hippo-repo1-full",
+				UID: "cronjob-uid", Controller: initialize.Bool(true),
+			}},
+		}}
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type: batchv1.JobFailed, Status: corev1.ConditionTrue,
+		}}
+		return job
+	}
+	staleJob := scheduled("hippo-repo1-full-old", 2*time.Hour)
+	newestJob := scheduled("hippo-repo1-full-new", time.Hour)
+
+	objects := []client.Object{pvc, host, cronJob, manual, staleJob, newestJob}
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		Owner:    client.FieldOwner(t.Name()),
+		Recorder: recorder,
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := r.reconcilePGBackRest(ctx, cluster, &observedInstances{})
+		assert.NilError(t, err)
+		assert.Equal(t, result, reconcile.Result{})
+	}
+
+	// nothing is changed
+	for _, object := range objects {
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(object), object),
+			"expected %s to exist", object.GetName())
+	}
+	assert.Assert(t, cluster.Status.PGBackRest.ScheduledBackups == nil)
+
+	// all of the changes are reported together, and just once
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionDryRun)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Reason, EventDryRunChanges)
+	for _, object := range []client.Object{pvc, host, cronJob, manual, staleJob} {
+		assert.Assert(t, strings.Contains(condition.Message, object.GetName()),
+			"expected %q in %q", object.GetName(), condition.Message)
+	}
+	assert.Assert(t, !strings.Contains(condition.Message, newestJob.GetName()))
+
+	assert.Equal(t, len(recorder.Events), 1)
+	event := <-recorder.Events
+	assert.Assert(t, strings.HasPrefix(event, "Normal "+EventDryRunChanges), event)
+	assert.Assert(t, strings.Contains(event, condition.Message), event)
+}
+
+func TestReconcileRepoVolumeOwnership(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, corev1.AddToScheme(scheme))
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns", UID: "hippouid"},
+	}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+		{Name: "repo1", Volume: &v1beta1.RepoPVC{}},
+		{Name: "repo2", Volume: &v1beta1.RepoPVC{}},
+		{Name: "repo3", Volume: &v1beta1.RepoPVC{}},
+		{Name: "repo4", S3: &v1beta1.RepoS3{}},
+	}
+
+	controller := true
+	owner := func(uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{
+			APIVersion: v1beta1.GroupVersion.String(), Kind: "PostgresCluster",
+			Name: "hippo", UID: uid, Controller: &controller,
+		}}
+	}
+
+	// repo1 is owned by the cluster, repo2 is annotated for adoption and repo3 does not exist
+	owned := &corev1.PersistentVolumeClaim{
+		ObjectMeta: naming.PGBackRestRepoVolume(cluster, "repo1"),
+	}
+	owned.OwnerReferences = owner(cluster.GetUID())
+	adopted := &corev1.PersistentVolumeClaim{
+		ObjectMeta: naming.PGBackRestRepoVolume(cluster, "repo2"),
+	}
+	adopted.Annotations = map[string]string{naming.PGBackRestAdoptRepoVolume: "hippo"}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(owned, adopted).Build(),
+		Recorder: recorder,
+	}
+
+	unowned, err := r.reconcileRepoVolumeOwnership(ctx, cluster)
+	assert.NilError(t, err)
+	assert.Equal(t, len(unowned), 0)
+	assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionRepoVolumeUnowned) == nil)
+
+	// a volume owned by another cluster (e.g. a previous cluster with the same name) is not used
+	owned.OwnerReferences = owner("rhinouid")
+	assert.NilError(t, r.Client.Update(ctx, owned))
+
+	unowned, err = r.reconcileRepoVolumeOwnership(ctx, cluster)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, unowned, map[string]bool{"repo1": true})
+	unowned, err = r.reconcileRepoVolumeOwnership(ctx, cluster)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, unowned, map[string]bool{"repo1": true})
+
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionRepoVolumeUnowned)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Assert(t, strings.Contains(condition.Message, owned.GetName()))
+	assert.Assert(t, !strings.Contains(condition.Message, adopted.GetName()))
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Assert(t, strings.HasPrefix(<-recorder.Events, "Warning "+EventRepoVolumeUnowned))
+
+	// a volume released by the other cluster is not adopted because of its labels alone
+	owned.OwnerReferences = nil
+	owned.Labels = naming.PGBackRestRepoVolumeLabels("hippo", "repo1")
+	assert.NilError(t, r.Client.Update(ctx, owned))
+
+	unowned, err = r.reconcileRepoVolumeOwnership(ctx, cluster)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, unowned, map[string]bool{"repo1": true})
+
+	// the condition is removed once the volume is annotated for adoption
+	owned.Annotations = map[string]string{naming.PGBackRestAdoptRepoVolume: "hippo"}
+	assert.NilError(t, r.Client.Update(ctx, owned))
+
+	unowned, err = r.reconcileRepoVolumeOwnership(ctx, cluster)
+	assert.NilError(t, err)
+	assert.Equal(t, len(unowned), 0)
+	assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionRepoVolumeUnowned) == nil)
+}
+
+func TestReconcileHostNamespaces(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, corev1.AddToScheme(scheme))
+
+	pod := func(name string, clusterName string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "ns", Labels: naming.PGBackRestLabels(clusterName),
+		}}
+	}
+	repoHost := pod("hippo-repo-host-0", "hippo")
+	hostNetwork := pod("hippo-backup-abcd", "hippo")
+	hostNetwork.Spec.HostNetwork = true
+	hostPID := pod("hippo-restore-abcd", "hippo")
+	hostPID.Spec.HostPID = true
+	otherCluster := pod("rhino-backup-abcd", "rhino")
+	otherCluster.Spec.HostIPC = true
+
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(repoHost, hostNetwork, hostPID, otherCluster).Build(),
+		Recorder: recorder,
+	}
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	assert.NilError(t, r.reconcileHostNamespaces(ctx, cluster))
+
+	// the pgBackRest Pods of the cluster that use host namespaces are reported, and no Pods
+	// are deleted
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionHostNamespaces)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, EventHostNamespacesNotAllowed)
+	assert.Assert(t, strings.Contains(condition.Message,
+		"hippo-backup-abcd, hippo-restore-abcd "))
+	assert.Assert(t, !strings.Contains(condition.Message, "rhino"))
+
+	pods := &corev1.PodList{}
+	assert.NilError(t, r.Client.List(ctx, pods))
+	assert.Equal(t, len(pods.Items), 4)
+
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Assert(t, strings.HasPrefix(<-recorder.Events, "Warning "+EventHostNamespacesNotAllowed))
+
+	// an event is only recorded when the condition changes
+	assert.NilError(t, r.reconcileHostNamespaces(ctx, cluster))
+	assert.Equal(t, len(recorder.Events), 0)
+
+	// the condition is removed once no such Pods remain
+	assert.NilError(t, r.Client.Delete(ctx, hostNetwork))
+	assert.NilError(t, r.Client.Delete(ctx, hostPID))
+	assert.NilError(t, r.reconcileHostNamespaces(ctx, cluster))
+	assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionHostNamespaces) == nil)
+}
+
+func TestDisableHostNamespaces(t *testing.T) {
+	spec := &corev1.PodSpec{HostNetwork: true, HostPID: true, HostIPC: true}
+	disableHostNamespaces(spec)
+	assert.Assert(t, !spec.HostNetwork && !spec.HostPID && !spec.HostIPC)
+}
+
+func TestReconcileBackupLock(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, v1beta1.AddToScheme(scheme))
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(cluster).Build()}
+
+	running := func(name string) *batchv1.Job {
+		job := &batchv1.Job{}
+		job.Name = name
+		return job
+	}
+	completed := running("completed")
+	completed.Status.Conditions = []batchv1.JobCondition{{
+		Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+	}}
+
+	lockHolder := func(t *testing.T) string {
+		t.Helper()
+		stored := &v1beta1.PostgresCluster{}
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(cluster), stored))
+		assert.Equal(t, stored.GetAnnotations()[naming.PGBackRestBackupLock],
+			cluster.GetAnnotations()[naming.PGBackRestBackupLock])
+		return stored.GetAnnotations()[naming.PGBackRestBackupLock]
+	}
+
+	// nothing is running
+	assert.NilError(t, r.reconcileBackupLock(ctx, cluster, &RepoResources{
+		manualBackupJobs: []*batchv1.Job{completed},
+	}))
+	assert.Equal(t, lockHolder(t), "")
+
+	// a running scheduled backup acquires the lock
+	assert.NilError(t, r.reconcileBackupLock(ctx, cluster, &RepoResources{
+		scheduledBackupJobs: []*batchv1.Job{running("scheduled")},
+	}))
+	assert.Equal(t, lockHolder(t), "scheduled")
+
+	// the lock is kept while the Job holding it is running
+	assert.NilError(t, r.reconcileBackupLock(ctx, cluster, &RepoResources{
+		manualBackupJobs:    []*batchv1.Job{running("manual")},
+		scheduledBackupJobs: []*batchv1.Job{running("scheduled")},
+	}))
+	assert.Equal(t, lockHolder(t), "scheduled")
+
+	// the lock moves to another running Job once the Job holding it has finished
+	assert.NilError(t, r.reconcileBackupLock(ctx, cluster, &RepoResources{
+		manualBackupJobs: []*batchv1.Job{running("manual")},
+	}))
+	assert.Equal(t, lockHolder(t), "manual")
+
+	// the lock is released once nothing is running
+	assert.NilError(t, r.reconcileBackupLock(ctx, cluster, &RepoResources{}))
+	assert.Equal(t, lockHolder(t), "")
+}
+
+func TestRepoHostCheckEnabled(t *testing.T) {
+
+	newCluster := func(checkReadiness *bool, repos ...v1beta1.RepoStatus) *v1beta1.PostgresCluster {
+		cluster := &v1beta1.PostgresCluster{}
+		cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{
+			Dedicated: &v1beta1.DedicatedRepo{CheckReadiness: checkReadiness},
+		}
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{Repos: repos}
+		return cluster
+	}
+
+	assert.Assert(t, !repoHostCheckEnabled(newCluster(nil,
+		v1beta1.RepoStatus{Name: "repo1", StanzaCreated: true})))
+	assert.Assert(t, !repoHostCheckEnabled(newCluster(initialize.Bool(false),
+		v1beta1.RepoStatus{Name: "repo1", StanzaCreated: true})))
+	assert.Assert(t, !repoHostCheckEnabled(newCluster(initialize.Bool(true))))
+	assert.Assert(t, !repoHostCheckEnabled(newCluster(initialize.Bool(true),
+		v1beta1.RepoStatus{Name: "repo1", StanzaCreated: true},
+		v1beta1.RepoStatus{Name: "repo2"})))
+	assert.Assert(t, repoHostCheckEnabled(newCluster(initialize.Bool(true),
+		v1beta1.RepoStatus{Name: "repo1", StanzaCreated: true},
+		v1beta1.RepoStatus{Name: "repo2", StanzaCreated: true})))
+}
+
+func TestRepoHostCheckRemaining(t *testing.T) {
+	now := time.Now()
+	lastCheck := func(ago time.Duration) *v1beta1.RepoHostStatus {
+		checked := metav1.NewTime(now.Add(-ago))
+		return &v1beta1.RepoHostStatus{LastCheckTime: &checked}
+	}
+
+	assert.Equal(t, repoHostCheckRemaining(nil, now), time.Duration(0))
+	assert.Equal(t, repoHostCheckRemaining(&v1beta1.RepoHostStatus{}, now), time.Duration(0))
+	assert.Equal(t, repoHostCheckRemaining(lastCheck(time.Minute), now),
+		repoHostCheckInterval-time.Minute)
+	assert.Equal(t, repoHostCheckRemaining(lastCheck(repoHostCheckInterval), now),
+		time.Duration(0))
+	assert.Equal(t, repoHostCheckRemaining(lastCheck(time.Hour), now), time.Duration(0))
+}
+
+func TestRestoreInProgress(t *testing.T) {
+
+	cluster := &v1beta1.PostgresCluster{}
+	assert.Assert(t, !restoreInProgress(cluster))
+
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
+	assert.Assert(t, !restoreInProgress(cluster))
+
+	cluster.Status.PGBackRest.Restore = &v1beta1.PGBackRestRestoreStatus{
+		PGBackRestJobStatus: v1beta1.PGBackRestJobStatus{ID: "restore"},
+	}
+	assert.Assert(t, !restoreInProgress(cluster))
+
+	cluster.Status.PGBackRest.Restore.Active = 1
+	assert.Assert(t, restoreInProgress(cluster))
+
+	cluster.Status.PGBackRest.Restore.Finished = true
+	assert.Assert(t, !restoreInProgress(cluster))
+}
+
+func TestRestoringInPlace(t *testing.T) {
+
+	cluster := &v1beta1.PostgresCluster{}
+	assert.Assert(t, !restoringInPlace(cluster))
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:   ConditionPGBackRestRestoreProgressing,
+		Status: metav1.ConditionTrue,
+		Reason: "InPlaceRestore",
+	})
+	assert.Assert(t, restoringInPlace(cluster))
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:   ConditionPGBackRestRestoreProgressing,
+		Status: metav1.ConditionFalse,
+		Reason: "InPlaceRestore",
+	})
+	assert.Assert(t, !restoringInPlace(cluster))
+}
+
+func TestGenerateBackupJobSpecIntentResources(t *testing.T) {
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+
+	spec, err := generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+		"sa", "repo.conf", nil, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, spec.Template.Spec.Containers[0].Resources, corev1.ResourceRequirements{})
+
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+		},
+	}
+	cluster.Spec.Backups.PGBackRest.Jobs = &v1beta1.BackupJobs{Resources: resources}
+
+	spec, err = generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+		"sa", "repo.conf", nil, nil)
+	assert.NilError(t, err)
+	containerResources := spec.Template.Spec.Containers[0].Resources
+	assert.Equal(t, containerResources.Requests.StorageEphemeral().String(), "1Gi")
+	assert.Equal(t, containerResources.Limits.StorageEphemeral().String(), "2Gi")
+
+	// the resources of the repo being backed up take precedence
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+		Name: "repo1",
+	}, {
+		Name: "repo2",
+		BackupJobResources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		},
+	}}
+
+	spec, err = generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+		"sa", "repo.conf", nil, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, spec.Template.Spec.Containers[0].Resources, resources)
+
+	spec, err = generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo2",
+		"sa", "repo.conf", nil, nil)
+	assert.NilError(t, err)
+	containerResources = spec.Template.Spec.Containers[0].Resources
+	assert.Equal(t, containerResources.Requests.Cpu().String(), "2")
+	assert.Assert(t, containerResources.Limits == nil)
+}
+
+func TestBackupJobDeadline(t *testing.T) {
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+
+	// no deadline by default
+	spec, err := generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+		"sa", "repo.conf", nil, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, spec.ActiveDeadlineSeconds == nil)
+
+	// only the base is allowed while the size of the database is unknown
+	cluster.Spec.Backups.PGBackRest.Jobs = &v1beta1.BackupJobs{
+		ActiveDeadline: &v1beta1.BackupJobDeadline{BaseSeconds: 600, SecondsPerGiB: 30},
+	}
+	spec, err = generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+		"sa", "repo.conf", nil, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, spec.ActiveDeadlineSeconds != nil)
+	assert.Equal(t, *spec.ActiveDeadlineSeconds, int64(600))
+
+	// the largest backup reported is used, rounded up to the next GiB
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		LatestBackups: []v1beta1.PGBackRestLatestBackupStatus{
+			{Type: "scheduled", BackupSizeBytes: 1 << 30},
+			{Type: "manual", BackupSizeBytes: 10<<30 + 1},
+		},
+	}
+	deadline := backupJobDeadline(cluster)
+	assert.Assert(t, deadline != nil)
+	assert.Equal(t, *deadline, int64(600+11*30))
+}
+
+func TestGenerateBackupJobSpecIntentAutomountServiceAccountToken(t *testing.T) {
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+
+	spec, err := generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+		"sa", "repo.conf", nil, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, spec.Template.Spec.AutomountServiceAccountToken == nil)
+
+	cluster.Spec.Backups.PGBackRest.Jobs = &v1beta1.BackupJobs{
+		AutomountServiceAccountToken: initialize.Bool(false),
+	}
+
+	spec, err = generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+		"sa", "repo.conf", nil, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, spec.Template.Spec.AutomountServiceAccountToken != nil)
+	assert.Assert(t, !*spec.Template.Spec.AutomountServiceAccountToken)
+}
+
+func TestGenerateBackupJobSpecIntentPriorityClassName(t *testing.T) {
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+
+	spec, err := generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+		"sa", "repo.conf", nil, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, spec.Template.Spec.PriorityClassName, "")
+
+	cluster.Spec.Backups.PGBackRest.Jobs = &v1beta1.BackupJobs{PriorityClassName: "low"}
+
+	spec, err = generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+		"sa", "repo.conf", nil, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, spec.Template.Spec.PriorityClassName, "low")
+}
+
+func TestGenerateBackupJobSpecIntentExclude(t *testing.T) {
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.Backups.PGBackRest.Jobs = &v1beta1.BackupJobs{
+		Exclude: []string{"pg_temp_cache/", "scratch"},
+	}
+
+	spec, err := generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+		"sa", "repo.conf", nil, nil, "--type=full")
+	assert.NilError(t, err)
+
+	var commandOpts string
+	for _, env := range spec.Template.Spec.Containers[0].Env {
+		if env.Name == "COMMAND_OPTS" {
+			commandOpts = env.Value
+		}
+	}
+	assert.Equal(t, commandOpts, "--stanza=db --repo=1 --type=full "+
+		"--exclude=pg_temp_cache/ --exclude=scratch")
+}
+
+func TestGenerateBackupJobSpecIntentManifestSaveThreshold(t *testing.T) {
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.Backups.PGBackRest.Jobs = &v1beta1.BackupJobs{
+		Exclude:               []string{"scratch"},
+		ManifestSaveThreshold: "5GiB",
+	}
+
+	spec, err := generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+		"sa", "repo.conf", nil, nil, "--type=full")
+	assert.NilError(t, err)
+
+	var commandOpts string
+	for _, env := range spec.Template.Spec.Containers[0].Env {
+		if env.Name == "COMMAND_OPTS" {
+			commandOpts = env.Value
+		}
+	}
+	assert.Equal(t, commandOpts, "--stanza=db --repo=1 --type=full "+
+		"--exclude=scratch --manifest-save-threshold=5GiB")
+}
+
+func TestGenerateCheckJobSpecIntent(t *testing.T) {
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	// options specific to backups are not included in the check command
+	cluster.Spec.Backups.PGBackRest.Jobs = &v1beta1.BackupJobs{Exclude: []string{"scratch"}}
+
+	spec, err := generateCheckJobSpecIntent(cluster, "selector", "pgbackrest",
+		"sa", "repo.conf", nil, nil)
+	assert.NilError(t, err)
+
+	env := map[string]string{}
+	for _, e := range spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	assert.Equal(t, env["COMMAND"], "check")
+	assert.Equal(t, env["COMMAND_OPTS"], "--stanza=db")
+}
+
+func TestSetPGBackRestCheckCondition(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{}
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{Recorder: recorder}
+
+	finished := func(name string, created time.Time,
+		conditionType batchv1.JobConditionType) *batchv1.Job {
+		job := &batchv1.Job{}
+		job.Name = name
+		job.CreationTimestamp = metav1.NewTime(created)
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type: conditionType, Status: corev1.ConditionTrue,
+		}}
+		return job
+	}
+	now := time.Now()
+
+	// nothing is reported until a check Job finishes
+	running := &batchv1.Job{}
+	running.Name = "running"
+	running.CreationTimestamp = metav1.NewTime(now)
+	r.setPGBackRestCheckCondition(cluster, []*batchv1.Job{running})
+	assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionPGBackRestCheck) == nil)
+	assert.Equal(t, len(recorder.Events), 0)
+
+	// the most recent finished Job determines the condition
+	jobs := []*batchv1.Job{
+		running,
+		finished("older", now.Add(-2*time.Hour), batchv1.JobFailed),
+		finished("newer", now.Add(-time.Hour), batchv1.JobComplete),
+	}
+	r.setPGBackRestCheckCondition(cluster, jobs)
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionPGBackRestCheck)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, EventPGBackRestCheckSucceeded)
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Assert(t, strings.HasPrefix(<-recorder.Events, "Normal "+EventPGBackRestCheckSucceeded))
+
+	// an event is only recorded once for each Job
+	r.setPGBackRestCheckCondition(cluster, jobs)
+	assert.Equal(t, len(recorder.Events), 0)
+
+	// a failed check is reported as well
+	jobs = append(jobs, finished("newest", now, batchv1.JobFailed))
+	r.setPGBackRestCheckCondition(cluster, jobs)
+	condition = meta.FindStatusCondition(cluster.Status.Conditions, ConditionPGBackRestCheck)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Assert(t, strings.Contains(condition.Message, "newest"))
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Assert(t, strings.HasPrefix(<-recorder.Events, "Warning "+EventPGBackRestCheckFailed))
+}
+
+func TestGenerateBackupJobSpecIntentAnnotation(t *testing.T) {
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "hippo", Namespace: "ns",
+			Annotations: map[string]string{
+				naming.PGBackRestBackupAnnotationPrefix + "release": "v1.2.3",
+				naming.PGBackRestBackupAnnotationPrefix + "commit":  "abc123",
+				naming.PGBackRestBackupAnnotationPrefix + "note":    "has spaces",
+				naming.PGBackRestBackupAnnotationPrefix:             "no-key",
+				"unrelated":                                         "ignored",
+			},
+		},
+	}
+
+	spec, err := generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+		"sa", "repo.conf", nil, nil, "--type=full")
+	assert.NilError(t, err)
+
+	var commandOpts string
+	for _, env := range spec.Template.Spec.Containers[0].Env {
+		if env.Name == "COMMAND_OPTS" {
+			commandOpts = env.Value
+		}
+	}
+	assert.Equal(t, commandOpts, "--stanza=db --repo=1 --type=full "+
+		"--annotation=commit=abc123 --annotation=release=v1.2.3")
+}
+
+func TestPreviousReplicaCreateRepo(t *testing.T) {
+	status := []v1beta1.RepoStatus{
+		{Name: "repo1", ReplicaCreateBackupComplete: true},
+		{Name: "repo2"},
+	}
+
+	assert.Equal(t, previousReplicaCreateRepo(status, "repo1"), "")
+	assert.Equal(t, previousReplicaCreateRepo(status, "repo2"), "repo1")
+	assert.Equal(t, previousReplicaCreateRepo(status, ""), "")
+	assert.Equal(t, previousReplicaCreateRepo(nil, "repo2"), "")
+
+	// nothing has changed once the status has been reset
+	status = getRepoVolumeStatus(status, nil, map[string]string{"repo1": "", "repo2": ""}, "repo2")
+	assert.Equal(t, previousReplicaCreateRepo(status, "repo2"), "")
+}
+
+func TestReplicaCreateNotWritable(t *testing.T) {
+	bootstrapped := &v1beta1.PatroniStatus{SystemIdentifier: "6952526174828511264"}
+
+	for _, tc := range []struct {
+		desc    string
+		cluster *v1beta1.PostgresCluster
+		reason  string
+	}{{
+		desc: "shutdown",
+		cluster: &v1beta1.PostgresCluster{
+			Spec:   v1beta1.PostgresClusterSpec{Shutdown: initialize.Bool(true)},
+			Status: v1beta1.PostgresClusterStatus{Patroni: bootstrapped},
+		},
+		reason: ReasonBackupSuspended,
+	}, {
+		desc: "standby",
+		cluster: &v1beta1.PostgresCluster{
+			Spec: v1beta1.PostgresClusterSpec{
+				Standby: &v1beta1.PostgresStandbySpec{Enabled: true},
+			},
+		},
+		reason: ReasonBackupSuspended,
+	}, {
+		desc:    "not bootstrapped",
+		cluster: &v1beta1.PostgresCluster{},
+		reason:  ReasonClusterNotBootstrapped,
+	}, {
+		desc: "no writable primary",
+		cluster: &v1beta1.PostgresCluster{
+			Status: v1beta1.PostgresClusterStatus{Patroni: bootstrapped},
+		},
+		reason: "RepoBackupNotComplete",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			reason, message := replicaCreateNotWritable(tc.cluster)
+			assert.Equal(t, reason, tc.reason)
+			assert.Assert(t, message != "")
+		})
+	}
+}
+
+func TestGetRepoVolumeStatusStorage(t *testing.T) {
+
+	repoVolume := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{naming.LabelPGBackRestRepo: "repo1"},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: initialize.String("fast"),
+			VolumeName:       "pv-1",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("1Gi"),
+				},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase: corev1.ClaimBound,
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("2Gi"),
+			},
+		},
+	}
+
+	for _, existing := range [][]v1beta1.RepoStatus{
+		nil, {{Name: "repo1", StanzaCreated: true}},
+	} {
+		status := getRepoVolumeStatus(existing, []*corev1.PersistentVolumeClaim{repoVolume},
+			nil, "repo1")
+		assert.Equal(t, len(status), 1)
+		assert.Equal(t, status[0].VolumeName, "pv-1")
+		assert.Equal(t, status[0].StorageClassName, "fast")
+		assert.Equal(t, status[0].RequestedCapacity, "1Gi")
+		assert.Equal(t, status[0].ActualCapacity, "2Gi")
+		assert.Assert(t, status[0].Bound)
+	}
+}
+
+func TestPGBackRestInitImage(t *testing.T) {
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Spec.Backups.PGBackRest.Image = "pgbackrest@sha256:main"
+	assert.Equal(t, pgBackRestInitImage(cluster), "pgbackrest@sha256:main")
+
+	cluster.Spec.Backups.PGBackRest.InitImage = "pgbackrest@sha256:init"
+	assert.Equal(t, pgBackRestInitImage(cluster), "pgbackrest@sha256:init")
+}
+
+func TestGenerateRepoVolumeIntentMetadata(t *testing.T) {
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, v1beta1.AddToScheme(scheme))
+	r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.Backups.PGBackRest.Metadata = &v1beta1.Metadata{
+		Annotations: map[string]string{"backups": "annotation"},
+	}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+		Name: "repo1",
+		Volume: &v1beta1.RepoPVC{
+			Metadata: &v1beta1.Metadata{
+				Annotations: map[string]string{"px/group": "backups"},
+				Labels:      map[string]string{"volume": "label"},
+			},
+		},
+	}, {
+		Name:   "repo2",
+		Volume: &v1beta1.RepoPVC{},
+	}}
+
+	pvc, err := r.generateRepoVolumeIntent(cluster,
+		&cluster.Spec.Backups.PGBackRest.Repos[0].Volume.VolumeClaimSpec, "repo1")
+	assert.NilError(t, err)
+	assert.Equal(t, pvc.Annotations["backups"], "annotation")
+	assert.Equal(t, pvc.Annotations["px/group"], "backups")
+	assert.Equal(t, pvc.Labels["volume"], "label")
+	assert.Equal(t, pvc.Labels[naming.LabelPGBackRestRepo], "repo1")
+
+	// the metadata of a volume only applies to the PVC of that repo
+	pvc, err = r.generateRepoVolumeIntent(cluster,
+		&cluster.Spec.Backups.PGBackRest.Repos[1].Volume.VolumeClaimSpec, "repo2")
+	assert.NilError(t, err)
+	assert.Equal(t, pvc.Annotations["backups"], "annotation")
+	assert.Assert(t, pvc.Annotations["px/group"] == "")
+	assert.Assert(t, pvc.Labels["volume"] == "")
+}
+
+func TestGenerateRepoHostIntentSidecars(t *testing.T) {
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, v1beta1.AddToScheme(scheme))
+	r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{
+		Dedicated: &v1beta1.DedicatedRepo{
+			Sidecars: []v1.Container{{Name: "exporter", Image: "exporter:latest"}},
+		},
+	}
+
+	repo, err := r.generateRepoHostIntent(cluster, "hippo-repo-host", nil)
+	assert.NilError(t, err)
+
+	containers := repo.Spec.Template.Spec.Containers
+	assert.Equal(t, containers[0].Name, naming.PGBackRestRepoContainerName)
+	assert.Equal(t, containers[len(containers)-1].Name, "exporter")
+	assert.Equal(t, containers[len(containers)-1].Image, "exporter:latest")
+}
+
+func TestGenerateRepoHostIntentFSGroupChangePolicy(t *testing.T) {
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, v1beta1.AddToScheme(scheme))
+	r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{
+		Dedicated: &v1beta1.DedicatedRepo{},
+	}
+
+	repo, err := r.generateRepoHostIntent(cluster, "hippo-repo-host", nil)
+	assert.NilError(t, err)
+	securityContext := repo.Spec.Template.Spec.SecurityContext
+	assert.Assert(t, securityContext.FSGroupChangePolicy != nil)
+	assert.Equal(t, *securityContext.FSGroupChangePolicy, v1.FSGroupChangeOnRootMismatch)
+
+	always := v1.FSGroupChangeAlways
+	cluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.FSGroupChangePolicy = &always
+
+	repo, err = r.generateRepoHostIntent(cluster, "hippo-repo-host", nil)
+	assert.NilError(t, err)
+	securityContext = repo.Spec.Template.Spec.SecurityContext
+	assert.Assert(t, securityContext.FSGroupChangePolicy != nil)
+	assert.Equal(t, *securityContext.FSGroupChangePolicy, v1.FSGroupChangeAlways)
+
+	// no fsGroup is set when deployed to OpenShift
+	cluster.Spec.OpenShift = initialize.Bool(true)
+
+	repo, err = r.generateRepoHostIntent(cluster, "hippo-repo-host", nil)
+	assert.NilError(t, err)
+	securityContext = repo.Spec.Template.Spec.SecurityContext
+	assert.Assert(t, securityContext.FSGroup == nil)
+	assert.Assert(t, securityContext.FSGroupChangePolicy == nil)
+}
+
+func TestGenerateRepoHostIntentUnownedRepos(t *testing.T) {
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, v1beta1.AddToScheme(scheme))
+	r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{
+		Dedicated: &v1beta1.DedicatedRepo{},
+	}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+		{Name: "repo1", Volume: &v1beta1.RepoPVC{}},
+		{Name: "repo2", Volume: &v1beta1.RepoPVC{}},
+	}
+
+	// the volume of an unowned repo is neither added to the pod nor mounted
+	repo, err := r.generateRepoHostIntent(cluster, "hippo-repo-host",
+		map[string]bool{"repo2": true})
+	assert.NilError(t, err)
+
+	volumes := map[string]bool{}
+	for _, volume := range repo.Spec.Template.Spec.Volumes {
+		volumes[volume.Name] = true
+	}
+	assert.Assert(t, volumes["repo1"])
+	assert.Assert(t, !volumes["repo2"])
+
+	for _, container := range repo.Spec.Template.Spec.Containers {
+		for _, mount := range container.VolumeMounts {
+			assert.Assert(t, mount.Name != "repo2", "container %q", container.Name)
+		}
+	}
+}
+
+func TestWithoutRepos(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+		{Name: "repo1", Volume: &v1beta1.RepoPVC{}},
+		{Name: "repo2", Volume: &v1beta1.RepoPVC{}},
+		{Name: "repo3", S3: &v1beta1.RepoS3{}},
+	}
+
+	assert.Assert(t, withoutRepos(cluster, nil) == cluster)
+
+	without := withoutRepos(cluster, map[string]bool{"repo2": true})
+	assert.Equal(t, len(without.Spec.Backups.PGBackRest.Repos), 2)
+	assert.Equal(t, without.Spec.Backups.PGBackRest.Repos[0].Name, "repo1")
+	assert.Equal(t, without.Spec.Backups.PGBackRest.Repos[1].Name, "repo3")
+
+	// the cluster provided is unchanged
+	assert.Equal(t, len(cluster.Spec.Backups.PGBackRest.Repos), 3)
+}
+
+func TestGenerateRepoHostIntentAutomountServiceAccountToken(t *testing.T) {
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, v1beta1.AddToScheme(scheme))
+	r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{
+		Dedicated: &v1beta1.DedicatedRepo{},
+	}
+
+	repo, err := r.generateRepoHostIntent(cluster, "hippo-repo-host", nil)
+	assert.NilError(t, err)
+	assert.Assert(t, repo.Spec.Template.Spec.AutomountServiceAccountToken == nil)
+
+	cluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.AutomountServiceAccountToken =
+		initialize.Bool(false)
+
+	repo, err = r.generateRepoHostIntent(cluster, "hippo-repo-host", nil)
+	assert.NilError(t, err)
+	assert.Assert(t, repo.Spec.Template.Spec.AutomountServiceAccountToken != nil)
+	assert.Assert(t, !*repo.Spec.Template.Spec.AutomountServiceAccountToken)
+}
+
+func TestReconcileRepoHostMetrics(t *testing.T) {
+
+	// setup the test environment and ensure a clean teardown
+	tEnv, tClient, cfg := setupTestEnv(t, ControllerName)
+	t.Cleanup(func() { teardownTestEnv(t, tEnv) })
+	r := &Reconciler{}
+	ctx, cancel := setupManager(t, cfg, func(mgr manager.Manager) {
+		r = &Reconciler{
+			Client:   tClient,
+			Recorder: mgr.GetEventRecorderFor(ControllerName),
+			Tracer:   otel.Tracer(ControllerName),
+			Owner:    ControllerName,
+		}
+	})
+	t.Cleanup(func() { teardownManager(cancel, t) })
+
+	ns := &v1.Namespace{}
+	ns.GenerateName = "postgres-operator-test-"
+	assert.NilError(t, tClient.Create(ctx, ns))
+	t.Cleanup(func() { assert.Check(t, tClient.Delete(ctx, ns)) })
+
+	postgresCluster := fakePostgresCluster("hippocluster", ns.GetName(), "hippouid", true)
+
+	// nothing is created when metrics are not enabled
+	service, err := r.reconcileRepoHostMetricsService(ctx, postgresCluster)
+	assert.NilError(t, err)
+	assert.Assert(t, service == nil)
+	assert.NilError(t, r.reconcileRepoHostServiceMonitor(ctx, postgresCluster))
+
+	postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.Metrics =
+		&v1beta1.DedicatedRepoMetrics{Port: 9854}
+
+	service, err = r.reconcileRepoHostMetricsService(ctx, postgresCluster)
+	assert.NilError(t, err)
+	assert.Equal(t, service.Name, "hippocluster-repo-host-metrics")
+	assert.DeepEqual(t, service.Spec.Selector,
+		map[string]string(naming.PGBackRestDedicatedLabels("hippocluster")))
+	assert.Equal(t, len(service.Spec.Ports), 1)
+	assert.Equal(t, service.Spec.Ports[0].Name, naming.PortMetrics)
+	assert.Equal(t, service.Spec.Ports[0].Port, int32(9854))
+	assert.Equal(t, service.Spec.Ports[0].TargetPort.IntValue(), 9854)
+	assert.Assert(t, metav1.IsControlledBy(service, postgresCluster))
+
+	// the ServiceMonitor API is not installed, which is not an error
+	assert.NilError(t, r.reconcileRepoHostServiceMonitor(ctx, postgresCluster))
+
+	// the Service is deleted once metrics are disabled
+	postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated.Metrics = nil
+
+	service, err = r.reconcileRepoHostMetricsService(ctx, postgresCluster)
+	assert.NilError(t, err)
+	assert.Assert(t, service == nil)
+	assert.NilError(t, r.reconcileRepoHostServiceMonitor(ctx, postgresCluster))
+
+	err = tClient.Get(ctx, client.ObjectKey{Namespace: ns.GetName(),
+		Name: "hippocluster-repo-host-metrics"}, &corev1.Service{})
+	assert.Assert(t, kerr.IsNotFound(err), "expected NotFound, got %v", err)
+}
+
+func TestRegexTargetTimeline(t *testing.T) {
+	for _, valid := range []string{"1", "2", "10", "current", "latest"} {
+		assert.Assert(t, regexTargetTimeline.MatchString(valid), "expected %q to be valid", valid)
+	}
+	for _, invalid := range []string{"", "0", "-1", "01", "1.5", "Latest", "latest1", "2 --delta"} {
+		assert.Assert(t, !regexTargetTimeline.MatchString(invalid), "expected %q to be invalid", invalid)
+	}
+}
+
+func TestRegexBackupSet(t *testing.T) {
+	for _, valid := range []string{
+		"20210101-000000F", "20210101-000000F_20210102-000000D", "20210101-000000F_20210102-000000I",
+	} {
+		assert.Assert(t, regexBackupSet.MatchString(valid), "expected %q to be valid", valid)
+	}
+	for _, invalid := range []string{
+		"", "latest", "20210101-000000D", "20210101-000000F_", "20210101-000000F --delta",
+	} {
+		assert.Assert(t, !regexBackupSet.MatchString(invalid), "expected %q to be invalid", invalid)
+	}
+}
+
+func TestValidateRestoreTarget(t *testing.T) {
+	for _, tc := range []struct {
+		targetType, target, invalid string
+	}{
+		{targetType: "", target: ""},
+		{targetType: "time", target: "2021-06-09 14:15:11 EDT"},
+		{targetType: "time", target: "2021-06-09T14:15:11.123Z"},
+		{targetType: "time", target: "2021-06-09 14:15:11+05:30"},
+		{targetType: "time", target: "2021-06-09", invalid: "not a valid time target"},
+		{targetType: "time", target: "2021-13-09 14:15:11", invalid: "not a valid time target"},
+		{targetType: "lsn", target: "0/3000060"},
+		{targetType: "lsn", target: "3000060", invalid: "not a valid lsn target"},
+		{targetType: "xid", target: "1234"},
+		{targetType: "xid", target: "-1", invalid: "not a valid xid target"},
+		{targetType: "name", target: "before-upgrade"},
+		{targetType: "name", target: `a" --delta "`, invalid: "characters that are not allowed"},
+		{targetType: "name", target: "$(id)", invalid: "characters that are not allowed"},
+		{targetType: "time", target: "", invalid: "a target is required"},
+		{targetType: "", target: "before-upgrade", invalid: "a type is required"},
+	} {
+		err := validateRestoreTarget(&v1beta1.PostgresClusterDataSource{
+			Type: tc.targetType, Target: tc.target,
+		})
+		if tc.invalid == "" {
+			assert.NilError(t, err, "type %q, target %q", tc.targetType, tc.target)
+		} else {
+			assert.ErrorContains(t, err, tc.invalid, "type %q, target %q", tc.targetType, tc.target)
+		}
+	}
+}
+
+func TestGenerateBackupJobSpecIntentVerify(t *testing.T) {
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+
+	envValue := func(container v1.Container, name string) string {
+		for _, env := range container.Env {
+			if env.Name == name {
+				return env.Value
+			}
+		}
+		return ""
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		spec, err := generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+			"sa", "repo.conf", nil, nil, "--type=full")
+		assert.NilError(t, err)
+		assert.Equal(t, len(spec.Template.Spec.InitContainers), 0)
+		assert.Equal(t, len(spec.Template.Spec.Containers), 1)
+		assert.Equal(t, envValue(spec.Template.Spec.Containers[0], "COMMAND"), "backup")
+	})
+
+	t.Run("OtherRepo", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+			{Name: "repo1"}, {Name: "repo2", Verify: initialize.Bool(true)},
+		}
+
+		spec, err := generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+			"sa", "repo.conf", nil, nil, "--type=full")
+		assert.NilError(t, err)
+		assert.Equal(t, len(spec.Template.Spec.InitContainers), 0)
+		assert.Equal(t, len(spec.Template.Spec.Containers), 1)
+		assert.Equal(t, envValue(spec.Template.Spec.Containers[0], "COMMAND"), "backup")
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+			{Name: "repo1", Verify: initialize.Bool(true)},
+		}
+
+		spec, err := generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+			"sa", "repo.conf", nil, nil, "--type=full")
+		assert.NilError(t, err)
+
+		// the backup runs first, followed by the verify
+		assert.Equal(t, len(spec.Template.Spec.InitContainers), 1)
+		backup := spec.Template.Spec.InitContainers[0]
+		assert.Equal(t, backup.Name, naming.PGBackRestRepoContainerName)
+		assert.Equal(t, envValue(backup, "COMMAND"), "backup")
+		assert.Equal(t, envValue(backup, "COMMAND_OPTS"), "--stanza=db --repo=1 --type=full")
+
+		assert.Equal(t, len(spec.Template.Spec.Containers), 1)
+		verify := spec.Template.Spec.Containers[0]
+		assert.Equal(t, verify.Name, naming.PGBackRestVerifyContainerName)
+		assert.Equal(t, envValue(verify, "COMMAND"), "verify")
+		assert.Equal(t, envValue(verify, "COMMAND_OPTS"), "--stanza=db --repo=1")
+		assert.Equal(t, envValue(verify, "SELECTOR"), "selector")
+
+		// both containers have the pgBackRest configuration mounted
+		assert.DeepEqual(t, verify.VolumeMounts, backup.VolumeMounts)
+		assert.Assert(t, len(verify.VolumeMounts) > 0)
+	})
+}
+
+func TestGenerateExpireJobSpecIntent(t *testing.T) {
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "hippo", Namespace: "ns",
+			Annotations: map[string]string{
+				naming.PGBackRestBackupAnnotationPrefix + "release": "v1.2.3",
+			},
+		},
+	}
+	cluster.Spec.Backups.PGBackRest.Jobs = &v1beta1.BackupJobs{
+		Exclude: []string{"scratch"},
+	}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+		{Name: "repo1", Verify: initialize.Bool(true)},
+	}
+
+	spec, err := generateExpireJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+		"sa", "repo.conf", nil, nil, "--set=20210101-000000F")
+	assert.NilError(t, err)
+
+	// only the expire runs, without any backup options or a verify
+	assert.Equal(t, len(spec.Template.Spec.InitContainers), 0)
+	assert.Equal(t, len(spec.Template.Spec.Containers), 1)
+
+	env := map[string]string{}
+	for _, e := range spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	assert.Equal(t, env["COMMAND"], "expire")
+	assert.Equal(t, env["COMMAND_OPTS"], "--stanza=db --repo=1 --set=20210101-000000F")
+	assert.Equal(t, env["SELECTOR"], "selector")
+	assert.Equal(t, spec.Template.Spec.ServiceAccountName, "sa")
+}
+
+func TestReplicaCreateBackupType(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{Name: "repo1"}}
+	status := &v1beta1.RepoStatus{Name: "repo1"}
+
+	// a full backup is needed when the backups within the repo are unknown
+	assert.Equal(t, replicaCreateBackupType(cluster, status), "full")
+
+	status.BackupCounts = &v1beta1.RepoBackupCounts{Incremental: 1}
+	assert.Equal(t, replicaCreateBackupType(cluster, status), "full")
+
+	// an existing full backup only requires an incremental backup
+	status.BackupCounts = &v1beta1.RepoBackupCounts{Full: 1}
+	assert.Equal(t, replicaCreateBackupType(cluster, status), "incr")
+
+	// the type configured for the repo always takes precedence
+	cluster.Spec.Backups.PGBackRest.Repos[0].ReplicaCreateBackupType = "full"
+	assert.Equal(t, replicaCreateBackupType(cluster, status), "full")
+
+	status.BackupCounts = nil
+	cluster.Spec.Backups.PGBackRest.Repos[0].ReplicaCreateBackupType = "diff"
+	assert.Equal(t, replicaCreateBackupType(cluster, status), "diff")
+}
+
+func TestStanzaCreateExcludedRepos(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{}
+
+	// no repos are excluded by default
+	assert.Equal(t, len(stanzaCreateExcludedRepos(cluster)), 0)
+
+	// repo names are separated by commas, ignoring whitespace and empty names
+	cluster.SetAnnotations(map[string]string{
+		naming.PGBackRestStanzaCreateExclude: "repo2, repo3,",
+	})
+	assert.DeepEqual(t, stanzaCreateExcludedRepos(cluster),
+		map[string]bool{"repo2": true, "repo3": true})
+}
+
+func TestBackupJobConfigName(t *testing.T) {
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	instances := newObservedInstances(cluster, []appsv1.StatefulSet{
+		{ObjectMeta: metav1.ObjectMeta{Name: "hippo-instance1-abcd"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "hippo-instance1-efgh"}},
+	}, nil)
+
+	job := func(configName string) *batchv1.Job {
+		spec, err := generateBackupJobSpecIntent(cluster, "selector", "pgbackrest", "repo1",
+			"sa", configName, nil, nil)
+		assert.NilError(t, err)
+		return &batchv1.Job{Spec: *spec}
+	}
+
+	t.Run("NoJob", func(t *testing.T) {
+		// the shared Job configuration does not depend on the current primary
+		assert.Equal(t, backupJobConfigName(cluster, instances, nil), pgbackrest.CMJobKey)
+	})
+
+	t.Run("SharedConfig", func(t *testing.T) {
+		assert.Equal(t, backupJobConfigName(cluster, instances,
+			job(pgbackrest.CMJobKey)), pgbackrest.CMJobKey)
+	})
+
+	t.Run("InstanceConfig", func(t *testing.T) {
+		// the config of an existing instance is kept for a Job created prior to the shared config
+		assert.Equal(t, backupJobConfigName(cluster, instances,
+			job("hippo-instance1-efgh.conf")), "hippo-instance1-efgh.conf")
+	})
+
+	t.Run("InstanceRemoved", func(t *testing.T) {
+		assert.Equal(t, backupJobConfigName(cluster, instances,
+			job("hippo-instance1-wxyz.conf")), pgbackrest.CMJobKey)
+	})
+
+	t.Run("DedicatedDisabled", func(t *testing.T) {
+		assert.Equal(t, backupJobConfigName(cluster, instances,
+			job(pgbackrest.CMRepoKey)), pgbackrest.CMJobKey)
+	})
+
+	t.Run("Dedicated", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{
+			Dedicated: &v1beta1.DedicatedRepo{},
+		}
+		assert.Equal(t, backupJobConfigName(cluster, instances,
+			job(pgbackrest.CMJobKey)), pgbackrest.CMRepoKey)
+	})
+}
+
+func TestReconcileRepoHostConfigHash(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, corev1.AddToScheme(scheme))
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{
+		Dedicated: &v1beta1.DedicatedRepo{},
+	}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		RepoHost: &v1beta1.RepoHostStatus{Ready: true},
+	}
+
+	repoHost := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name: "hippo-repo-host-0", Namespace: "ns",
+		Labels: naming.PGBackRestDedicatedLabels(cluster.Name),
+	}}
+
+	var execs int
+	active := "abcde12345"
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(repoHost).Build(),
+		PodExec: func(namespace, pod, container string, stdin io.Reader, stdout,
+			stderr io.Writer, command ...string) error {
+			execs++
+			assert.Equal(t, pod, repoHost.Name)
+			_, _ = stdout.Write([]byte(active))
+			return nil
+		},
+	}
+
+	// a mismatch reports the hash mounted within the repo host
+	active = "old"
+	assert.NilError(t, r.reconcileRepoHostConfigHash(ctx, cluster, "abcde12345"))
+	assert.Equal(t, cluster.Status.PGBackRest.RepoHost.DesiredConfigHash, "abcde12345")
+	assert.Equal(t, cluster.Status.PGBackRest.RepoHost.ActiveConfigHash, "old")
+	assert.Equal(t, execs, 1)
+
+	// the hash is read again until it matches, and then no longer read
+	active = "abcde12345"
+	assert.NilError(t, r.reconcileRepoHostConfigHash(ctx, cluster, "abcde12345"))
+	assert.NilError(t, r.reconcileRepoHostConfigHash(ctx, cluster, "abcde12345"))
+	assert.Equal(t, cluster.Status.PGBackRest.RepoHost.ActiveConfigHash, "abcde12345")
+	assert.Equal(t, execs, 2)
+
+	// nothing is read from a repo host that is not ready
+	cluster.Status.PGBackRest.RepoHost.Ready = false
+	assert.NilError(t, r.reconcileRepoHostConfigHash(ctx, cluster, "fghij67890"))
+	assert.Equal(t, cluster.Status.PGBackRest.RepoHost.DesiredConfigHash, "fghij67890")
+	assert.Equal(t, execs, 2)
+}
+
+func TestReconcileVersionMismatch(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, corev1.AddToScheme(scheme))
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
+
+	instance := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "hippo-instance-0", Namespace: "ns",
+			Labels: map[string]string{
+				naming.LabelCluster:  cluster.Name,
+				naming.LabelInstance: "hippo-instance",
+			},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: naming.ContainerDatabase, Image: "postgres"},
+			{Name: naming.PGBackRestRepoContainerName, Image: "ignored"},
+		}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	repoHost := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "hippo-repo-host-0", Namespace: "ns",
+			Labels: naming.PGBackRestDedicatedLabels(cluster.Name),
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: naming.PGBackRestRepoContainerName, Image: "pgbackrest"},
+		}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	var execs int
+	versions := map[string]string{instance.Name: "2.33", repoHost.Name: "2.33"}
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance, repoHost).Build(),
+		Recorder: recorder,
+		PodExec: func(namespace, pod, container string, stdin io.Reader, stdout,
+			stderr io.Writer, command ...string) error {
+			execs++
+			assert.Assert(t, container != "ignored")
+			_, _ = stdout.Write([]byte("pgBackRest " + versions[pod] + "\n"))
+			return nil
+		},
+	}
+
+	// matching versions are reported without an event
+	assert.NilError(t, r.reconcileVersionMismatch(ctx, cluster))
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionVersionMismatch)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, "VersionsMatch")
+	assert.Equal(t, execs, 2)
+	assert.Equal(t, len(recorder.Events), 0)
+
+	// versions are not compared again while the images are unchanged
+	assert.NilError(t, r.reconcileVersionMismatch(ctx, cluster))
+	assert.Equal(t, execs, 2)
+
+	// a new repo host image with a different version is reported
+	versions[repoHost.Name] = "2.34"
+	repoHost.Spec.Containers[0].Image = "pgbackrest-newer"
+	assert.NilError(t, r.Client.Update(ctx, repoHost))
+	assert.NilError(t, r.reconcileVersionMismatch(ctx, cluster))
+	condition = meta.FindStatusCondition(cluster.Status.Conditions, ConditionVersionMismatch)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, EventVersionMismatch)
+	assert.Assert(t, strings.Contains(condition.Message, "2.33 (postgres)"))
+	assert.Assert(t, strings.Contains(condition.Message, "2.34 (pgbackrest-newer)"))
+	assert.Equal(t, execs, 4)
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Assert(t, strings.HasPrefix(<-recorder.Events, "Warning "+EventVersionMismatch))
+
+	// the condition is removed once only a single image remains
+	assert.NilError(t, r.Client.Delete(ctx, repoHost))
+	assert.NilError(t, r.reconcileVersionMismatch(ctx, cluster))
+	assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionVersionMismatch) == nil)
+	assert.Equal(t, execs, 4)
+}
+
+func TestSetConfigHashMismatchCondition(t *testing.T) {
+
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{Recorder: recorder}
+	cluster := &v1beta1.PostgresCluster{}
+
+	// a new mismatch sets the condition without recording an event
+	r.setConfigHashMismatchCondition(cluster, true)
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionConfigHashMismatch)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, "ConfigHashMismatch")
+	assert.Equal(t, len(recorder.Events), 0)
+
+	// a mismatch persisting beyond the threshold updates the reason and records a single event
+	condition.LastTransitionTime = metav1.NewTime(
+		time.Now().Add(-2 * configHashMismatchThreshold))
+	r.setConfigHashMismatchCondition(cluster, true)
+	r.setConfigHashMismatchCondition(cluster, true)
+	condition = meta.FindStatusCondition(cluster.Status.Conditions, ConditionConfigHashMismatch)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Reason, EventConfigNotPropagating)
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Assert(t, strings.HasPrefix(<-recorder.Events, "Warning "+EventConfigNotPropagating))
+
+	// the condition is removed once the configuration propagates
+	r.setConfigHashMismatchCondition(cluster, false)
+	assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionConfigHashMismatch) == nil)
+}
+
+func TestSetRepoConfigStatus(t *testing.T) {
+
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{Recorder: recorder}
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+		Name:   "repo1",
+		Volume: &v1beta1.RepoPVC{},
+	}, {
+		Name: "repo2",
+		S3:   &v1beta1.RepoS3{Bucket: "bucket", Endpoint: "endpoint", Region: "region"},
+	}}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{{Name: "repo1"}, {Name: "repo2"}},
+	}
+
+	// missing credentials are reported in the status of the external repo, with a single event
+	r.setRepoConfigStatus(cluster, map[string]string{})
+	r.setRepoConfigStatus(cluster, map[string]string{})
+	assert.Assert(t, cluster.Status.PGBackRest.Repos[0].ConfigValid == nil)
+	assert.DeepEqual(t, cluster.Status.PGBackRest.Repos[1].ConfigValid, initialize.Bool(false))
+	assert.Assert(t, strings.Contains(cluster.Status.PGBackRest.Repos[1].ConfigError,
+		"repo2-s3-key"))
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Assert(t, strings.HasPrefix(<-recorder.Events, "Warning "+EventInvalidRepoConfig))
+
+	// the error is cleared once the credentials are provided
+	r.setRepoConfigStatus(cluster, map[string]string{
+		"repo2-s3-key": "key", "repo2-s3-key-secret": "secret",
+	})
+	assert.DeepEqual(t, cluster.Status.PGBackRest.Repos[1].ConfigValid, initialize.Bool(true))
+	assert.Equal(t, cluster.Status.PGBackRest.Repos[1].ConfigError, "")
+	assert.Equal(t, len(recorder.Events), 0)
+
+	// the retention settings of any repo are validated, including those of volume repos
+	cluster.Spec.Backups.PGBackRest.Repos[0].Retention = &v1beta1.RepoRetention{
+		Full: initialize.Int32(14), FullType: "time",
+	}
+	r.setRepoConfigStatus(cluster, map[string]string{
+		"repo2-s3-key": "key", "repo2-s3-key-secret": "secret",
+		"repo1-retention-full-type": "count",
+	})
+	assert.DeepEqual(t, cluster.Status.PGBackRest.Repos[0].ConfigValid, initialize.Bool(false))
+	assert.Assert(t, strings.Contains(cluster.Status.PGBackRest.Repos[0].ConfigError,
+		"repo1-retention-full-type"))
+	assert.DeepEqual(t, cluster.Status.PGBackRest.Repos[1].ConfigValid, initialize.Bool(true))
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Assert(t, strings.HasPrefix(<-recorder.Events, "Warning "+EventInvalidRepoConfig))
+}
+
+func TestReconcileRepoAccess(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, corev1.AddToScheme(scheme))
+
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+		Name:   "repo1",
+		Volume: &v1beta1.RepoPVC{},
+	}, {
+		Name: "repo2",
+		S3:   &v1beta1.RepoS3{Bucket: "bucket", Endpoint: "endpoint", Region: "region"},
+	}}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{
+			{Name: "repo1"},
+			{Name: "repo2", ConfigValid: initialize.Bool(true)},
+		},
+	}
+
+	primary := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name: "hippo-instance-0", Namespace: "ns",
+		Labels: map[string]string{
+			naming.LabelCluster:  cluster.Name,
+			naming.LabelInstance: "hippo-instance",
+			naming.LabelRole:     naming.RolePatroniLeader,
+		},
+	}}
+
+	var execs int
+	var output string
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(primary).Build(),
+		Recorder: recorder,
+		PodExec: func(namespace, pod, container string, stdin io.Reader, stdout,
+			stderr io.Writer, command ...string) error {
+			execs++
+			assert.Equal(t, pod, primary.Name)
+			assert.DeepEqual(t, command[5:7], []string{"abcde12345", "2"})
+			if output != "" {
+				_, _ = stderr.Write([]byte(output))
+				return errors.New("exit status 39")
+			}
+			return nil
+		},
+	}
+
+	// a missing bucket is reported in the status of the repo and the condition, with an event
+	output = "ERROR: [039]: HTTP request failed with 404 (Not Found)"
+	retry, err := r.reconcileRepoAccess(ctx, cluster, "abcde12345")
+	assert.NilError(t, err)
+	assert.Assert(t, retry)
+	assert.Equal(t, cluster.Status.PGBackRest.Repos[1].AccessError, output)
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionReposAccessible)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, "BucketNotFound")
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Assert(t, strings.HasPrefix(<-recorder.Events, "Warning "+EventRepoInaccessible))
+
+	// denied access is reported as well
+	output = "ERROR: [039]: HTTP request failed with 403 (Forbidden)"
+	_, err = r.reconcileRepoAccess(ctx, cluster, "abcde12345")
+	assert.NilError(t, err)
+	condition = meta.FindStatusCondition(cluster.Status.Conditions, ConditionReposAccessible)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Reason, "AccessDenied")
+	assert.Equal(t, len(recorder.Events), 1)
+	<-recorder.Events
+
+	// once accessible, the repo is not checked again for the same configuration
+	output = ""
+	retry, err = r.reconcileRepoAccess(ctx, cluster, "abcde12345")
+	assert.NilError(t, err)
+	assert.Assert(t, !retry)
+	assert.Equal(t, cluster.Status.PGBackRest.Repos[1].AccessError, "")
+	assert.Equal(t, cluster.Status.PGBackRest.Repos[1].AccessConfigHash, "abcde12345")
+	condition = meta.FindStatusCondition(cluster.Status.Conditions, ConditionReposAccessible)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, execs, 3)
+
+	_, err = r.reconcileRepoAccess(ctx, cluster, "abcde12345")
+	assert.NilError(t, err)
+	assert.Equal(t, execs, 3)
+	assert.Equal(t, len(recorder.Events), 0)
+
+	// the condition is removed once no cloud repos remain
+	cluster.Spec.Backups.PGBackRest.Repos = cluster.Spec.Backups.PGBackRest.Repos[:1]
+	_, err = r.reconcileRepoAccess(ctx, cluster, "abcde12345")
+	assert.NilError(t, err)
+	assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionReposAccessible) == nil)
+}
+
+func TestScheduledBackupsDeferral(t *testing.T) {
+
+	now := time.Now()
+	cluster := &v1beta1.PostgresCluster{}
+	assert.Equal(t, scheduledBackupsDeferral(cluster, now), time.Duration(0))
+
+	// nothing is deferred without a configured delay
+	bootstrapped := metav1.NewTime(now.Add(-time.Minute))
+	cluster.Status.Patroni = &v1beta1.PatroniStatus{
+		SystemIdentifier: "6952526174828511264",
+		BootstrappedAt:   &bootstrapped,
+	}
+	assert.Equal(t, scheduledBackupsDeferral(cluster, now), time.Duration(0))
+
+	cluster.Spec.Backups.PGBackRest.ScheduledBackupDelaySeconds = initialize.Int32(300)
+	assert.Equal(t, scheduledBackupsDeferral(cluster, now), 4*time.Minute)
+
+	// scheduled backups are enabled once the delay has passed
+	assert.Equal(t, scheduledBackupsDeferral(cluster, now.Add(10*time.Minute)), time.Duration(0))
+}
+
+func TestScheduledBackupWindow(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2021, time.June, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	// scheduled backups are always allowed without any windows
+	cluster := &v1beta1.PostgresCluster{}
+	open, next := scheduledBackupWindow(cluster, at(12, 0))
+	assert.Assert(t, open)
+	assert.Equal(t, next, time.Duration(0))
+
+	// no backups during business hours
+	cluster.Spec.Backups.PGBackRest.ScheduledBackupWindows = []v1beta1.BackupWindow{
+		{Start: "17:00", End: "09:00"},
+	}
+	open, next = scheduledBackupWindow(cluster, at(12, 0))
+	assert.Assert(t, !open)
+	assert.Equal(t, next, 5*time.Hour)
+
+	open, next = scheduledBackupWindow(cluster, at(17, 0))
+	assert.Assert(t, open)
+	assert.Equal(t, next, 16*time.Hour)
+
+	open, next = scheduledBackupWindow(cluster, at(8, 59))
+	assert.Assert(t, open)
+	assert.Equal(t, next, time.Minute)
+
+	// the time provided is compared in UTC
+	open, _ = scheduledBackupWindow(cluster, at(12, 0).In(time.FixedZone("X", 8*60*60)))
+	assert.Assert(t, !open)
+
+	// backups are allowed within any of the windows
+	cluster.Spec.Backups.PGBackRest.ScheduledBackupWindows = []v1beta1.BackupWindow{
+		{Start: "01:00", End: "02:00"},
+		{Start: "12:00", End: "12:30"},
+	}
+	open, next = scheduledBackupWindow(cluster, at(12, 15))
+	assert.Assert(t, open)
+	assert.Equal(t, next, 15*time.Minute)
+
+	open, next = scheduledBackupWindow(cluster, at(13, 0))
+	assert.Assert(t, !open)
+	assert.Equal(t, next, 12*time.Hour)
+
+	// a window that closes when it opens spans the entire day
+	cluster.Spec.Backups.PGBackRest.ScheduledBackupWindows = []v1beta1.BackupWindow{
+		{Start: "06:00", End: "06:00"},
+	}
+	open, _ = scheduledBackupWindow(cluster, at(5, 0))
+	assert.Assert(t, open)
+}
+
+func TestStaleScheduledBackupJobs(t *testing.T) {
+
+	now := time.Now()
+	newJob := func(name, cronJob string, age time.Duration, failed bool) batchv1.Job {
+		job := batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			Labels:            map[string]string{naming.LabelPGBackRestCronJob: "full"},
+			OwnerReferences: []metav1.OwnerReference{{
+				Kind: "CronJob", Name: cronJob, Controller: initialize.Bool(true),
+			}},
+		}}
+		if failed {
+			job.Status.Conditions = []batchv1.JobCondition{{
+				Type: batchv1.JobFailed, Status: corev1.ConditionTrue,
+			}}
+		}
+		return job
+	}
+
+	jobs := []batchv1.Job{
+		newJob("full-old-failed", "full", 3*time.Hour, true),
+		newJob("full-old-succeeded", "full", 2*time.Hour, false),
+		newJob("full-newest-failed", "full", time.Hour, true),
+		newJob("diff-old-failed", "diff", 2*time.Hour, true),
+		newJob("diff-newest", "diff", time.Hour, false),
+		newJob("incr-newest-failed", "incr", time.Hour, true),
+	}
+
+	stale := []string{}
+	for _, job := range staleScheduledBackupJobs(jobs) {
+		stale = append(stale, job.GetName())
+	}
+	assert.DeepEqual(t, stale, []string{"full-old-failed", "diff-old-failed"})
+}
+
+func TestSetLatestBackupStatus(t *testing.T) {
+
+	now := time.Now()
+	newJob := func(name, repoName string, age time.Duration) *batchv1.Job {
+		return &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			Labels:            map[string]string{naming.LabelPGBackRestRepo: repoName},
+		}}
+	}
+
+	cluster := &v1beta1.PostgresCluster{}
+	repoResources := &RepoResources{
+		manualBackupJobs: []*batchv1.Job{
+			newJob("manual-old", "repo1", 2*time.Hour),
+			newJob("manual-new", "repo2", time.Hour),
+		},
+		replicaCreateBackupJobs: []*batchv1.Job{newJob("replica-create", "repo1", time.Hour)},
+		scheduledBackupJobs: []*batchv1.Job{
+			newJob("scheduled-new", "repo1", time.Minute),
+			newJob("scheduled-old", "repo1", time.Hour),
+		},
+	}
+	repoResources.scheduledBackupJobs[0].Status.Active = 1
+
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{Recorder: recorder}
+	r.setLatestBackupStatus(cluster, repoResources)
+
+	latest := cluster.Status.PGBackRest.LatestBackups
+	assert.Equal(t, len(latest), 3)
+	assert.Equal(t, latest[0].Type, string(naming.BackupManual))
+	assert.Equal(t, latest[0].JobName, "manual-new")
+	assert.Equal(t, latest[0].RepoName, "repo2")
+	assert.Equal(t, latest[1].Type, string(naming.BackupReplicaCreate))
+	assert.Equal(t, latest[1].JobName, "replica-create")
+	assert.Equal(t, latest[2].Type, string(naming.BackupScheduled))
+	assert.Equal(t, latest[2].JobName, "scheduled-new")
+	assert.Equal(t, latest[2].Active, int32(1))
+	assert.Equal(t, len(recorder.Events), 0, "no backups have started")
+
+	// events are recorded once as backups start and finish
+	start := metav1.NewTime(now.Add(-time.Minute))
+	repoResources.scheduledBackupJobs[0].Status.StartTime = &start
+	r.setLatestBackupStatus(cluster, repoResources)
+	r.setLatestBackupStatus(cluster, repoResources)
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Equal(t, <-recorder.Events, "Normal BackupStarted pgBackRest backup started: "+
+		"id=scheduled-new type=scheduled repo=repo1 job=scheduled-new")
+
+	complete := metav1.NewTime(start.Add(90 * time.Second))
+	repoResources.scheduledBackupJobs[0].Status.CompletionTime = &complete
+	repoResources.scheduledBackupJobs[0].Status.Conditions = []batchv1.JobCondition{{
+		Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+	}}
+	r.setLatestBackupStatus(cluster, repoResources)
+	r.setLatestBackupStatus(cluster, repoResources)
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Equal(t, <-recorder.Events, "Normal BackupCompleted pgBackRest backup completed: "+
+		"id=scheduled-new type=scheduled repo=repo1 job=scheduled-new duration=1m30s")
+	assert.Assert(t, cluster.Status.PGBackRest.LatestBackups[2].Finished)
+
+	// the status of a finished backup is retained once its Job has been removed
+	repoResources.replicaCreateBackupJobs[0].Status.Conditions = []batchv1.JobCondition{{
+		Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+	}}
+	r.setLatestBackupStatus(cluster, repoResources)
+	assert.Equal(t, len(recorder.Events), 1)
+	<-recorder.Events
+
+	repoResources.replicaCreateBackupJobs = nil
+	repoResources.manualBackupJobs = nil
+	r.setLatestBackupStatus(cluster, repoResources)
+	latest = cluster.Status.PGBackRest.LatestBackups
+	assert.Equal(t, len(latest), 2, "unfinished manual backup is removed")
+	assert.Equal(t, latest[0].Type, string(naming.BackupReplicaCreate))
+	assert.Equal(t, latest[0].JobName, "replica-create")
+	assert.Assert(t, latest[0].Finished)
+	assert.Equal(t, latest[1].Type, string(naming.BackupScheduled))
+	assert.Equal(t, len(recorder.Events), 0)
+}
+
+func TestSetLastBackupStatus(t *testing.T) {
+
+	now := time.Now()
+	completedJob := func(repoName string, age time.Duration,
+		labels, annotations map[string]string) *batchv1.Job {
+		complete := metav1.NewTime(now.Add(-age))
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Labels:      naming.Merge(labels, map[string]string{naming.LabelPGBackRestRepo: repoName}),
+			Annotations: annotations,
+		}}
+		job.Status.CompletionTime = &complete
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+		}}
+		return job
+	}
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{{Name: "repo1"}, {Name: "repo2"}, {Name: "repo3"}},
+	}
+
+	running := completedJob("repo1", 0, nil, nil)
+	running.Status.Conditions = nil
+	running.Status.CompletionTime = nil
+
+	repoResources := &RepoResources{
+		manualBackupJobs: []*batchv1.Job{
+			completedJob("repo2", time.Hour, nil,
+				map[string]string{naming.PGBackRestBackupSet: "20210101-000000F_20210101-010000D"}),
+		},
+		replicaCreateBackupJobs: []*batchv1.Job{
+			completedJob("repo1", 2*time.Hour,
+				map[string]string{naming.LabelPGBackRestBackupType: full}, nil),
+		},
+		scheduledBackupJobs: []*batchv1.Job{
+			completedJob("repo1", time.Hour,
+				map[string]string{naming.LabelPGBackRestBackupType: incremental}, nil),
+			completedJob("repo1", 3*time.Hour,
+				map[string]string{naming.LabelPGBackRestBackupType: differential}, nil),
+			running,
+		},
+	}
+
+	// the most recent completed backup of each repo is reported
+	setLastBackupStatus(cluster, repoResources)
+	repos := cluster.Status.PGBackRest.Repos
+	assert.Assert(t, repos[0].LastBackupTime != nil)
+	assert.Assert(t, repos[0].LastBackupTime.Equal(
+		repoResources.scheduledBackupJobs[0].Status.CompletionTime))
+	assert.Equal(t, repos[0].LastBackupType, incremental)
+	assert.Assert(t, repos[1].LastBackupTime != nil)
+	assert.Equal(t, repos[1].LastBackupType, differential, "type from the backup set")
+	assert.Assert(t, repos[2].LastBackupTime == nil)
+	assert.Equal(t, repos[2].LastBackupType, "")
+
+	// the status is retained once the Jobs have been removed
+	setLastBackupStatus(cluster, &RepoResources{})
+	assert.Assert(t, cluster.Status.PGBackRest.Repos[0].LastBackupTime != nil)
+	assert.Equal(t, cluster.Status.PGBackRest.Repos[0].LastBackupType, incremental)
+
+	// and is only replaced by a more recent backup
+	setLastBackupStatus(cluster, &RepoResources{
+		scheduledBackupJobs: []*batchv1.Job{completedJob("repo1", time.Minute,
+			map[string]string{naming.LabelPGBackRestBackupType: full}, nil)},
+	})
+	assert.Equal(t, cluster.Status.PGBackRest.Repos[0].LastBackupType, full)
+}
+
+func TestReconcileReplicaCreateBackup(t *testing.T) {
+
+	// setup the test environment and ensure a clean teardown
+	tEnv, tClient, cfg := setupTestEnv(t, ControllerName)
+	t.Cleanup(func() { teardownTestEnv(t, tEnv) })
+	r := &Reconciler{}
+	ctx, cancel := setupManager(t, cfg, func(mgr manager.Manager) {
+		r = &Reconciler{
+			Client:   mgr.GetClient(),
+			Recorder: mgr.GetEventRecorderFor(ControllerName),
+			Tracer:   otel.Tracer(ControllerName),
+			Owner:    ControllerName,
+		}
+	})
+	t.Cleanup(func() { teardownManager(cancel, t) })
+
+	clusterName := "hippocluster"
+	clusterUID := "hippouid"
+
+	ns := &v1.Namespace{}
+	ns.GenerateName = "postgres-operator-test-"
+	assert.NilError(t, tClient.Create(ctx, ns))
+	t.Cleanup(func() { assert.Check(t, tClient.Delete(ctx, ns)) })
+	namespace := ns.Name
+
+	// create a PostgresCluster to test with
+	postgresCluster := fakePostgresCluster(clusterName, ns.GetName(), clusterUID, true)
+	// set status for the "replica create" repo, e.g. the repo ad index 0
+	postgresCluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: false}},
+	}
+	instances := newObservedInstances(postgresCluster, nil, []corev1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"status": `"role":"master"`},
+		},
+	}})
+
+	// first add a fake dedicated repo pod to the env
+	repoHost := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-repo-host",
+			Namespace: namespace,
+			Labels:    naming.PGBackRestDedicatedLabels(clusterName),
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "test", Image: "test"}},
+		},
+	}
+	assert.NilError(t, r.Client.Create(ctx, repoHost))
+
+	err := wait.Poll(time.Second/2, time.Second*3, func() (bool, error) {
+		if err := r.Client.Get(ctx,
+			client.ObjectKeyFromObject(repoHost), &corev1.Pod{}); err != nil {
+			return false, nil
+		}
+		return true, nil
+	})
+	assert.NilError(t, err)
+
+	meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
+		ObservedGeneration: postgresCluster.GetGeneration(),
+		Type:               ConditionRepoHostReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "RepoHostReady",
+		Message:            "pgBackRest dedicated repository host is ready",
+	})
+	meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
+		ObservedGeneration: postgresCluster.GetGeneration(),
+		Type:               ConditionReplicaRepoReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "StanzaCreated",
+		Message:            "pgBackRest replica create repo is ready for backups",
+	})
+	postgresCluster.Status.Patroni = &v1beta1.PatroniStatus{
+		SystemIdentifier: "6952526174828511264",
+	}
+
+	replicaCreateRepo := postgresCluster.Spec.Backups.PGBackRest.Repos[0].Name
+	configHash := "abcde12345"
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo-sa"},
+	}
+
+	err = r.reconcileReplicaCreateBackup(ctx, postgresCluster, instances,
+		[]*batchv1.Job{}, sa, configHash, replicaCreateRepo)
 	assert.NilError(t, err)
 
 	// now find the expected job
@@ -1001,7 +4276,7 @@ func TestReconcileReplicaCreateBackup(t *testing.T) {
 	}
 	assert.Assert(t, foundOwnershipRef)
 
-	var foundConfigAnnotation, foundHashAnnotation bool
+	var foundConfigAnnotation, foundHashAnnotation, foundTypeAnnotation bool
 	// verify annotations
 	for k, v := range backupJob.GetAnnotations() {
 		if k == naming.PGBackRestCurrentConfig && v == pgbackrest.CMRepoKey {
@@ -1010,9 +4285,13 @@ func TestReconcileReplicaCreateBackup(t *testing.T) {
 		if k == naming.PGBackRestConfigHash && v == configHash {
 			foundHashAnnotation = true
 		}
+		if k == naming.PGBackRestBackupType && v == "full" {
+			foundTypeAnnotation = true
+		}
 	}
 	assert.Assert(t, foundConfigAnnotation)
 	assert.Assert(t, foundHashAnnotation)
+	assert.Assert(t, foundTypeAnnotation)
 
 	// verify container & env vars
 	assert.Assert(t, len(backupJob.Spec.Template.Spec.Containers) == 1)
@@ -1024,7 +4303,7 @@ func TestReconcileReplicaCreateBackup(t *testing.T) {
 		case "COMMAND":
 			assert.Assert(t, env.Value == "backup")
 		case "COMMAND_OPTS":
-			assert.Assert(t, env.Value == "--stanza=db --repo=1")
+			assert.Assert(t, env.Value == "--stanza=db --repo=1 --type=full")
 		case "COMPARE_HASH":
 			assert.Assert(t, env.Value == "true")
 		case "CONTAINER":
@@ -1049,6 +4328,21 @@ func TestReconcileReplicaCreateBackup(t *testing.T) {
 	assert.Equal(t, backupJob.Spec.Template.Spec.ImagePullSecrets[0].Name,
 		"myImagePullSecret")
 
+	// a running Job is left as is when the backup options change, since the Pod template of
+	// a Job cannot be updated
+	backupJobs := postgresCluster.Spec.Backups.PGBackRest.Jobs
+	postgresCluster.Spec.Backups.PGBackRest.Jobs = &v1beta1.BackupJobs{
+		Exclude: []string{"scratch"},
+	}
+	err = r.reconcileReplicaCreateBackup(ctx, postgresCluster, instances,
+		[]*batchv1.Job{&backupJob}, sa, configHash, replicaCreateRepo)
+	assert.NilError(t, err)
+	postgresCluster.Spec.Backups.PGBackRest.Jobs = backupJobs
+
+	runningJob := &batchv1.Job{}
+	assert.NilError(t, tClient.Get(ctx, client.ObjectKeyFromObject(&backupJob), runningJob))
+	assert.DeepEqual(t, runningJob.Spec.Template.Spec.Containers[0].Env, container.Env)
+
 	// now set the job to complete
 	backupJob.Status.Conditions = append(backupJob.Status.Conditions,
 		batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue})
@@ -1077,6 +4371,13 @@ func TestReconcileReplicaCreateBackup(t *testing.T) {
 	if assert.Check(t, replicaCreateRepoStatus != nil) {
 		assert.Assert(t, replicaCreateRepoStatus.ReplicaCreateBackupComplete)
 	}
+
+	// once completion has been recorded, the successful Job is deleted
+	err = r.reconcileReplicaCreateBackup(ctx, postgresCluster, instances,
+		[]*batchv1.Job{&backupJob}, sa, configHash, replicaCreateRepo)
+	assert.NilError(t, err)
+	err = tClient.Get(ctx, client.ObjectKeyFromObject(&backupJob), &batchv1.Job{})
+	assert.Assert(t, kerr.IsNotFound(err), "expected NotFound, got %v", err)
 }
 
 func TestReconcileManualBackup(t *testing.T) {
@@ -1137,6 +4438,8 @@ func TestReconcileManualBackup(t *testing.T) {
 		dedicatedOnly bool
 		// whether or not the primary instance should be read-only
 		standby bool
+		// whether or not Patroni should be paused
+		paused bool
 		// whether or not to mock a current job in the env before reconciling (this job is not
 		// actually created, but rather just passed into the reconcile function under test)
 		createCurrentJob bool
@@ -1324,7 +4627,75 @@ func TestReconcileManualBackup(t *testing.T) {
 		expectCurrentJobDeletion: false,
 		expectReconcile:          true,
 	}, {
-		testDesc:         "reconcile job when current job exists for id and is in progress",
+		testDesc:         "offline backup should not reconcile unless patroni is paused",
+		createCurrentJob: false,
+		clusterConditions: map[string]metav1.ConditionStatus{
+			ConditionRepoHostReady: metav1.ConditionTrue,
+			ConditionReplicaCreate: metav1.ConditionTrue,
+		},
+		standby: true,
+		status: &v1beta1.PostgresClusterStatus{
+			PGBackRest: &v1beta1.PGBackRestStatus{
+				Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: true}}},
+		},
+		backupId: backupId,
+		manual: &v1beta1.PGBackRestManualBackup{
+			RepoName: "repo1", Online: initialize.Bool(false)},
+		expectCurrentJobDeletion: false,
+		expectReconcile:          false,
+		expectedEventReason:      "InvalidManualBackup",
+	}, {
+		testDesc:         "reconcile offline backup job when patroni is paused",
+		createCurrentJob: false,
+		clusterConditions: map[string]metav1.ConditionStatus{
+			ConditionRepoHostReady: metav1.ConditionTrue,
+			ConditionReplicaCreate: metav1.ConditionTrue,
+		},
+		standby: true,
+		paused:  true,
+		status: &v1beta1.PostgresClusterStatus{
+			PGBackRest: &v1beta1.PGBackRestStatus{
+				Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: true}}},
+		},
+		backupId: backupId,
+		manual: &v1beta1.PGBackRestManualBackup{
+			RepoName: "repo1", Online: initialize.Bool(false)},
+		expectCurrentJobDeletion: false,
+		expectReconcile:          true,
+	}, {
+		testDesc:         "reconcile job with a backup type",
+		createCurrentJob: false,
+		clusterConditions: map[string]metav1.ConditionStatus{
+			ConditionRepoHostReady: metav1.ConditionTrue,
+			ConditionReplicaCreate: metav1.ConditionTrue,
+		},
+		status: &v1beta1.PostgresClusterStatus{
+			PGBackRest: &v1beta1.PGBackRestStatus{
+				Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: true}}},
+		},
+		backupId:                 backupId,
+		manual:                   &v1beta1.PGBackRestManualBackup{RepoName: "repo1", Type: "diff"},
+		expectCurrentJobDeletion: false,
+		expectReconcile:          true,
+	}, {
+		testDesc:         "backup type with type option should not reconcile",
+		createCurrentJob: false,
+		clusterConditions: map[string]metav1.ConditionStatus{
+			ConditionRepoHostReady: metav1.ConditionTrue,
+			ConditionReplicaCreate: metav1.ConditionTrue,
+		},
+		status: &v1beta1.PostgresClusterStatus{
+			PGBackRest: &v1beta1.PGBackRestStatus{
+				Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: true}}},
+		},
+		backupId: backupId,
+		manual: &v1beta1.PGBackRestManualBackup{
+			RepoName: "repo1", Type: "diff", Options: []string{"--type=full"}},
+		expectCurrentJobDeletion: false,
+		expectReconcile:          false,
+		expectedEventReason:      "InvalidManualBackup",
+	}, {
+		testDesc:         "do not update current job when it exists for id and is in progress",
 		createCurrentJob: true,
 		clusterConditions: map[string]metav1.ConditionStatus{
 			ConditionRepoHostReady: metav1.ConditionTrue,
@@ -1337,9 +4708,9 @@ func TestReconcileManualBackup(t *testing.T) {
 		backupId:                 defaultBackupId,
 		manual:                   &v1beta1.PGBackRestManualBackup{RepoName: "repo1"},
 		expectCurrentJobDeletion: false,
-		expectReconcile:          true,
+		expectReconcile:          false,
 	}, {
-		testDesc:         "reconcile new job when in-progress job exists for another id",
+		testDesc:         "wait for in-progress job for another id before reconciling new job",
 		createCurrentJob: true,
 		clusterConditions: map[string]metav1.ConditionStatus{
 			ConditionRepoHostReady: metav1.ConditionTrue,
@@ -1352,7 +4723,7 @@ func TestReconcileManualBackup(t *testing.T) {
 		backupId:                 backupId,
 		manual:                   &v1beta1.PGBackRestManualBackup{RepoName: "repo1"},
 		expectCurrentJobDeletion: false,
-		expectReconcile:          true,
+		expectReconcile:          false,
 	}, {
 		testDesc:         "delete current job since job is complete and new backup id",
 		createCurrentJob: true,
@@ -1406,6 +4777,10 @@ func TestReconcileManualBackup(t *testing.T) {
 
 				postgresCluster := fakePostgresCluster(clusterName, ns.GetName(), "", dedicated)
 				postgresCluster.Spec.Backups.PGBackRest.Manual = tc.manual
+				if tc.paused {
+					postgresCluster.Spec.Patroni = &v1beta1.PatroniSpec{}
+					postgresCluster.Spec.Patroni.DynamicConfiguration.Raw = []byte(`{"pause":true}`)
+				}
 				postgresCluster.Status = *tc.status
 				postgresCluster.Annotations = map[string]string{naming.PGBackRestBackup: tc.backupId}
 				for condition, status := range tc.clusterConditions {
@@ -1460,6 +4835,10 @@ func TestReconcileManualBackup(t *testing.T) {
 					}
 					assert.Assert(t, foundOwnershipRef)
 
+					// verify the backup lock is held by the manual backup Job
+					assert.Equal(t, jobs.Items[0].GetName(),
+						postgresCluster.GetAnnotations()[naming.PGBackRestBackupLock])
+
 					// verify image pull secret
 					var foundImagePullSecret bool
 					for _, job := range jobs.Items {
@@ -1472,6 +4851,41 @@ func TestReconcileManualBackup(t *testing.T) {
 					}
 					assert.Assert(t, foundImagePullSecret)
 
+					// verify the backup runs on the dedicated repo host if enabled, and otherwise
+					// directly within the database container of the current primary
+					expectedContainer := naming.ContainerDatabase
+					if dedicated {
+						expectedContainer = naming.PGBackRestRepoContainerName
+					}
+					var foundContainerEnv bool
+					for _, env := range jobs.Items[0].Spec.Template.Spec.Containers[0].Env {
+						if env.Name == "CONTAINER" {
+							foundContainerEnv = true
+							assert.Equal(t, env.Value, expectedContainer)
+						}
+					}
+					assert.Assert(t, foundContainerEnv)
+
+					// verify an offline backup is taken using the "--no-online" option
+					for _, env := range jobs.Items[0].Spec.Template.Spec.Containers[0].Env {
+						if env.Name == "COMMAND_OPTS" {
+							offline := tc.manual.Online != nil && !*tc.manual.Online
+							assert.Equal(t, strings.Contains(env.Value, "--no-online"), offline)
+						}
+					}
+
+					// verify the type of backup, if any, is passed using the "--type" option
+					if tc.manual.Type != "" {
+						for _, env := range jobs.Items[0].Spec.Template.Spec.Containers[0].Env {
+							if env.Name == "COMMAND_OPTS" {
+								assert.Assert(t, strings.Contains(env.Value,
+									"--type="+tc.manual.Type), env.Value)
+							}
+						}
+						assert.Equal(t, jobs.Items[0].GetLabels()[naming.LabelPGBackRestBackupType],
+							tc.manual.Type)
+					}
+
 					// verify status is populated with the proper ID
 					assert.Assert(t, postgresCluster.Status.PGBackRest.ManualBackup != nil)
 					assert.Assert(t, postgresCluster.Status.PGBackRest.ManualBackup.ID != "")
@@ -1682,6 +5096,48 @@ func TestGetPGBackRestResources(t *testing.T) {
 			jobCount: 0, pvcCount: 1, hostCount: 0,
 			sshConfigPresent: false, sshSecretPresent: false,
 		},
+	}, {
+		desc: "repo no longer exists keep pvc without another stanza",
+		createResources: []client.Object{
+			&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blocked-pvc",
+					Namespace: namespace,
+					Labels:    naming.PGBackRestRepoVolumeLabels(clusterName, "repo1"),
+				},
+				Spec: v1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			},
+		},
+		cluster: &v1beta1.PostgresCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterName,
+				Namespace: namespace,
+				UID:       types.UID(clusterUID),
+			},
+			Spec: v1beta1.PostgresClusterSpec{
+				Backups: v1beta1.Backups{
+					PGBackRest: v1beta1.PGBackRestArchive{
+						Repos: []v1beta1.PGBackRestRepo{{
+							Name:   "repo4",
+							Volume: &v1beta1.RepoPVC{},
+						}},
+					},
+				},
+			},
+		},
+		// both this volume and the one kept by the previous test case remain, since no repo
+		// in the spec has a stanza
+		result: testResult{
+			jobCount: 0, pvcCount: 2, hostCount: 0,
+			sshConfigPresent: false, sshSecretPresent: false,
+		},
 	}, {
 		desc: "repo no longer exists delete pvc",
 		createResources: []client.Object{
@@ -1717,6 +5173,11 @@ func TestGetPGBackRestResources(t *testing.T) {
 					},
 				},
 			},
+			Status: v1beta1.PostgresClusterStatus{
+				PGBackRest: &v1beta1.PGBackRestStatus{
+					Repos: []v1beta1.RepoStatus{{Name: "repo4", StanzaCreated: true}},
+				},
+			},
 		},
 		result: testResult{
 			jobCount: 0, pvcCount: 0, hostCount: 0,
@@ -2074,6 +5535,7 @@ func TestReconcilePostgresClusterDataSource(t *testing.T) {
 	type testResult struct {
 		jobCount, pvcCount                                      int
 		invalidSourceRepo, invalidSourceCluster, invalidOptions bool
+		invalidTarget                                           bool
 		expectedClusterCondition                                *metav1.Condition
 	}
 
@@ -2166,6 +5628,63 @@ func TestReconcilePostgresClusterDataSource(t *testing.T) {
 				invalidSourceRepo: false, invalidSourceCluster: false, invalidOptions: true,
 				expectedClusterCondition: nil,
 			},
+		}, {
+			desc: "invalid option: set",
+			dataSource: &v1beta1.DataSource{PostgresCluster: &v1beta1.PostgresClusterDataSource{
+				ClusterName: "invalid-set-option", RepoName: "repo1",
+				Set: "20210101-000000F", Options: []string{"--set=20210101-000000F"},
+			}},
+			clusterBootstrapped: false,
+			sourceClusterName:   "invalid-set-option",
+			sourceClusterRepos:  []v1beta1.PGBackRestRepo{{Name: "repo1"}},
+			result: testResult{
+				jobCount: 0, pvcCount: 1,
+				invalidSourceRepo: false, invalidSourceCluster: false, invalidOptions: true,
+				expectedClusterCondition: nil,
+			},
+		}, {
+			desc: "backup set",
+			dataSource: &v1beta1.DataSource{PostgresCluster: &v1beta1.PostgresClusterDataSource{
+				ClusterName: "backup-set", RepoName: "repo1",
+				Set: "20210101-000000F_20210102-000000I",
+			}},
+			clusterBootstrapped: false,
+			sourceClusterName:   "backup-set",
+			sourceClusterRepos:  []v1beta1.PGBackRestRepo{{Name: "repo1"}},
+			result: testResult{
+				jobCount: 1, pvcCount: 1,
+				invalidSourceRepo: false, invalidSourceCluster: false, invalidOptions: false,
+				expectedClusterCondition: nil,
+			},
+		}, {
+			desc: "recovery target",
+			dataSource: &v1beta1.DataSource{PostgresCluster: &v1beta1.PostgresClusterDataSource{
+				ClusterName: "recovery-target", RepoName: "repo1",
+				Type: "time", Target: "2021-06-09 14:15:11-04",
+			}},
+			clusterBootstrapped: false,
+			sourceClusterName:   "recovery-target",
+			sourceClusterRepos:  []v1beta1.PGBackRestRepo{{Name: "repo1"}},
+			result: testResult{
+				jobCount: 1, pvcCount: 1,
+				invalidSourceRepo: false, invalidSourceCluster: false, invalidOptions: false,
+				expectedClusterCondition: nil,
+			},
+		}, {
+			desc: "invalid recovery target",
+			dataSource: &v1beta1.DataSource{PostgresCluster: &v1beta1.PostgresClusterDataSource{
+				ClusterName: "invalid-recovery-target", RepoName: "repo1",
+				Type: "time", Target: "yesterday",
+			}},
+			clusterBootstrapped: false,
+			sourceClusterName:   "invalid-recovery-target",
+			sourceClusterRepos:  []v1beta1.PGBackRestRepo{{Name: "repo1"}},
+			result: testResult{
+				jobCount: 0, pvcCount: 1,
+				invalidSourceRepo: false, invalidSourceCluster: false, invalidOptions: false,
+				invalidTarget:            true,
+				expectedClusterCondition: nil,
+			},
 		}, {
 			desc: "cluster bootstrapped init condition missing",
 			dataSource: &v1beta1.DataSource{PostgresCluster: &v1beta1.PostgresClusterDataSource{
@@ -2267,6 +5786,19 @@ func TestReconcilePostgresClusterDataSource(t *testing.T) {
 				if len(restoreJobs.Items) == 1 {
 					assert.Assert(t, restoreJobs.Items[0].Labels[naming.LabelStartupInstance] != "")
 					assert.Assert(t, restoreJobs.Items[0].Annotations[naming.PGBackRestConfigHash] != "")
+
+					// the backup set and recovery target, when configured, are provided to
+					// the restore command, with the cluster promoted once the target is reached
+					command := strings.Join(
+						restoreJobs.Items[0].Spec.Template.Spec.Containers[0].Command, " ")
+					if set := tc.dataSource.PostgresCluster.Set; set != "" {
+						assert.Assert(t, strings.Contains(command, "--set="+set))
+					}
+					if target := tc.dataSource.PostgresCluster.Target; target != "" {
+						assert.Assert(t, strings.Contains(command, "--type=time"))
+						assert.Assert(t, strings.Contains(command, `--target="`+target+`"`))
+						assert.Assert(t, strings.Contains(command, "--target-action=promote"))
+					}
 				}
 
 				dataPVCs := &v1.PersistentVolumeClaimList{}
@@ -2293,14 +5825,18 @@ func TestReconcilePostgresClusterDataSource(t *testing.T) {
 				}
 
 				if tc.result.invalidSourceCluster || tc.result.invalidSourceRepo ||
-					tc.result.invalidOptions {
+					tc.result.invalidOptions || tc.result.invalidTarget {
+					reason := "InvalidDataSource"
+					if tc.result.invalidTarget {
+						reason = EventInvalidRestoreTarget
+					}
 					events := &corev1.EventList{}
 					if err := wait.Poll(time.Second/2, time.Second*2, func() (bool, error) {
 						if err := tClient.List(ctx, events, &client.MatchingFields{
 							"involvedObject.kind":      "PostgresCluster",
 							"involvedObject.name":      clusterName,
 							"involvedObject.namespace": namespace,
-							"reason":                   "InvalidDataSource",
+							"reason":                   reason,
 						}); err != nil {
 							return false, err
 						}
@@ -2794,6 +6330,218 @@ func TestPrepareForRestore(t *testing.T) {
 	}
 }
 
+func TestReconcileFollowedBackups(t *testing.T) {
+	// setup the test environment and ensure a clean teardown
+	tEnv, tClient, cfg := setupTestEnv(t, ControllerName)
+	t.Cleanup(func() { teardownTestEnv(t, tEnv) })
+	r := &Reconciler{}
+	ctx, cancel := setupManager(t, cfg, func(mgr manager.Manager) {
+		r = &Reconciler{
+			Client:   mgr.GetClient(),
+			Recorder: mgr.GetEventRecorderFor(ControllerName),
+			Tracer:   otel.Tracer(ControllerName),
+			Owner:    ControllerName,
+		}
+	})
+	t.Cleanup(func() { teardownManager(cancel, t) })
+
+	ns := &v1.Namespace{}
+	ns.GenerateName = "postgres-operator-test-"
+	assert.NilError(t, tClient.Create(ctx, ns))
+	t.Cleanup(func() { assert.Check(t, tClient.Delete(ctx, ns)) })
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo-sa"},
+	}
+	instances := &observedInstances{
+		forCluster: []*Instance{{
+			Name: "instance1",
+			Pods: []*v1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"status": `{"role":"master"}`},
+					Labels:      map[string]string{naming.LabelRole: naming.RolePatroniLeader},
+				},
+			}},
+		}},
+	}
+
+	cluster := fakePostgresCluster("hippocluster", ns.GetName(), "", false)
+	cluster.Spec.Backups.PGBackRest.Repos[1].FollowSchedulesOf = "repo1"
+	assert.NilError(t, tClient.Create(ctx, cluster))
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{
+			{Name: "repo1", StanzaCreated: true},
+			{Name: "repo2", StanzaCreated: true},
+		},
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:   ConditionReplicaCreate,
+		Status: metav1.ConditionTrue,
+		Reason: "RepoBackupComplete",
+	})
+
+	completedJob := func(name, repoName, backupType string, completed time.Time) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: ns.GetName(),
+				Labels: naming.PGBackRestCronJobLabels(cluster.GetName(), repoName, backupType),
+			},
+			Status: batchv1.JobStatus{
+				CompletionTime: &metav1.Time{Time: completed},
+				Conditions: []batchv1.JobCondition{{
+					Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+				}},
+			},
+		}
+	}
+	followedJobs := func(backupType string) []batchv1.Job {
+		jobs := &batchv1.JobList{}
+		assert.NilError(t, tClient.List(ctx, jobs, client.InNamespace(ns.GetName()),
+			client.MatchingLabels(naming.PGBackRestCronJobLabels(cluster.GetName(), "repo2",
+				backupType))))
+		return jobs.Items
+	}
+
+	now := time.Now()
+	scheduled := []*batchv1.Job{
+		completedJob("hippocluster-repo1-full-1", "repo1", "full", now.Add(-2*time.Hour)),
+		completedJob("hippocluster-repo1-incr-1", "repo1", "incr", now.Add(-time.Hour)),
+	}
+
+	// the most recent backup of the followed repo is taken in the following repo
+	assert.NilError(t, r.reconcileFollowedBackups(ctx, cluster, scheduled, sa, instances))
+	assert.Equal(t, len(followedJobs("full")), 0)
+	jobs := followedJobs("incr")
+	assert.Equal(t, len(jobs), 1)
+	assert.Equal(t, jobs[0].GetAnnotations()[naming.PGBackRestBackupSource],
+		"hippocluster-repo1-incr-1")
+	assert.Equal(t, jobs[0].GetLabels()[naming.LabelPGBackRestBackup],
+		string(naming.BackupScheduled))
+	assert.Equal(t, cluster.GetAnnotations()[naming.PGBackRestBackupLock], jobs[0].GetName())
+	for _, env := range jobs[0].Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "COMMAND_OPTS" {
+			assert.Equal(t, env.Value, "--stanza=db --repo=2 --type=incr")
+		}
+	}
+
+	// nothing more is done once the backup has been taken
+	scheduled = append(scheduled, &jobs[0])
+	assert.NilError(t, r.reconcileFollowedBackups(ctx, cluster, scheduled, sa, instances))
+	assert.Equal(t, len(followedJobs("incr")), 1)
+
+	// a new backup of the followed repo waits for the backup lock to be released
+	scheduled = append(scheduled,
+		completedJob("hippocluster-repo1-full-2", "repo1", "full", now))
+	cluster.Annotations = map[string]string{naming.PGBackRestBackupLock: "other"}
+	assert.NilError(t, r.reconcileFollowedBackups(ctx, cluster, scheduled, sa, instances))
+	assert.Equal(t, len(followedJobs("full")), 0)
+
+	cluster.Annotations = nil
+	assert.NilError(t, r.reconcileFollowedBackups(ctx, cluster, scheduled, sa, instances))
+	jobs = followedJobs("full")
+	assert.Equal(t, len(jobs), 1)
+	assert.Equal(t, jobs[0].GetAnnotations()[naming.PGBackRestBackupSource],
+		"hippocluster-repo1-full-2")
+	assert.Equal(t, cluster.GetAnnotations()[naming.PGBackRestBackupLock], jobs[0].GetName())
+}
+
+func TestReconcileExpire(t *testing.T) {
+	// setup the test environment and ensure a clean teardown
+	tEnv, tClient, cfg := setupTestEnv(t, ControllerName)
+	t.Cleanup(func() { teardownTestEnv(t, tEnv) })
+	r := &Reconciler{}
+	ctx, cancel := setupManager(t, cfg, func(mgr manager.Manager) {
+		r = &Reconciler{
+			Client:   mgr.GetClient(),
+			Recorder: mgr.GetEventRecorderFor(ControllerName),
+			Tracer:   otel.Tracer(ControllerName),
+			Owner:    ControllerName,
+		}
+	})
+	t.Cleanup(func() { teardownManager(cancel, t) })
+
+	ns := &v1.Namespace{}
+	ns.GenerateName = "postgres-operator-test-"
+	assert.NilError(t, tClient.Create(ctx, ns))
+	t.Cleanup(func() { assert.Check(t, tClient.Delete(ctx, ns)) })
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo-sa"},
+	}
+	instances := &observedInstances{
+		forCluster: []*Instance{{
+			Name: "instance1",
+			Pods: []*v1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"status": `{"role":"master"}`},
+					Labels:      map[string]string{naming.LabelRole: naming.RolePatroniLeader},
+				},
+			}},
+		}},
+	}
+
+	cluster := fakePostgresCluster("hippocluster", ns.GetName(), "", false)
+	cluster.Annotations = map[string]string{naming.PGBackRestExpire: "expire-1"}
+	cluster.Spec.Backups.PGBackRest.Expire = &v1beta1.PGBackRestExpire{
+		RepoName: "repo1", Options: []string{"--repo1-retention-full=1"},
+	}
+	assert.NilError(t, tClient.Create(ctx, cluster))
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: true}},
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:   ConditionReplicaCreate,
+		Status: metav1.ConditionTrue,
+		Reason: "RepoBackupComplete",
+	})
+
+	expireJobs := func() []*batchv1.Job {
+		jobs := &batchv1.JobList{}
+		assert.NilError(t, tClient.List(ctx, jobs, client.InNamespace(ns.GetName()),
+			client.MatchingLabels(naming.PGBackRestBackupJobLabels(cluster.GetName(), "repo1",
+				naming.BackupExpire))))
+		result := []*batchv1.Job{}
+		for i := range jobs.Items {
+			result = append(result, &jobs.Items[i])
+		}
+		return result
+	}
+
+	// the expire Job is created once it holds the backup lock
+	assert.NilError(t, r.reconcileExpire(ctx, cluster, nil, sa, instances))
+	jobs := expireJobs()
+	assert.Equal(t, len(jobs), 1)
+	assert.Equal(t, jobs[0].GetAnnotations()[naming.PGBackRestExpire], "expire-1")
+	assert.Equal(t, cluster.GetAnnotations()[naming.PGBackRestBackupLock], jobs[0].GetName())
+	assert.Equal(t, cluster.Status.PGBackRest.Expire.ID, "expire-1")
+	for _, env := range jobs[0].Spec.Template.Spec.Containers[0].Env {
+		switch env.Name {
+		case "COMMAND":
+			assert.Equal(t, env.Value, "expire")
+		case "COMMAND_OPTS":
+			assert.Equal(t, env.Value, "--stanza=db --repo=1 --repo1-retention-full=1")
+		}
+	}
+
+	// the outcome of the Job is reported once it completes
+	jobs[0].Status.Conditions = []batchv1.JobCondition{{
+		Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+	}}
+	assert.NilError(t, r.reconcileExpire(ctx, cluster, jobs, sa, instances))
+	assert.Assert(t, cluster.Status.PGBackRest.Expire.Finished)
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionExpireSuccessful)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, "ExpireComplete")
+
+	// and the finished Job is deleted once another expire is requested
+	cluster.Annotations[naming.PGBackRestExpire] = "expire-2"
+	assert.NilError(t, r.reconcileExpire(ctx, cluster, jobs, sa, instances))
+	job := &batchv1.Job{}
+	err := tClient.Get(ctx, client.ObjectKeyFromObject(jobs[0]), job)
+	assert.Assert(t, kerr.IsNotFound(err) || job.GetDeletionTimestamp() != nil, err)
+}
+
 func TestReconcileScheduledBackups(t *testing.T) {
 	// setup the test environment and ensure a clean teardown
 	tEnv, tClient, cfg := setupTestEnv(t, ControllerName)
@@ -3023,6 +6771,19 @@ func TestReconcileScheduledBackups(t *testing.T) {
 						assert.Equal(t, returnedCronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Name,
 							"pgbackrest")
 						assert.Assert(t, returnedCronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].SecurityContext != &corev1.SecurityContext{})
+
+						// the CronJob runs an actual pgBackRest backup of the proper type
+						// for the repo, using the pgBackRest ServiceAccount
+						podSpec := returnedCronJob.Spec.JobTemplate.Spec.Template.Spec
+						assert.Equal(t, podSpec.ServiceAccountName, sa.GetName())
+						env := map[string]string{}
+						for _, e := range podSpec.Containers[0].Env {
+							env[e.Name] = e.Value
+						}
+						assert.Equal(t, env["COMMAND"], "backup")
+						assert.Equal(t, env["COMMAND_OPTS"],
+							"--stanza=db --repo=1 --type="+backupType)
+						assert.Assert(t, env["SELECTOR"] != "")
 					}
 					return
 				}
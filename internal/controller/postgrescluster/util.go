@@ -28,10 +28,14 @@ import (
 
 	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 var tmpDirSizeLimit = resource.MustParse("16Mi")
 
+// maxGroupID is the largest group ID that can be added as a supplemental group
+const maxGroupID = 2147483647
+
 const (
 	// nssWrapperDir is the directory in a container for the nss_wrapper passwd and group files
 	nssWrapperDir = "/tmp/nss_wrapper/%s/%s"
@@ -48,14 +52,25 @@ const (
 //  * As the pgBackRest lock directory (this is the default lock location for pgBackRest)
 //  * The location where the replication client certificates can be loaded with the proper
 //    permissions set
-func addTMPEmptyDir(template *v1.PodTemplateSpec) {
+// The size limit and medium of the EmptyDir can be customized using the provided TmpVolumeSpec,
+// e.g. to accommodate a pgBackRest spool path used for asynchronous archiving.
+func addTMPEmptyDir(template *v1.PodTemplateSpec, spec *v1beta1.TmpVolumeSpec) {
+
+	emptyDir := &v1.EmptyDirVolumeSource{
+		SizeLimit: &tmpDirSizeLimit,
+	}
+	if spec != nil {
+		if spec.SizeLimit != nil {
+			sizeLimit := spec.SizeLimit.DeepCopy()
+			emptyDir.SizeLimit = &sizeLimit
+		}
+		emptyDir.Medium = spec.Medium
+	}
 
 	template.Spec.Volumes = append(template.Spec.Volumes, v1.Volume{
 		Name: "tmp",
 		VolumeSource: v1.VolumeSource{
-			EmptyDir: &v1.EmptyDirVolumeSource{
-				SizeLimit: &tmpDirSizeLimit,
-			},
+			EmptyDir: emptyDir,
 		},
 	})
 
@@ -105,27 +120,112 @@ func addNSSWrapper(image string, template *v1.PodTemplateSpec) {
 		})
 }
 
-// jobFailed returns "true" if the Job provided has failed.  Otherwise it returns "false".
-func jobFailed(job *batchv1.Job) bool {
+// supplementalGroups returns the supplemental groups for the Pods of the PostgresCluster, which
+// default to the "nobody" group (65534) unless groups are specified in the spec
+func supplementalGroups(cluster *v1beta1.PostgresCluster) []int64 {
+	if cluster.Spec.SupplementalGroups == nil {
+		return []int64{65534}
+	}
+	return append([]int64{}, cluster.Spec.SupplementalGroups...)
+}
+
+// fsGroup returns the FSGroup for the Pods of the PostgresCluster, which defaults to the
+// "postgres" group (26) unless specified in the spec.  By default no FSGroup is set when
+// deploying to OpenShift, where it is instead assigned according to the SCC of the Pod.
+func fsGroup(cluster *v1beta1.PostgresCluster) *int64 {
+	if cluster.Spec.FSGroup != nil {
+		return initialize.Int64(*cluster.Spec.FSGroup)
+	}
+	if cluster.Spec.OpenShift != nil && *cluster.Spec.OpenShift {
+		return nil
+	}
+	return initialize.Int64(26)
+}
+
+// invalidSupplementalGroup returns the first supplemental group in the spec of the PostgresCluster
+// that is not a valid, non-root group ID, and whether or not such a group was found
+func invalidSupplementalGroup(cluster *v1beta1.PostgresCluster) (int64, bool) {
+	for _, group := range cluster.Spec.SupplementalGroups {
+		if group < 1 || group > maxGroupID {
+			return group, true
+		}
+	}
+	return 0, false
+}
+
+// addWaitForSecrets adds an init container to the Pod template that mounts each of the Secrets
+// provided.  Since the kubelet will not start the init container (or any of the containers that
+// follow it) until all of its volumes have been mounted, this prevents the Pod from starting
+// until the Secrets exist, e.g. once they have been synchronized from an external secret store.
+// Nothing is added if no Secrets are provided.
+func addWaitForSecrets(image string, template *v1.PodTemplateSpec, secretNames []string) {
+
+	if len(secretNames) == 0 {
+		return
+	}
+
+	container := v1.Container{
+		Command:         []string{"true"},
+		Image:           image,
+		Name:            naming.ContainerWaitForSecrets,
+		SecurityContext: initialize.RestrictedSecurityContext(),
+	}
+
+	for i, name := range secretNames {
+		volumeName := fmt.Sprintf("%s-%d", naming.ContainerWaitForSecrets, i)
+		template.Spec.Volumes = append(template.Spec.Volumes, v1.Volume{
+			Name: volumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: name,
+					Optional:   initialize.Bool(false),
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+			Name:      volumeName,
+			MountPath: "/etc/wait-for-secrets/" + name,
+			ReadOnly:  true,
+		})
+	}
+
+	// run before any other init containers
+	template.Spec.InitContainers = append([]v1.Container{container},
+		template.Spec.InitContainers...)
+}
+
+// jobConditionTrue returns "true" if the Job provided has a condition of the type provided with
+// a status of "True".  Otherwise it returns "false".
+func jobConditionTrue(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
 	conditions := job.Status.Conditions
 	for i := range conditions {
-		if conditions[i].Type == batchv1.JobFailed {
+		if conditions[i].Type == conditionType {
 			return (conditions[i].Status == v1.ConditionTrue)
 		}
 	}
 	return false
 }
 
+// jobFailed returns "true" if the Job provided has failed.  Otherwise it returns "false".
+func jobFailed(job *batchv1.Job) bool {
+	return jobConditionTrue(job, batchv1.JobFailed)
+}
+
 // jobCompleted returns "true" if the Job provided completed successfully.  Otherwise it returns
 // "false".
 func jobCompleted(job *batchv1.Job) bool {
-	conditions := job.Status.Conditions
-	for i := range conditions {
-		if conditions[i].Type == batchv1.JobComplete {
-			return (conditions[i].Status == v1.ConditionTrue)
-		}
+	return jobConditionTrue(job, batchv1.JobComplete)
+}
+
+// jobActive returns the number of actively running Pods for the Job provided.  Since completion
+// and failure are determined using the Job's conditions, zero is returned once the Job has
+// finished, even if a Pod is still running (e.g. because a service mesh sidecar container has
+// not yet exited).
+func jobActive(job *batchv1.Job) int32 {
+	if jobCompleted(job) || jobFailed(job) {
+		return 0
 	}
-	return false
+	return job.Status.Active
 }
 
 // safeHash32 runs content and returns a short alphanumeric string that
@@ -143,14 +243,23 @@ func safeHash32(content func(w io.Writer) error) (string, error) {
 // updateReconcileResult creates a new Result based on the new and existing results provided to it.
 // This includes setting "Requeue" to true in the Result if set to true in the new Result but not
 // in the existing Result, while also updating RequeueAfter if the RequeueAfter value for the new
-// result is less the the RequeueAfter value for the existing Result.
+// result is less the the RequeueAfter value for the existing Result.  Only positive RequeueAfter
+// values are considered, so the most urgent delayed requeue always governs regardless of the
+// order in which results are provided.
+//
+// Both fields are retained in the Result returned.  When it is handled by controller-runtime, a
+// positive RequeueAfter takes precedence over Requeue, meaning a delayed requeue is never
+// postponed by the rate limiting applied to a bare Requeue.
 func updateReconcileResult(currResult, newResult reconcile.Result) reconcile.Result {
 
 	if newResult.Requeue {
 		currResult.Requeue = true
 	}
 
-	if newResult.RequeueAfter != 0 {
+	if currResult.RequeueAfter < 0 {
+		currResult.RequeueAfter = 0
+	}
+	if newResult.RequeueAfter > 0 {
 		if currResult.RequeueAfter == 0 || newResult.RequeueAfter < currResult.RequeueAfter {
 			currResult.RequeueAfter = newResult.RequeueAfter
 		}
@@ -26,9 +26,12 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 func TestSafeHash32(t *testing.T) {
@@ -177,6 +180,26 @@ func TestUpdateReconcileResult(t *testing.T) {
 		newResult:            reconcile.Result{},
 		requeueExpected:      true,
 		expectedRequeueAfter: 5 * time.Second,
+	}, {
+		currResult:           reconcile.Result{RequeueAfter: 5 * time.Second},
+		newResult:            reconcile.Result{RequeueAfter: -1 * time.Second},
+		requeueExpected:      false,
+		expectedRequeueAfter: 5 * time.Second,
+	}, {
+		currResult:           reconcile.Result{RequeueAfter: -1 * time.Second},
+		newResult:            reconcile.Result{RequeueAfter: 5 * time.Second},
+		requeueExpected:      false,
+		expectedRequeueAfter: 5 * time.Second,
+	}, {
+		currResult:           reconcile.Result{RequeueAfter: -1 * time.Second},
+		newResult:            reconcile.Result{Requeue: true},
+		requeueExpected:      true,
+		expectedRequeueAfter: 0,
+	}, {
+		currResult:           reconcile.Result{Requeue: true},
+		newResult:            reconcile.Result{RequeueAfter: 10 * time.Second},
+		requeueExpected:      true,
+		expectedRequeueAfter: 10 * time.Second,
 	}}
 
 	for _, tc := range testCases {
@@ -279,6 +302,136 @@ func TestAddNSSWrapper(t *testing.T) {
 	}
 }
 
+func TestAddTMPEmptyDir(t *testing.T) {
+
+	newTemplate := func() *v1.PodTemplateSpec {
+		return &v1.PodTemplateSpec{Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "init"}},
+			Containers:     []v1.Container{{Name: "database"}, {Name: "pgbackrest"}},
+		}}
+	}
+
+	t.Run("default", func(t *testing.T) {
+		template := newTemplate()
+		addTMPEmptyDir(template, nil)
+
+		assert.Equal(t, len(template.Spec.Volumes), 1)
+		assert.Equal(t, template.Spec.Volumes[0].Name, "tmp")
+		emptyDir := template.Spec.Volumes[0].EmptyDir
+		assert.Assert(t, emptyDir != nil)
+		assert.Equal(t, emptyDir.SizeLimit.String(), "16Mi")
+		assert.Equal(t, emptyDir.Medium, v1.StorageMediumDefault)
+
+		for _, c := range append(template.Spec.InitContainers, template.Spec.Containers...) {
+			assert.DeepEqual(t, c.VolumeMounts, []v1.VolumeMount{{Name: "tmp", MountPath: "/tmp"}})
+		}
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		sizeLimit := resource.MustParse("1Gi")
+		spec := &v1beta1.TmpVolumeSpec{
+			SizeLimit: &sizeLimit,
+			Medium:    v1.StorageMediumMemory,
+		}
+
+		template := newTemplate()
+		addTMPEmptyDir(template, spec)
+
+		assert.Equal(t, len(template.Spec.Volumes), 1)
+		emptyDir := template.Spec.Volumes[0].EmptyDir
+		assert.Assert(t, emptyDir != nil)
+		assert.Equal(t, emptyDir.SizeLimit.String(), "1Gi")
+		assert.Equal(t, emptyDir.Medium, v1.StorageMediumMemory)
+
+		// the spec is not modified through the template
+		emptyDir.SizeLimit.Add(resource.MustParse("1Gi"))
+		assert.Equal(t, spec.SizeLimit.String(), "1Gi")
+	})
+}
+
+func TestSupplementalGroups(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{}
+	assert.DeepEqual(t, supplementalGroups(cluster), []int64{65534})
+
+	cluster.Spec.SupplementalGroups = []int64{}
+	assert.DeepEqual(t, supplementalGroups(cluster), []int64{})
+
+	cluster.Spec.SupplementalGroups = []int64{1000, 2000}
+	groups := supplementalGroups(cluster)
+	assert.DeepEqual(t, groups, []int64{1000, 2000})
+
+	// the spec is not modified through the result
+	groups[0] = 3000
+	assert.DeepEqual(t, cluster.Spec.SupplementalGroups, []int64{1000, 2000})
+}
+
+func TestFSGroup(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{}
+	assert.DeepEqual(t, fsGroup(cluster), initialize.Int64(26))
+
+	// none is set by default on OpenShift
+	cluster.Spec.OpenShift = initialize.Bool(true)
+	assert.Assert(t, fsGroup(cluster) == nil)
+
+	// an FSGroup in the spec is always set
+	cluster.Spec.FSGroup = initialize.Int64(1000)
+	assert.DeepEqual(t, fsGroup(cluster), initialize.Int64(1000))
+
+	cluster.Spec.OpenShift = initialize.Bool(false)
+	assert.DeepEqual(t, fsGroup(cluster), initialize.Int64(1000))
+}
+
+func TestInvalidSupplementalGroup(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{}
+	_, invalid := invalidSupplementalGroup(cluster)
+	assert.Assert(t, !invalid)
+
+	cluster.Spec.SupplementalGroups = []int64{1, 65534, maxGroupID}
+	_, invalid = invalidSupplementalGroup(cluster)
+	assert.Assert(t, !invalid)
+
+	for _, group := range []int64{0, -1, maxGroupID + 1} {
+		cluster.Spec.SupplementalGroups = []int64{1000, group}
+		found, invalid := invalidSupplementalGroup(cluster)
+		assert.Assert(t, invalid)
+		assert.Equal(t, found, group)
+	}
+}
+
+func TestAddWaitForSecrets(t *testing.T) {
+
+	template := &v1.PodTemplateSpec{Spec: v1.PodSpec{
+		InitContainers: []v1.Container{{Name: "init"}},
+		Containers:     []v1.Container{{Name: "pgbackrest"}},
+	}}
+
+	addWaitForSecrets("test-image", template, nil)
+	assert.Equal(t, len(template.Spec.InitContainers), 1)
+	assert.Equal(t, len(template.Spec.Volumes), 0)
+
+	addWaitForSecrets("test-image", template, []string{"s3-creds", "ssh-keys"})
+	assert.Equal(t, len(template.Spec.InitContainers), 2)
+
+	wait := template.Spec.InitContainers[0]
+	assert.Equal(t, wait.Name, naming.ContainerWaitForSecrets)
+	assert.Equal(t, wait.Image, "test-image")
+	assert.Equal(t, template.Spec.InitContainers[1].Name, "init")
+
+	assert.Equal(t, len(template.Spec.Volumes), 2)
+	assert.Equal(t, len(wait.VolumeMounts), 2)
+	for i, name := range []string{"s3-creds", "ssh-keys"} {
+		volume := template.Spec.Volumes[i]
+		assert.Assert(t, volume.Secret != nil)
+		assert.Equal(t, volume.Secret.SecretName, name)
+		assert.Assert(t, volume.Secret.Optional != nil && !*volume.Secret.Optional)
+		assert.Equal(t, wait.VolumeMounts[i].Name, volume.Name)
+		assert.Equal(t, wait.VolumeMounts[i].MountPath, "/etc/wait-for-secrets/"+name)
+	}
+
+	// the main containers are untouched
+	assert.Equal(t, len(template.Spec.Containers[0].VolumeMounts), 0)
+}
+
 func TestJobCompleted(t *testing.T) {
 
 	testCases := []struct {
@@ -333,6 +486,25 @@ func TestJobCompleted(t *testing.T) {
 	}
 }
 
+func TestJobActive(t *testing.T) {
+
+	job := &batchv1.Job{Status: batchv1.JobStatus{Active: 1}}
+	assert.Equal(t, jobActive(job), int32(1))
+
+	// a Pod may linger once the Job is complete, e.g. due to a sidecar that has not exited
+	job.Status.Conditions = []batchv1.JobCondition{{
+		Type:   batchv1.JobComplete,
+		Status: v1.ConditionTrue,
+	}}
+	assert.Equal(t, jobActive(job), int32(0))
+
+	job.Status.Conditions = []batchv1.JobCondition{{
+		Type:   batchv1.JobFailed,
+		Status: v1.ConditionTrue,
+	}}
+	assert.Equal(t, jobActive(job), int32(0))
+}
+
 func TestJobFailed(t *testing.T) {
 
 	testCases := []struct {
@@ -20,6 +20,21 @@ const (
 
 	Finalizer = annotationPrefix + "finalizer"
 
+	// PGBackRestArchivedCluster is an annotation that is added to an archived repository volume
+	// (PVC) by the operator.  The value of the annotation is the name of the PostgresCluster the
+	// volume belonged to before it was archived.
+	PGBackRestArchivedCluster = annotationPrefix + "pgbackrest-archived-cluster"
+
+	// PGBackRestArchivedTime is an annotation that is added to an archived repository volume (PVC)
+	// by the operator.  The value of the annotation is the time (in RFC 3339 format) at which the
+	// volume was archived, e.g. to determine when it can be garbage collected.
+	PGBackRestArchivedTime = annotationPrefix + "pgbackrest-archived-time"
+
+	// PGBackRestAdoptRepoVolume is an annotation that is added to an existing repository volume
+	// (PVC) without a controller to have it adopted by a PostgresCluster.  The value of the
+	// annotation must be the name of the PostgresCluster adopting the volume.
+	PGBackRestAdoptRepoVolume = annotationPrefix + "pgbackrest-adopt-repo-volume"
+
 	// PGBackRestBackup is the annotation that is added to a PostgresCluster to initiate a manual
 	// backup.  The value of the annotation will be a unique identifier for a backup Job (e.g. a
 	// timestamp), which will be stored in the PostgresCluster status to properly track completion
@@ -27,11 +42,72 @@ const (
 	// ID associated with a specific manual backup Job.
 	PGBackRestBackup = annotationPrefix + "pgbackrest-backup"
 
+	// PGBackRestBackupAnnotationPrefix is the prefix of any annotations added to a PostgresCluster
+	// in order to annotate its pgBackRest backups, e.g. with the release of an application.  For
+	// each of these annotations, the remainder of the key and the value of the annotation are
+	// provided to every backup using the pgBackRest "--annotation" option, and are then displayed
+	// by "pgbackrest info" when viewing a specific backup set.  For example, the annotation
+	// "postgres-operator.crunchydata.com/pgbackrest-backup-annotation.release: v1.2.3" results
+	// in the "--annotation=release=v1.2.3" option.
+	PGBackRestBackupAnnotationPrefix = annotationPrefix + "pgbackrest-backup-annotation."
+
+	// PGBackRestBackupLock is an annotation that is added to a PostgresCluster by the operator
+	// while a pgBackRest backup Job is running, in order to ensure only one backup runs at a time
+	// for the cluster.  The value of the annotation is the name of the backup Job holding the
+	// lock, and the annotation is removed once that Job has finished.
+	PGBackRestBackupLock = annotationPrefix + "pgbackrest-backup-lock"
+
+	// PGBackRestBackupSet is an annotation that is added to a backup Job by the operator once the
+	// Job completes successfully.  The value of the annotation is the label of the pgBackRest
+	// backup set created by the Job (e.g. "20240101-120000F"), as reported by "pgbackrest info".
+	PGBackRestBackupSet = annotationPrefix + "pgbackrest-backup-set"
+
+	// PGBackRestBackupSize is an annotation that is added to a backup Job by the operator along
+	// with PGBackRestBackupSet.  The value of the annotation is the amount of data (in bytes)
+	// backed up from the database by the Job, as reported by "pgbackrest info".
+	PGBackRestBackupSize = annotationPrefix + "pgbackrest-backup-size"
+
+	// PGBackRestBackupSource is an annotation used to specify the name of the scheduled backup
+	// Job whose backup is also taken by a backup Job, as needed for repos that follow the
+	// schedules of another repo.
+	PGBackRestBackupSource = annotationPrefix + "pgbackrest-backup-source"
+
+	// PGBackRestBackupType is an annotation used to specify the type of backup (e.g. "full" or
+	// "incr") taken by a backup Job whose type is determined by the operator.  This allows the
+	// type to remain the same for the life of the Job, even as the backups within the repository
+	// change.
+	PGBackRestBackupType = annotationPrefix + "pgbackrest-backup-type"
+
 	// PGBackRestConfigHash is an annotation used to specify the hash value associated with a
 	// repo configuration as needed to detect configuration changes that invalidate running Jobs
 	// (and therefore must be recreated)
 	PGBackRestConfigHash = annotationPrefix + "pgbackrest-hash"
 
+	// PGBackRestFinalBackup is the annotation that is added to a PostgresCluster to initiate a final
+	// full backup, after which all scheduled backups are suspended.  The value of the annotation
+	// will be a unique identifier for the backup Job (e.g. a timestamp), which will be stored in
+	// the PostgresCluster status to properly track completion of the Job.  Removing the
+	// annotation resumes any scheduled backups.
+	PGBackRestFinalBackup = annotationPrefix + "pgbackrest-final-backup"
+
+	// PGBackRestExpire is the annotation that is added to a PostgresCluster to expire backups
+	// according to the configured retention without taking a new backup.  The value of the
+	// annotation will be a unique identifier for the expire Job (e.g. a timestamp), which will be
+	// stored in the PostgresCluster status to properly track completion of the Job.
+	PGBackRestExpire = annotationPrefix + "pgbackrest-expire"
+
+	// PGBackRestConfirmRepoRemoval is an annotation that is added to a PostgresCluster to confirm
+	// that the volumes (PVCs) of repos removed from the spec can be deleted even though no repo
+	// remaining in the spec has a stanza, i.e. even though the cluster is left without any
+	// backups.  The value of the annotation is ignored.
+	PGBackRestConfirmRepoRemoval = annotationPrefix + "pgbackrest-confirm-repo-removal"
+
+	// PGBackRestDryRun is an annotation that is added to a PostgresCluster to reconcile pgBackRest
+	// in dry-run mode.  While present, the pgBackRest resources that cleanup would delete,
+	// archive or retain are reported rather than changed, and no other pgBackRest resources are
+	// reconciled (nor reported).  The value of the annotation is ignored.
+	PGBackRestDryRun = annotationPrefix + "pgbackrest-dry-run"
+
 	// PGBackRestCurrentConfig is an annotation used to indicate the name of the pgBackRest
 	// configuration associated with a specific Job as determined by either the current primary
 	// (if no dedicated repository host is enabled), or the dedicated repository host.  This helps
@@ -40,6 +116,19 @@ const (
 	// enabled or disabled.
 	PGBackRestCurrentConfig = annotationPrefix + "pgbackrest-config"
 
+	// PGBackRestStanzaCreate is the annotation that is added to a PostgresCluster to force the
+	// pgBackRest stanza-create command to run on the next reconcile, e.g. to immediately retry
+	// after fixing custom pgBackRest configuration.  The value of the annotation will be a unique
+	// identifier (e.g. a timestamp), which will be stored in the PostgresCluster status once the
+	// stanzas are created successfully.
+	PGBackRestStanzaCreate = annotationPrefix + "pgbackrest-stanza-create"
+
+	// PGBackRestStanzaCreateExclude is the annotation that is added to a PostgresCluster to
+	// temporarily exclude repos from stanza creation, e.g. while a cloud repo is unreachable
+	// during an outage.  The value of the annotation is a comma-separated list of repo names
+	// (e.g. "repo2,repo3").
+	PGBackRestStanzaCreateExclude = annotationPrefix + "pgbackrest-stanza-create-exclude"
+
 	// PGBackRestRestore is the annotation that is added to a PostgresCluster to initiate an in-place
 	// restore.  The value of the annotation will be a unique identfier for a restore Job (e.g. a
 	// timestamp), which will be stored in the PostgresCluster status to properly track completion
@@ -24,8 +24,22 @@ import (
 
 func TestAnnotationsValid(t *testing.T) {
 	assert.Assert(t, nil == validation.IsQualifiedName(Finalizer))
+	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestAdoptRepoVolume))
+	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestArchivedCluster))
+	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestArchivedTime))
 	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestBackup))
+	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestBackupAnnotationPrefix+"release"))
+	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestBackupLock))
+	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestBackupSet))
+	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestBackupSize))
+	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestBackupSource))
+	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestBackupType))
 	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestConfigHash))
+	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestConfirmRepoRemoval))
 	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestCurrentConfig))
+	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestExpire))
+	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestFinalBackup))
 	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestRestore))
+	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestStanzaCreate))
+	assert.Assert(t, nil == validation.IsQualifiedName(PGBackRestStanzaCreateExclude))
 }
@@ -38,9 +38,21 @@ const (
 	// LabelPGBackRest is used to indicate that a resource is for pgBackRest
 	LabelPGBackRest = labelPrefix + "pgbackrest"
 
+	// LabelPGBackRestArchived is used to indicate that a repository volume (PVC) was archived,
+	// rather than deleted, after its repository was removed from a PostgresCluster
+	LabelPGBackRestArchived = labelPrefix + "pgbackrest-archived"
+
 	// LabelPGBackRestBackup is used to indicate that a resource is for a pgBackRest backup
 	LabelPGBackRestBackup = labelPrefix + "pgbackrest-backup"
 
+	// LabelPGBackRestBackupType is used to indicate the type of backup (i.e. "full", "diff" or
+	// "incr") taken by a pgBackRest backup Job, when that type is known
+	LabelPGBackRestBackupType = labelPrefix + "pgbackrest-backup-type"
+
+	// LabelPGBackRestCheck is used to indicate that a resource is for the scheduled pgBackRest
+	// "check" command
+	LabelPGBackRestCheck = labelPrefix + "pgbackrest-check"
+
 	// LabelPGBackRestConfig is used to indicate that a ConfigMap is for pgBackRest
 	LabelPGBackRestConfig = labelPrefix + "pgbackrest-config"
 
@@ -113,6 +125,18 @@ const (
 	// BackupReplicaCreate is the backup type for the backup taken to enable pgBackRest replica
 	// creation
 	BackupReplicaCreate BackupJobType = "replica-create"
+
+	// BackupFinal is the backup type for the final backup taken prior to suspending all
+	// scheduled backups
+	BackupFinal BackupJobType = "final"
+
+	// BackupScheduled is the backup type for backups taken by the pgBackRest scheduled backup
+	// CronJobs
+	BackupScheduled BackupJobType = "scheduled"
+
+	// BackupExpire is the type for Jobs that only expire backups according to the configured
+	// retention, without taking a new backup
+	BackupExpire BackupJobType = "expire"
 )
 
 // Merge takes sets of labels and merges them. The last set
@@ -217,6 +241,15 @@ func PGBackRestCronJobLabels(clusterName, repoName, backupType string) labels.Se
 	return labels.Merge(commonLabels, cronJobLabels)
 }
 
+// PGBackRestCheckLabels provides labels for the pgBackRest check CronJob and the Jobs it creates
+func PGBackRestCheckLabels(clusterName string) labels.Set {
+	commonLabels := PGBackRestLabels(clusterName)
+	checkLabels := map[string]string{
+		LabelPGBackRestCheck: "",
+	}
+	return labels.Merge(commonLabels, checkLabels)
+}
+
 // PGBackRestDedicatedLabels provides labels for a pgBackRest dedicated repository host
 func PGBackRestDedicatedLabels(clusterName string) labels.Set {
 	repoLabels := PGBackRestRepoHostLabels(clusterName)
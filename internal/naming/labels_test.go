@@ -30,7 +30,10 @@ func TestLabelsValid(t *testing.T) {
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPatroni))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelRole))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRest))
+	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestArchived))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestBackup))
+	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestBackupType))
+	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestCheck))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestConfig))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestDedicated))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestRepo))
@@ -51,6 +54,8 @@ func TestLabelValuesValid(t *testing.T) {
 	assert.Assert(t, nil == validation.IsDNS1123Label(RolePrimary))
 	assert.Assert(t, nil == validation.IsDNS1123Label(RoleReplica))
 	assert.Assert(t, nil == validation.IsDNS1123Label(string(BackupReplicaCreate)))
+	assert.Assert(t, nil == validation.IsDNS1123Label(string(BackupScheduled)))
+	assert.Assert(t, nil == validation.IsDNS1123Label(string(BackupFinal)))
 	assert.Assert(t, nil == validation.IsDNS1123Label(RoleMonitoring))
 }
 
@@ -181,6 +186,12 @@ func TestPGBackRestLabelFuncs(t *testing.T) {
 	assert.Check(t, pgBackRestCronJobLabels.Has(LabelPGBackRest))
 	assert.Equal(t, pgBackRestCronJobLabels.Get(LabelPGBackRestRepo), repoName)
 
+	// verify the labels that identify the pgBackRest check CronJob
+	pgBackRestCheckLabels := PGBackRestCheckLabels(clusterName)
+	assert.Equal(t, pgBackRestCheckLabels.Get(LabelCluster), clusterName)
+	assert.Check(t, pgBackRestCheckLabels.Has(LabelPGBackRest))
+	assert.Check(t, pgBackRestCheckLabels.Has(LabelPGBackRestCheck))
+
 	// verify the labels that identify pgBackRest dedicated repository host resources
 	pgBackRestDedicatedLabels := PGBackRestDedicatedLabels(clusterName)
 	assert.Equal(t, pgBackRestDedicatedLabels.Get(LabelCluster), clusterName)
@@ -50,6 +50,9 @@ const (
 	// ContainerNSSWrapperInit is the name of the init container utilized to configure support
 	// for the nss_wrapper
 	ContainerNSSWrapperInit = "nss-wrapper-init"
+	// ContainerWaitForSecrets is the name of the init container that prevents a Pod from starting
+	// until the Secrets it depends on exist
+	ContainerWaitForSecrets = "wait-for-secrets"
 
 	// ContainerPGMonitorExporter is the name of a container running postgres_exporter
 	ContainerPGMonitorExporter = "exporter"
@@ -57,6 +60,7 @@ const (
 
 const (
 	PortExporter   = "exporter"
+	PortMetrics    = "metrics"
 	PortPGBouncer  = "pgbouncer"
 	PortPostgreSQL = "postgres"
 )
@@ -122,6 +126,10 @@ const (
 	// restores
 	PGBackRestRestoreContainerName = "pgbackrest-restore"
 
+	// PGBackRestVerifyContainerName is the name assigned to the container used to verify a
+	// pgBackRest backup once it has completed
+	PGBackRestVerifyContainerName = "pgbackrest-verify"
+
 	// PGBackRestRepoName is the name used for a pgbackrest repository
 	PGBackRestRepoName = "%s-pgbackrest-repo-%s"
 
@@ -333,6 +341,15 @@ func PGBackRestCronJob(cluster *v1beta1.PostgresCluster, backuptype, repoName st
 	}
 }
 
+// PGBackRestCheckCronJob returns the ObjectMeta for the CronJob that runs the pgBackRest
+// "check" command on a schedule
+func PGBackRestCheckCronJob(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.GetNamespace(),
+		Name:      cluster.Name + "-pgbackrest-check",
+	}
+}
+
 // PGBackRestRestoreJob returns the ObjectMeta for a pgBackRest restore Job
 func PGBackRestRestoreJob(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
@@ -359,6 +376,15 @@ func PGBackRestRepoVolume(cluster *v1beta1.PostgresCluster,
 	}
 }
 
+// PGBackRestRepoHostMetrics returns the ObjectMeta for the Service and ServiceMonitor that
+// expose the metrics of a pgBackRest dedicated repository host
+func PGBackRestRepoHostMetrics(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      cluster.GetName() + "-repo-host-metrics",
+		Namespace: cluster.GetNamespace(),
+	}
+}
+
 // PGBackRestSSHConfig returns the ObjectMeta for a pgBackRest SSHD ConfigMap
 func PGBackRestSSHConfig(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
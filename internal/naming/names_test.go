@@ -100,6 +100,7 @@ func TestClusterNamesUniqueAndValid(t *testing.T) {
 			{"PGBackRestCronJon", PGBackRestCronJob(cluster, "incr", "repo2")},
 			{"PGBackRestCronJon", PGBackRestCronJob(cluster, "diff", "repo3")},
 			{"PGBackRestCronJon", PGBackRestCronJob(cluster, "full", "repo4")},
+			{"PGBackRestCheckCronJob", PGBackRestCheckCronJob(cluster)},
 		})
 	})
 
@@ -153,6 +154,7 @@ func TestClusterNamesUniqueAndValid(t *testing.T) {
 			{"ClusterPGBouncer", ClusterPGBouncer(cluster)},
 			{"ClusterPodService", ClusterPodService(cluster)},
 			{"ClusterPrimaryService", ClusterPrimaryService(cluster)},
+			{"PGBackRestRepoHostMetrics", PGBackRestRepoHostMetrics(cluster)},
 			// Patroni can use Endpoints which relate directly to a Service.
 			{"PatroniDistributedConfiguration", PatroniDistributedConfiguration(cluster)},
 			{"PatroniLeaderEndpoints", PatroniLeaderEndpoints(cluster)},
@@ -184,6 +184,17 @@ CREATE DATABASE :"dbname";
 GRANT ALL PRIVILEGES ON DATABASE :"dbname" TO :"user";
 `
 
+		// When the data of the cluster is restored from the backups of another cluster (e.g.
+		// when cloning), remove the password of the default user of that cluster so that this
+		// cluster never accepts the credentials of its source.  The default user of this
+		// cluster is given its own password above.
+		var sourceUserVar string
+		if sourceUser := dataSourceUser(cluster); sourceUser != "" &&
+			sourceUser != string(pgUser.Data["user"]) {
+			sql += "ALTER ROLE :\"source_user\" PASSWORD NULL;\n"
+			sourceUserVar = " --set=source_user=" + quoteShellWord(sourceUser)
+		}
+
 		root["bootstrap"] = map[string]interface{}{
 			"dcs": DynamicConfiguration(cluster, configuration, pgHBAs, pgParameters),
 
@@ -203,6 +214,7 @@ GRANT ALL PRIVILEGES ON DATABASE :"dbname" TO :"user";
 				" --set=ON_ERROR_STOP=0"+
 				" --set=dbname="+quoteShellWord(string(pgUser.Data["dbname"]))+
 				" --set=password="+quoteShellWord(string(pgUser.Data["verifier"]))+
+				sourceUserVar+
 				" --set=user="+quoteShellWord(string(pgUser.Data["user"]))+
 				" --file=- <<< "+quoteShellWord(sql),
 			),
@@ -217,6 +229,32 @@ GRANT ALL PRIVILEGES ON DATABASE :"dbname" TO :"user";
 	return string(append([]byte(yamlGeneratedWarning), b...)), err
 }
 
+// dataSourceUser returns the name of the default user of the PostgresCluster that the data of
+// the cluster was most recently restored from, if any.  This is the cluster defined by the
+// in-place restore in the spec when that restore was the last one performed, and otherwise
+// the cluster defined by the data source used to bootstrap the cluster.
+func dataSourceUser(cluster *v1beta1.PostgresCluster) string {
+	if cluster.Status.PGBackRest == nil || cluster.Status.PGBackRest.Restore == nil {
+		return ""
+	}
+
+	var source *v1beta1.PostgresClusterDataSource
+	restore := cluster.Spec.Backups.PGBackRest.Restore
+	if restore != nil && cluster.Status.PGBackRest.Restore.ID != "" &&
+		cluster.Status.PGBackRest.Restore.ID == cluster.GetAnnotations()[naming.PGBackRestRestore] {
+		source = restore.PostgresClusterDataSource
+	} else if cluster.Spec.DataSource != nil {
+		source = cluster.Spec.DataSource.PostgresCluster
+	}
+
+	// the default user of a cluster is named after the cluster, and the data source defaults
+	// to the cluster itself
+	if source == nil || source.ClusterName == "" {
+		return cluster.Name
+	}
+	return source.ClusterName
+}
+
 // DynamicConfiguration combines configuration with some PostgreSQL settings
 // and returns a value that can be marshaled to JSON.
 func DynamicConfiguration(
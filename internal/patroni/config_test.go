@@ -27,6 +27,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/postgres"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
@@ -104,6 +106,76 @@ watchdog:
 	`)+"\n")
 }
 
+func TestClusterYAMLClone(t *testing.T) {
+	t.Parallel()
+
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Default()
+	cluster.Name = "staging"
+	cluster.Spec.DataSource = &v1beta1.DataSource{
+		PostgresCluster: &v1beta1.PostgresClusterDataSource{
+			ClusterName: "production", RepoName: "repo1",
+		},
+	}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Restore: &v1beta1.PGBackRestRestoreStatus{PGBackRestJobStatus: v1beta1.PGBackRestJobStatus{
+			ID: "~pgo-bootstrap-staging", Finished: true,
+		}},
+	}
+
+	pgUser := new(v1.Secret)
+	pgUser.Data = map[string][]byte{
+		"dbname":   []byte("staging"),
+		"user":     []byte("staging"),
+		"verifier": []byte("digest$and==:stuff"),
+	}
+
+	// the password of the default user of the source cluster is removed during bootstrap
+	data, err := clusterYAML(cluster, pgUser, postgres.HBAs{}, postgres.Parameters{})
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(data, `--set=source_user='"'"'production'"'"'`), "\n%s", data)
+	assert.Assert(t, strings.Contains(data, `ALTER ROLE :"source_user" PASSWORD NULL;`))
+
+	// nothing changes when bootstrapping from the backups of the cluster itself
+	cluster.Spec.DataSource.PostgresCluster.ClusterName = ""
+	data, err = clusterYAML(cluster, pgUser, postgres.HBAs{}, postgres.Parameters{})
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(data, "source_user"))
+}
+
+func TestDataSourceUser(t *testing.T) {
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Name = "hippo"
+	assert.Equal(t, dataSourceUser(cluster), "")
+
+	// bootstrapped from the data source in the spec
+	cluster.Spec.DataSource = &v1beta1.DataSource{
+		PostgresCluster: &v1beta1.PostgresClusterDataSource{ClusterName: "rhino"},
+	}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Restore: &v1beta1.PGBackRestRestoreStatus{PGBackRestJobStatus: v1beta1.PGBackRestJobStatus{
+			ID: "~pgo-bootstrap-hippo",
+		}},
+	}
+	assert.Equal(t, dataSourceUser(cluster), "rhino")
+
+	// restored in place from the data source of the requested restore
+	cluster.Annotations = map[string]string{naming.PGBackRestRestore: "one"}
+	cluster.Spec.Backups.PGBackRest.Restore = &v1beta1.PGBackRestRestore{
+		Enabled: initialize.Bool(true),
+		PostgresClusterDataSource: &v1beta1.PostgresClusterDataSource{
+			ClusterName: "elephant",
+		},
+	}
+	assert.Equal(t, dataSourceUser(cluster), "rhino")
+
+	cluster.Status.PGBackRest.Restore.ID = "one"
+	assert.Equal(t, dataSourceUser(cluster), "elephant")
+
+	cluster.Spec.Backups.PGBackRest.Restore.ClusterName = ""
+	assert.Equal(t, dataSourceUser(cluster), "hippo")
+}
+
 func TestDynamicConfiguration(t *testing.T) {
 	t.Parallel()
 
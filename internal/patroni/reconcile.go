@@ -17,6 +17,7 @@ package patroni
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -39,6 +40,23 @@ func ClusterBootstrapped(postgresCluster *v1beta1.PostgresCluster) bool {
 		postgresCluster.Status.Patroni.SystemIdentifier != "")
 }
 
+// ClusterPaused returns a bool indicating whether or not Patroni is paused (i.e. in maintenance
+// mode) according to the dynamic configuration in the spec of the PostgresCluster.  While paused,
+// Patroni neither starts, stops nor fails over PostgreSQL.
+// - https://github.com/zalando/patroni/blob/v2.0.2/docs/pause.rst
+func ClusterPaused(postgresCluster *v1beta1.PostgresCluster) bool {
+	if postgresCluster.Spec.Patroni == nil {
+		return false
+	}
+
+	// There will be no error because the Kubernetes API has already ensured it is a JSON object.
+	var configuration struct {
+		Pause bool `json:"pause"`
+	}
+	_ = json.Unmarshal(postgresCluster.Spec.Patroni.DynamicConfiguration.Raw, &configuration)
+	return configuration.Pause
+}
+
 // ClusterConfigMap populates the shared ConfigMap with fields needed to run Patroni.
 func ClusterConfigMap(ctx context.Context,
 	inCluster *v1beta1.PostgresCluster,
@@ -30,6 +30,20 @@ import (
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
+func TestClusterPaused(t *testing.T) {
+	cluster := new(v1beta1.PostgresCluster)
+	assert.Assert(t, !ClusterPaused(cluster))
+
+	cluster.Spec.Patroni = new(v1beta1.PatroniSpec)
+	assert.Assert(t, !ClusterPaused(cluster))
+
+	cluster.Spec.Patroni.DynamicConfiguration.Raw = []byte(`{"loop_wait":10}`)
+	assert.Assert(t, !ClusterPaused(cluster))
+
+	cluster.Spec.Patroni.DynamicConfiguration.Raw = []byte(`{"pause":true}`)
+	assert.Assert(t, ClusterPaused(cluster))
+}
+
 func TestClusterConfigMap(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
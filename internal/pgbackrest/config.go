@@ -41,8 +41,11 @@ const (
 	DefaultStanzaName = "db"
 
 	// configmap key references
-	cmJobKey     = "pgbackrest_job.conf"
 	cmPrimaryKey = "pgbackrest_primary.conf"
+	// CMJobKey is the name of the configuration file for pgBackRest Jobs (e.g. backups) when
+	// a dedicated repository host is not enabled.  Unlike the configuration of each instance, it
+	// does not depend on which instance is the primary.
+	CMJobKey = "pgbackrest_job.conf"
 	// CMRepoKey is the name of the configuration file for a pgBackRest dedicated repository host
 	CMRepoKey = "pgbackrest_repo.conf"
 
@@ -95,7 +98,7 @@ func CreatePGBackRestConfigMapIntent(postgresCluster *v1beta1.PostgresCluster,
 	addDedicatedHost := (postgresCluster.Spec.Backups.PGBackRest.RepoHost != nil) &&
 		(postgresCluster.Spec.Backups.PGBackRest.RepoHost.Dedicated != nil)
 
-	pgdataDir := postgres.DataDirectory(postgresCluster)
+	pgdataDir := PGPath(postgresCluster)
 	// Port will always be populated, since the API will set a default of 5432 if not provided
 	pgPort := *postgresCluster.Spec.Port
 	for i, name := range instanceNames {
@@ -112,6 +115,17 @@ func CreatePGBackRestConfigMapIntent(postgresCluster *v1beta1.PostgresCluster,
 				postgresCluster.Spec.Backups.PGBackRest.Global))
 	}
 
+	// Without a dedicated repo host, pgBackRest Jobs run their commands within the current
+	// primary, where PostgreSQL is always local.  Their configuration therefore only needs to
+	// reference the local instance, and is the same regardless of which instance is primary.
+	if !addDedicatedHost {
+		cm.Data[CMJobKey] = getConfigString(
+			populatePGInstanceConfigurationMap(serviceName, serviceNamespace, repoHostName,
+				pgdataDir, pgPort, nil,
+				postgresCluster.Spec.Backups.PGBackRest.Repos,
+				postgresCluster.Spec.Backups.PGBackRest.Global))
+	}
+
 	if addDedicatedHost && repoHostName != "" {
 		cm.Data[CMRepoKey] = getConfigString(
 			populateRepoHostConfigurationMap(serviceName, serviceNamespace,
@@ -183,7 +197,7 @@ func RepositoryConfigVolumeAndMount(pgBackRestConfigMap *v1.ConfigMap, pod *v1.P
 // JobConfigVolumeAndMount creates a volume and mount configuration from the pgBackRest configmap to be used by the
 // postgrescluster's job pods
 func JobConfigVolumeAndMount(pgBackRestConfigMap *v1.ConfigMap, pod *v1.PodSpec, containerName string) {
-	configVolumeAndMount(pgBackRestConfigMap, pod, containerName, cmJobKey)
+	configVolumeAndMount(pgBackRestConfigMap, pod, containerName, CMJobKey)
 }
 
 // RestoreCommand returns the command for performing a pgBackRest restore.  In addition to calling
@@ -214,6 +228,16 @@ mv "${pgdata}" "${pgdata}_bootstrap"`
 	return append([]string{"bash", "-ceu", "--", restoreScript, "-", pgdata}, args...)
 }
 
+// PGPath returns the path of the PostgreSQL data directory used by pgBackRest, i.e. the value of
+// the "pg-path" option of each PostgreSQL host, as defined in the spec.  Defaults to the data
+// directory of the PostgreSQL version defined in the spec.
+func PGPath(postgresCluster *v1beta1.PostgresCluster) string {
+	if path := postgresCluster.Spec.Backups.PGBackRest.PGPath; path != "" {
+		return path
+	}
+	return postgres.DataDirectory(postgresCluster)
+}
+
 // populatePGInstanceConfigurationMap returns a map representing the pgBackRest configuration for
 // a PostgreSQL instance
 func populatePGInstanceConfigurationMap(serviceName, serviceNamespace, repoHostName, pgdataDir string,
@@ -250,11 +274,14 @@ func populatePGInstanceConfigurationMap(serviceName, serviceNamespace, repoHostN
 				naming.KubernetesClusterDomain(context.Background())
 			pgBackRestConfig["global"][repo.Name+"-host-user"] = "postgres"
 		}
-		pgBackRestConfig["global"][repo.Name+"-path"] = defaultRepo1Path + repo.Name
+		pgBackRestConfig["global"][repo.Name+"-path"] = repoPath(repo)
 
 		for option, val := range repoConfigs {
 			pgBackRestConfig["global"][option] = val
 		}
+		for option, val := range getRetentionConfigs(repo) {
+			pgBackRestConfig["global"][option] = val
+		}
 	}
 
 	for option, val := range globalConfig {
@@ -312,11 +339,14 @@ func populateRepoHostConfigurationMap(serviceName, serviceNamespace, pgdataDir s
 		if repo.Volume == nil {
 			repoConfigs = getExternalRepoConfigs(repo)
 		}
-		pgBackRestConfig["global"][repo.Name+"-path"] = defaultRepo1Path + repo.Name
+		pgBackRestConfig["global"][repo.Name+"-path"] = repoPath(repo)
 
 		for option, val := range repoConfigs {
 			pgBackRestConfig["global"][option] = val
 		}
+		for option, val := range getRetentionConfigs(repo) {
+			pgBackRestConfig["global"][option] = val
+		}
 	}
 
 	for option, val := range globalConfig {
@@ -358,6 +388,16 @@ func getConfigString(c map[string]map[string]string) string {
 	return configString
 }
 
+// repoPath returns the path for the pgBackRest repository provided.  The path configured in the
+// spec is used for external repositories (e.g. to share a bucket between clusters), while all
+// other repositories use the default path.
+func repoPath(repo v1beta1.PGBackRestRepo) string {
+	if repo.Volume == nil && repo.Path != "" {
+		return repo.Path
+	}
+	return defaultRepo1Path + repo.Name
+}
+
 // getExternalRepoConfigs returns a map containing the configuration settings for an external
 // pgBackRest repository as defined in the PostgresCluster spec
 func getExternalRepoConfigs(repo v1beta1.PGBackRestRepo) map[string]string {
@@ -380,6 +420,30 @@ func getExternalRepoConfigs(repo v1beta1.PGBackRestRepo) map[string]string {
 	return repoConfigs
 }
 
+// getRetentionConfigs returns a map containing the retention settings for a pgBackRest
+// repository as defined in the PostgresCluster spec, if any
+func getRetentionConfigs(repo v1beta1.PGBackRestRepo) map[string]string {
+
+	retentionConfigs := make(map[string]string)
+
+	if retention := repo.Retention; retention != nil {
+		if retention.Full != nil {
+			retentionConfigs[repo.Name+"-retention-full"] = fmt.Sprint(*retention.Full)
+		}
+		if retention.FullType != "" {
+			retentionConfigs[repo.Name+"-retention-full-type"] = retention.FullType
+		}
+		if retention.Diff != nil {
+			retentionConfigs[repo.Name+"-retention-diff"] = fmt.Sprint(*retention.Diff)
+		}
+		if retention.Archive != nil {
+			retentionConfigs[repo.Name+"-retention-archive"] = fmt.Sprint(*retention.Archive)
+		}
+	}
+
+	return retentionConfigs
+}
+
 // sortedKeys sorts and returns the keys from a given map
 func sortedKeys(m map[string]string) []string {
 	keys := make([]string, 0, len(m))
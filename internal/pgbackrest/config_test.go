@@ -302,6 +302,69 @@ pg1-socket-path=/tmp/postgres
 	})
 }
 
+func TestCreatePGBackRestConfigMapIntentJobConfig(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.PostgresVersion = 13
+	cluster.Spec.Port = initialize.Int32(5432)
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+		Name:   "repo1",
+		Volume: &v1beta1.RepoPVC{},
+	}}
+	cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{}
+
+	instances := []string{"hippo-instance1-abcd", "hippo-instance1-efgh"}
+	cm := CreatePGBackRestConfigMapIntent(cluster, "hippo-repo-host", "hash",
+		"hippo-pods", "ns", instances)
+
+	// the configuration of each instance references the other instances, while the shared
+	// configuration for Jobs only references the local instance
+	assert.Assert(t, strings.Contains(cm.Data["hippo-instance1-abcd.conf"], "pg2-host="))
+	assert.Assert(t, strings.Contains(cm.Data[CMJobKey], "pg1-path="))
+	assert.Assert(t, !strings.Contains(cm.Data[CMJobKey], "pg2-host="))
+	assert.Assert(t, !strings.Contains(cm.Data[CMJobKey], "hippo-instance1"))
+
+	// the Jobs of a dedicated repo host use its configuration instead
+	cluster.Spec.Backups.PGBackRest.RepoHost.Dedicated = &v1beta1.DedicatedRepo{}
+	cm = CreatePGBackRestConfigMapIntent(cluster, "hippo-repo-host", "hash",
+		"hippo-pods", "ns", instances)
+	_, found := cm.Data[CMJobKey]
+	assert.Assert(t, !found)
+}
+
+func TestPGPath(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.PostgresVersion = 13
+	cluster.Spec.Port = initialize.Int32(5432)
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+		Name:   "repo1",
+		Volume: &v1beta1.RepoPVC{},
+	}}
+	cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{
+		Dedicated: &v1beta1.DedicatedRepo{},
+	}
+
+	assert.Equal(t, PGPath(cluster), "/pgdata/pg13")
+
+	// a custom path is used by every PostgreSQL host in the generated configuration
+	cluster.Spec.Backups.PGBackRest.PGPath = "/var/lib/postgresql/data"
+	assert.Equal(t, PGPath(cluster), "/var/lib/postgresql/data")
+
+	instances := []string{"hippo-instance1-abcd", "hippo-instance1-efgh"}
+	cm := CreatePGBackRestConfigMapIntent(cluster, "hippo-repo-host", "hash",
+		"hippo-pods", "ns", instances)
+	assert.Assert(t, strings.Contains(cm.Data["hippo-instance1-abcd.conf"],
+		"pg1-path=/var/lib/postgresql/data\n"))
+	assert.Assert(t, strings.Contains(cm.Data[CMRepoKey],
+		"pg1-path=/var/lib/postgresql/data\n"))
+	assert.Assert(t, strings.Contains(cm.Data[CMRepoKey],
+		"pg2-path=/var/lib/postgresql/data\n"))
+	assert.Assert(t, !strings.Contains(cm.Data[CMRepoKey], "/pgdata/pg13"))
+}
+
 func TestRestoreCommand(t *testing.T) {
 	shellcheck, err := exec.LookPath("shellcheck")
 	if err != nil {
@@ -329,3 +392,56 @@ func TestRestoreCommand(t *testing.T) {
 	output, err := cmd.CombinedOutput()
 	assert.NilError(t, err, "%q\n%s", cmd.Args, output)
 }
+
+func TestRepoPath(t *testing.T) {
+	assert.Equal(t, repoPath(v1beta1.PGBackRestRepo{Name: "repo1"}), "/pgbackrest/repo1")
+
+	assert.Equal(t, repoPath(v1beta1.PGBackRestRepo{
+		Name: "repo2", Path: "/hippo/repo2", S3: &v1beta1.RepoS3{Bucket: "shared"},
+	}), "/hippo/repo2")
+
+	// a path is never used for volume repos, which are always mounted at the default path
+	assert.Equal(t, repoPath(v1beta1.PGBackRestRepo{
+		Name: "repo3", Path: "/hippo/repo3", Volume: &v1beta1.RepoPVC{},
+	}), "/pgbackrest/repo3")
+}
+
+func TestCreatePGBackRestConfigMapIntentRetention(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+	cluster.Spec.PostgresVersion = 13
+	cluster.Spec.Port = initialize.Int32(5432)
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+		Name:   "repo1",
+		Volume: &v1beta1.RepoPVC{},
+		Retention: &v1beta1.RepoRetention{
+			Full: initialize.Int32(14), FullType: "time", Archive: initialize.Int32(2),
+		},
+	}, {
+		Name:      "repo2",
+		S3:        &v1beta1.RepoS3{Bucket: "bucket", Endpoint: "endpoint", Region: "region"},
+		Retention: &v1beta1.RepoRetention{Full: initialize.Int32(2), Diff: initialize.Int32(4)},
+	}}
+	cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{}
+
+	instances := []string{"hippo-instance1-abcd"}
+	cm := CreatePGBackRestConfigMapIntent(cluster, "hippo-repo-host", "hash",
+		"hippo-pods", "ns", instances)
+
+	for _, key := range []string{"hippo-instance1-abcd.conf", CMJobKey} {
+		assert.Assert(t, strings.Contains(cm.Data[key], "repo1-retention-archive=2\n"), key)
+		assert.Assert(t, strings.Contains(cm.Data[key], "repo1-retention-full=14\n"), key)
+		assert.Assert(t, strings.Contains(cm.Data[key], "repo1-retention-full-type=time\n"), key)
+		assert.Assert(t, strings.Contains(cm.Data[key], "repo2-retention-diff=4\n"), key)
+		assert.Assert(t, strings.Contains(cm.Data[key], "repo2-retention-full=2\n"), key)
+		assert.Assert(t, !strings.Contains(cm.Data[key], "repo2-retention-archive"), key)
+	}
+
+	// the configuration of a dedicated repo host includes retention as well
+	cluster.Spec.Backups.PGBackRest.RepoHost.Dedicated = &v1beta1.DedicatedRepo{}
+	cm = CreatePGBackRestConfigMapIntent(cluster, "hippo-repo-host", "hash",
+		"hippo-pods", "ns", instances)
+	assert.Assert(t, strings.Contains(cm.Data[CMRepoKey], "repo1-retention-full=14\n"))
+	assert.Assert(t, strings.Contains(cm.Data[CMRepoKey], "repo2-retention-diff=4\n"))
+}
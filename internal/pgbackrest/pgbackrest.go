@@ -18,18 +18,106 @@ package pgbackrest
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 const (
 	// errMsgConfigHashMismatch is the error message displayed when a configuration hash mismatch
 	// is detected while attempting stanza creation
 	errMsgConfigHashMismatch = "postgres operator error: pgBackRest config hash mismatch"
+
+	// RestoreProgressRecovering is the restore progress reported once all files have been
+	// restored and PostgreSQL is replaying WAL
+	RestoreProgressRecovering = "Recovering"
+
+	// maxOutputTail is the maximum number of bytes of output returned by CommandError.Tail
+	maxOutputTail = 1024
 )
 
+// CommandError is returned when a pgBackRest command run by an Executor fails.  It retains the
+// output of the command so that the end of it can be reported, e.g. in status conditions.
+type CommandError struct {
+	Err    error
+	Stdout string
+	Stderr string
+}
+
+// commandError returns a CommandError for err and the output captured in stdout and stderr.
+func commandError(err error, stdout, stderr *bytes.Buffer) *CommandError {
+	return &CommandError{Err: err, Stdout: stdout.String(), Stderr: stderr.String()}
+}
+
+// Error returns the error of the command followed by its stderr.
+func (e *CommandError) Error() string { return fmt.Sprintf("%v: %v", e.Err, e.Stderr) }
+
+// Unwrap returns the error of the command.
+func (e *CommandError) Unwrap() error { return e.Err }
+
+// Tail returns the end of the output of the command, i.e. stderr or stdout when there is nothing
+// in stderr, limited to maxOutputTail bytes.  Whenever the output is truncated, it starts at the
+// beginning of a line if possible.
+func (e *CommandError) Tail() string {
+	output := strings.TrimSpace(e.Stderr)
+	if output == "" {
+		output = strings.TrimSpace(e.Stdout)
+	}
+	if len(output) > maxOutputTail {
+		output = output[len(output)-maxOutputTail:]
+		if i := strings.IndexByte(output, '\n'); i >= 0 && i < len(output)-1 {
+			output = output[i+1:]
+		}
+	}
+	return output
+}
+
+// stanzaInfo is the subset of the JSON output of the pgBackRest "info" command used by the
+// PostgreSQL Operator for each stanza.
+// - https://pgbackrest.org/command.html#command-info
+type stanzaInfo struct {
+	Name   string
+	Backup []struct {
+		Database struct {
+			RepoKey int `json:"repo-key"`
+		}
+		Info      struct{ Delta int64 }
+		Label     string
+		Timestamp struct{ Start, Stop int64 }
+		Type      string
+	}
+	DB []struct {
+		ID       int    `json:"id"`
+		RepoKey  int    `json:"repo-key"`
+		SystemID uint64 `json:"system-id"`
+	}
+	Repo []struct {
+		Key    int
+		Status struct{ Code int }
+	}
+}
+
+// BackupSet describes a backup set within a pgBackRest repository as reported by the pgBackRest
+// "info" command.
+type BackupSet struct {
+	// The label of the backup set, e.g. "20240101-120000F"
+	Label string
+	// The index of the repository containing the backup set, e.g. "1" for "repo1"
+	RepoIndex string
+	// The times the backup started and stopped
+	Start, Stop time.Time
+	// The amount of data (in bytes) backed up from the database, i.e. excluding any files that
+	// were unchanged since a prior backup
+	Size int64
+}
+
 // Executor calls "pgbackrest" commands
 type Executor func(
 	ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
@@ -39,25 +127,36 @@ type Executor func(
 // function is false, this indicates that a pgBackRest config hash mismatch was identified that
 // prevented the "pgbackrest stanza-create" command from running (with a config has mitmatch
 // indicating that pgBackRest configuration as stored in the cluster's pgBackRest ConfigMap has
-// not yet propagated to the Pod).
-func (exec Executor) StanzaCreate(ctx context.Context, configHash string) (bool, error) {
+// not yet propagated to the Pod).  Prior to creating the stanza, it waits briefly for the path
+// of each cloud repo provided (identified using its repo index) to become available.
+func (exec Executor) StanzaCreate(ctx context.Context, configHash string,
+	cloudRepoIndexes ...string) (bool, error) {
 
 	var stdout, stderr bytes.Buffer
 
 	// this is the script that is run to create a stanza.  First it checks the
 	// "config-hash" file to ensure all configuration changes (e.g. from ConfigMaps) have
-	// propagated to the container, and if so then runs the "stanza-create" command (and if
-	// not, it prints an error and returns with exit code 1).
+	// propagated to the container, and if not, it prints an error and returns with exit code 1.
+	// Next it lists the path of each cloud repo, retrying a few times as needed, since the
+	// bucket or prefix of a brand new cloud repo might not yet be available.  It then runs the
+	// "stanza-create" command, which reports any repo that is still unavailable.
 	const script = `
 declare -r hash="$1" stanza="$2" message="$3"
+shift 3
 if [[ "$(< /etc/pgbackrest/conf.d/config-hash)" != "${hash}" ]]; then
     printf >&2 "%s" "${message}"; exit 1;
-else
-    pgbackrest stanza-create --stanza="${stanza}"
 fi
+for repo in "$@"; do
+    attempt=1
+    until pgbackrest repo-ls --repo="${repo}" > /dev/null || (( attempt >= 5 )); do
+        sleep 2; (( attempt += 1 ))
+    done
+done
+pgbackrest stanza-create --stanza="${stanza}"
 `
-	if err := exec(ctx, nil, &stdout, &stderr, "bash", "-ceu", "--",
-		script, "-", configHash, DefaultStanzaName, errMsgConfigHashMismatch); err != nil {
+	if err := exec(ctx, nil, &stdout, &stderr, append([]string{"bash", "-ceu", "--",
+		script, "-", configHash, DefaultStanzaName, errMsgConfigHashMismatch},
+		cloudRepoIndexes...)...); err != nil {
 
 		// if the config hashes didn't match, return true and don't return an error since this is
 		// expected while waiting for config changes in ConfigMaps and Secrets to make it to the
@@ -66,8 +165,249 @@ fi
 			return true, nil
 		}
 
-		return false, errors.WithStack(fmt.Errorf("%w: %v", err, stderr.String()))
+		return false, errors.WithStack(commandError(err, &stdout, &stderr))
+	}
+
+	return false, nil
+}
+
+// RepoAccessible runs the pgBackRest "repo-ls" command against the repo with the index provided,
+// e.g. to verify that the bucket or container of a cloud repo exists and can be accessed using
+// the credentials provided.  Similar to StanzaCreate, the bool returned is true when a pgBackRest
+// config hash mismatch prevented the "repo-ls" command from running.
+func (exec Executor) RepoAccessible(ctx context.Context, configHash,
+	repoIndex string) (bool, error) {
+
+	var stdout, stderr bytes.Buffer
+
+	const script = `
+declare -r hash="$1" repo="$2" message="$3"
+if [[ "$(< /etc/pgbackrest/conf.d/config-hash)" != "${hash}" ]]; then
+    printf >&2 "%s" "${message}"; exit 1;
+fi
+pgbackrest repo-ls --repo="${repo}" > /dev/null
+`
+	if err := exec(ctx, nil, &stdout, &stderr, "bash", "-ceu", "--",
+		script, "-", configHash, repoIndex, errMsgConfigHashMismatch); err != nil {
+
+		if stderr.String() == errMsgConfigHashMismatch {
+			return true, nil
+		}
+
+		return false, errors.WithStack(commandError(err, &stdout, &stderr))
 	}
 
 	return false, nil
 }
+
+// ValidStanzaRepos runs the pgBackRest "info" command and returns the index of each repo that
+// already contains a valid stanza, e.g. as created for the same PostgreSQL cluster by another
+// PostgresCluster.  When a system identifier is provided, the stanza must also belong to the
+// PostgreSQL cluster with that identifier.  Similar to StanzaCreate, the bool returned is true
+// when a pgBackRest config hash mismatch prevented the "info" command from running.
+func (exec Executor) ValidStanzaRepos(ctx context.Context, configHash,
+	systemIdentifier string) (map[string]bool, bool, error) {
+
+	var stdout, stderr bytes.Buffer
+
+	const script = `
+declare -r hash="$1" stanza="$2" message="$3"
+if [[ "$(< /etc/pgbackrest/conf.d/config-hash)" != "${hash}" ]]; then
+    printf >&2 "%s" "${message}"; exit 1;
+fi
+pgbackrest info --stanza="${stanza}" --output=json
+`
+	if err := exec(ctx, nil, &stdout, &stderr, "bash", "-ceu", "--",
+		script, "-", configHash, DefaultStanzaName, errMsgConfigHashMismatch); err != nil {
+
+		if stderr.String() == errMsgConfigHashMismatch {
+			return nil, true, nil
+		}
+
+		return nil, false, errors.WithStack(commandError(err, &stdout, &stderr))
+	}
+
+	var stanzas []stanzaInfo
+	if err := json.Unmarshal(stdout.Bytes(), &stanzas); err != nil {
+		return nil, false, errors.WithStack(err)
+	}
+
+	valid := make(map[string]bool)
+	for _, stanza := range stanzas {
+		if stanza.Name != DefaultStanzaName {
+			continue
+		}
+		for _, repo := range stanza.Repo {
+			// the stanza is valid when the repo is "ok", or when it simply does not yet contain
+			// any backups
+			// - https://pgbackrest.org/command.html#command-info
+			if repo.Status.Code != 0 && repo.Status.Code != 2 {
+				continue
+			}
+
+			// the current database of the stanza is the one with the highest ID
+			var current, found = 0, false
+			for i, db := range stanza.DB {
+				if db.RepoKey == repo.Key && (!found || db.ID > stanza.DB[current].ID) {
+					current, found = i, true
+				}
+			}
+			if !found || (systemIdentifier != "" &&
+				strconv.FormatUint(stanza.DB[current].SystemID, 10) != systemIdentifier) {
+				continue
+			}
+
+			valid[strconv.Itoa(repo.Key)] = true
+		}
+	}
+
+	return valid, false, nil
+}
+
+// BackupCounts runs the pgBackRest "info" command and returns the number of full, differential
+// and incremental backups within each repo, keyed by repo index.
+func (exec Executor) BackupCounts(ctx context.Context) (map[string]v1beta1.RepoBackupCounts,
+	error) {
+
+	var stdout, stderr bytes.Buffer
+
+	if err := exec(ctx, nil, &stdout, &stderr, "pgbackrest", "info",
+		"--stanza="+DefaultStanzaName, "--output=json"); err != nil {
+		return nil, errors.WithStack(commandError(err, &stdout, &stderr))
+	}
+
+	var stanzas []stanzaInfo
+	if err := json.Unmarshal(stdout.Bytes(), &stanzas); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	counts := make(map[string]v1beta1.RepoBackupCounts)
+	for _, stanza := range stanzas {
+		if stanza.Name != DefaultStanzaName {
+			continue
+		}
+		// include each repo, even those without any backups
+		for _, repo := range stanza.Repo {
+			counts[strconv.Itoa(repo.Key)] = v1beta1.RepoBackupCounts{}
+		}
+		for _, backup := range stanza.Backup {
+			index := strconv.Itoa(backup.Database.RepoKey)
+			repoCounts := counts[index]
+			switch backup.Type {
+			case "full":
+				repoCounts.Full++
+			case "diff":
+				repoCounts.Differential++
+			case "incr":
+				repoCounts.Incremental++
+			}
+			counts[index] = repoCounts
+		}
+	}
+
+	return counts, nil
+}
+
+// BackupSets runs the pgBackRest "info" command and returns every backup set within each repo.
+func (exec Executor) BackupSets(ctx context.Context) ([]BackupSet, error) {
+
+	var stdout, stderr bytes.Buffer
+
+	if err := exec(ctx, nil, &stdout, &stderr, "pgbackrest", "info",
+		"--stanza="+DefaultStanzaName, "--output=json"); err != nil {
+		return nil, errors.WithStack(commandError(err, &stdout, &stderr))
+	}
+
+	var stanzas []stanzaInfo
+	if err := json.Unmarshal(stdout.Bytes(), &stanzas); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var sets []BackupSet
+	for _, stanza := range stanzas {
+		if stanza.Name != DefaultStanzaName {
+			continue
+		}
+		for _, backup := range stanza.Backup {
+			sets = append(sets, BackupSet{
+				Label:     backup.Label,
+				RepoIndex: strconv.Itoa(backup.Database.RepoKey),
+				Start:     time.Unix(backup.Timestamp.Start, 0).UTC(),
+				Stop:      time.Unix(backup.Timestamp.Stop, 0).UTC(),
+				Size:      backup.Info.Delta,
+			})
+		}
+	}
+
+	return sets, nil
+}
+
+// Check runs the pgBackRest "check" command, which verifies that pgBackRest is able to reach
+// each configured repository, and that WAL archiving is properly configured.
+func (exec Executor) Check(ctx context.Context) error {
+
+	var stdout, stderr bytes.Buffer
+
+	if err := exec(ctx, nil, &stdout, &stderr, "pgbackrest", "check",
+		"--stanza="+DefaultStanzaName); err != nil {
+		return errors.WithStack(commandError(err, &stdout, &stderr))
+	}
+
+	return nil
+}
+
+// ConfigHash returns the pgBackRest configuration hash currently mounted within the container,
+// i.e. the hash of the configuration pgBackRest is currently using.
+func (exec Executor) ConfigHash(ctx context.Context) (string, error) {
+
+	var stdout, stderr bytes.Buffer
+
+	if err := exec(ctx, nil, &stdout, &stderr, "cat",
+		ConfigDir+"/"+ConfigHashKey); err != nil {
+		return "", errors.WithStack(commandError(err, &stdout, &stderr))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// Version returns the version of pgBackRest installed within the container, e.g. "2.33", as
+// reported by the pgBackRest "version" command.
+func (exec Executor) Version(ctx context.Context) (string, error) {
+
+	var stdout, stderr bytes.Buffer
+
+	if err := exec(ctx, nil, &stdout, &stderr, "pgbackrest", "version"); err != nil {
+		return "", errors.WithStack(commandError(err, &stdout, &stderr))
+	}
+
+	return strings.TrimPrefix(strings.TrimSpace(stdout.String()), "pgBackRest "), nil
+}
+
+// RestoreProgress returns the progress of a pgBackRest restore as reported in the restore log
+// file, i.e. the percentage of the backup restored as of the last file restored.  Once all files
+// have been restored and PostgreSQL has been started to replay WAL, RestoreProgressRecovering is
+// returned instead.  An empty string is returned when progress cannot be determined, e.g. if
+// nothing has been restored yet.
+func (exec Executor) RestoreProgress(ctx context.Context) (string, error) {
+
+	var stdout, stderr bytes.Buffer
+
+	// this is the script that is run to determine restore progress.  The temporary PostgreSQL
+	// config created by the restore script once the restore completes indicates recovery is in
+	// progress, otherwise the percentage of the last file restored is printed (if any).
+	const script = `
+declare -r log="$1" recovering="$2"
+if [[ -f /tmp/postgres.restore.conf ]]; then
+    printf "%s" "${recovering}"
+elif [[ -f "${log}" ]]; then
+    grep -o '[0-9.]*%)' "${log}" | tail -n 1 | tr -d ')' || true
+fi
+`
+	if err := exec(ctx, nil, &stdout, &stderr, "bash", "-ceu", "--", script, "-",
+		defaultLogPath+"/"+DefaultStanzaName+"-restore.log",
+		RestoreProgressRecovering); err != nil {
+		return "", errors.WithStack(commandError(err, &stdout, &stderr))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
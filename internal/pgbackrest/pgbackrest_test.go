@@ -17,13 +17,18 @@ package pgbackrest
 
 import (
 	"context"
+	"errors"
 	"io"
 	"io/ioutil"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
+
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 func TestStanzaCreate(t *testing.T) {
@@ -41,13 +46,20 @@ func TestStanzaCreate(t *testing.T) {
 	configHash := "7f5d4d5bdc"
 	expectedCommand := []string{"bash", "-ceu", "--", `
 declare -r hash="$1" stanza="$2" message="$3"
+shift 3
 if [[ "$(< /etc/pgbackrest/conf.d/config-hash)" != "${hash}" ]]; then
     printf >&2 "%s" "${message}"; exit 1;
-else
-    pgbackrest stanza-create --stanza="${stanza}"
 fi
+for repo in "$@"; do
+    attempt=1
+    until pgbackrest repo-ls --repo="${repo}" > /dev/null || (( attempt >= 5 )); do
+        sleep 2; (( attempt += 1 ))
+    done
+done
+pgbackrest stanza-create --stanza="${stanza}"
 `,
-		"-", "7f5d4d5bdc", "db", "postgres operator error: pgBackRest config hash mismatch"}
+		"-", "7f5d4d5bdc", "db", "postgres operator error: pgBackRest config hash mismatch",
+		"2", "3"}
 
 	var shellCheckScript string
 	stanzaExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
@@ -62,7 +74,7 @@ fi
 		return nil
 	}
 
-	configHashMismatch, err := Executor(stanzaExec).StanzaCreate(ctx, configHash)
+	configHashMismatch, err := Executor(stanzaExec).StanzaCreate(ctx, configHash, "2", "3")
 	assert.NilError(t, err)
 	assert.Assert(t, !configHashMismatch)
 
@@ -77,3 +89,342 @@ fi
 	output, err := cmd.CombinedOutput()
 	assert.NilError(t, err, "%q\n%s", cmd.Args, output)
 }
+
+func TestCheck(t *testing.T) {
+
+	ctx := context.Background()
+	expectedCommand := []string{"pgbackrest", "check", "--stanza=db"}
+
+	t.Run("success", func(t *testing.T) {
+		checkExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			assert.DeepEqual(t, command, expectedCommand)
+			return nil
+		}
+		assert.NilError(t, Executor(checkExec).Check(ctx))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		checkExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			_, _ = stderr.Write([]byte("unable to find bucket"))
+			return errors.New("exit status 1")
+		}
+		err := Executor(checkExec).Check(ctx)
+		assert.ErrorContains(t, err, "unable to find bucket")
+	})
+}
+
+func TestConfigHash(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		configHashExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			assert.DeepEqual(t, command,
+				[]string{"cat", "/etc/pgbackrest/conf.d/config-hash"})
+			_, _ = stdout.Write([]byte("abcde12345\n"))
+			return nil
+		}
+		hash, err := Executor(configHashExec).ConfigHash(ctx)
+		assert.NilError(t, err)
+		assert.Equal(t, hash, "abcde12345")
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		configHashExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			_, _ = stderr.Write([]byte("No such file or directory"))
+			return errors.New("exit status 1")
+		}
+		_, err := Executor(configHashExec).ConfigHash(ctx)
+		assert.ErrorContains(t, err, "No such file or directory")
+	})
+}
+
+func TestVersion(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		versionExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			assert.DeepEqual(t, command, []string{"pgbackrest", "version"})
+			_, _ = stdout.Write([]byte("pgBackRest 2.33\n"))
+			return nil
+		}
+		version, err := Executor(versionExec).Version(ctx)
+		assert.NilError(t, err)
+		assert.Equal(t, version, "2.33")
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		versionExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			_, _ = stderr.Write([]byte("pgbackrest: command not found"))
+			return errors.New("exit status 127")
+		}
+		_, err := Executor(versionExec).Version(ctx)
+		assert.ErrorContains(t, err, "command not found")
+	})
+}
+
+func TestRestoreProgress(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("progress", func(t *testing.T) {
+		progressExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			assert.Assert(t, len(command) == 7)
+			assert.DeepEqual(t, command[:3], []string{"bash", "-ceu", "--"})
+			assert.DeepEqual(t, command[4:], []string{"-", "/tmp/db-restore.log", "Recovering"})
+			_, _ = stdout.Write([]byte("42.17%\n"))
+			return nil
+		}
+		progress, err := Executor(progressExec).RestoreProgress(ctx)
+		assert.NilError(t, err)
+		assert.Equal(t, progress, "42.17%")
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		progressExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			return nil
+		}
+		progress, err := Executor(progressExec).RestoreProgress(ctx)
+		assert.NilError(t, err)
+		assert.Equal(t, progress, "")
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		progressExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			_, _ = stderr.Write([]byte("container not found"))
+			return errors.New("exit status 1")
+		}
+		_, err := Executor(progressExec).RestoreProgress(ctx)
+		assert.ErrorContains(t, err, "container not found")
+	})
+}
+
+func TestRepoAccessible(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("accessible", func(t *testing.T) {
+		lsExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			assert.DeepEqual(t, command[:3], []string{"bash", "-ceu", "--"})
+			assert.Assert(t, strings.Contains(command[3], "pgbackrest repo-ls"))
+			assert.DeepEqual(t, command[4:], []string{"-", "7f5d4d5bdc", "2",
+				"postgres operator error: pgBackRest config hash mismatch"})
+			return nil
+		}
+		mismatch, err := Executor(lsExec).RepoAccessible(ctx, "7f5d4d5bdc", "2")
+		assert.NilError(t, err)
+		assert.Assert(t, !mismatch)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		lsExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			_, _ = stderr.Write([]byte(errMsgConfigHashMismatch))
+			return errors.New("exit status 1")
+		}
+		mismatch, err := Executor(lsExec).RepoAccessible(ctx, "7f5d4d5bdc", "2")
+		assert.NilError(t, err)
+		assert.Assert(t, mismatch)
+	})
+
+	t.Run("inaccessible", func(t *testing.T) {
+		lsExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			_, _ = stderr.Write([]byte("ERROR: [039]: HTTP request failed with 404 (Not Found)"))
+			return errors.New("exit status 39")
+		}
+		mismatch, err := Executor(lsExec).RepoAccessible(ctx, "7f5d4d5bdc", "2")
+		assert.ErrorContains(t, err, "404 (Not Found)")
+		assert.Assert(t, !mismatch)
+	})
+}
+
+func TestValidStanzaRepos(t *testing.T) {
+
+	ctx := context.Background()
+	info := `[{"name":"db","db":[
+	{"id":1,"repo-key":1,"system-id":6952526174828511264,"version":"13"},
+	{"id":1,"repo-key":2,"system-id":6952526174828511264,"version":"13"},
+	{"id":1,"repo-key":3,"system-id":6952526174828511264,"version":"12"},
+	{"id":2,"repo-key":3,"system-id":7000000000000000000,"version":"13"}],
+"repo":[
+	{"key":1,"status":{"code":0,"message":"ok"}},
+	{"key":2,"status":{"code":2,"message":"no valid backups"}},
+	{"key":3,"status":{"code":0,"message":"ok"}},
+	{"key":4,"status":{"code":1,"message":"missing stanza path"}}],
+"status":{"code":99,"message":"other"}}]`
+
+	t.Run("valid", func(t *testing.T) {
+		infoExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			assert.DeepEqual(t, command[:3], []string{"bash", "-ceu", "--"})
+			assert.DeepEqual(t, command[4:], []string{"-", "7f5d4d5bdc", "db",
+				"postgres operator error: pgBackRest config hash mismatch"})
+			_, _ = stdout.Write([]byte(info))
+			return nil
+		}
+
+		valid, mismatch, err := Executor(infoExec).ValidStanzaRepos(ctx, "7f5d4d5bdc",
+			"6952526174828511264")
+		assert.NilError(t, err)
+		assert.Assert(t, !mismatch)
+		assert.DeepEqual(t, valid, map[string]bool{"1": true, "2": true})
+
+		// the system identifier is not checked when unknown
+		valid, _, err = Executor(infoExec).ValidStanzaRepos(ctx, "7f5d4d5bdc", "")
+		assert.NilError(t, err)
+		assert.DeepEqual(t, valid, map[string]bool{"1": true, "2": true, "3": true})
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		infoExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			_, _ = stderr.Write([]byte("postgres operator error: pgBackRest config hash mismatch"))
+			return errors.New("exit status 1")
+		}
+		valid, mismatch, err := Executor(infoExec).ValidStanzaRepos(ctx, "7f5d4d5bdc", "")
+		assert.NilError(t, err)
+		assert.Assert(t, mismatch)
+		assert.Assert(t, valid == nil)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		infoExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			_, _ = stderr.Write([]byte("unable to find bucket"))
+			return errors.New("exit status 1")
+		}
+		_, _, err := Executor(infoExec).ValidStanzaRepos(ctx, "7f5d4d5bdc", "")
+		assert.ErrorContains(t, err, "unable to find bucket")
+	})
+}
+
+func TestBackupCounts(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		infoExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			assert.DeepEqual(t, command,
+				[]string{"pgbackrest", "info", "--stanza=db", "--output=json"})
+			_, _ = stdout.Write([]byte(`[{"name":"db","backup":[
+	{"database":{"id":1,"repo-key":1},"type":"full"},
+	{"database":{"id":1,"repo-key":1},"type":"incr"},
+	{"database":{"id":1,"repo-key":1},"type":"incr"},
+	{"database":{"id":1,"repo-key":1},"type":"full"},
+	{"database":{"id":1,"repo-key":2},"type":"diff"}],
+"repo":[{"key":1},{"key":2},{"key":3}]}]`))
+			return nil
+		}
+
+		counts, err := Executor(infoExec).BackupCounts(ctx)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, counts, map[string]v1beta1.RepoBackupCounts{
+			"1": {Full: 2, Incremental: 2},
+			"2": {Differential: 1},
+			"3": {},
+		})
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		infoExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			_, _ = stderr.Write([]byte("unable to find bucket"))
+			return errors.New("exit status 1")
+		}
+		_, err := Executor(infoExec).BackupCounts(ctx)
+		assert.ErrorContains(t, err, "unable to find bucket")
+	})
+}
+
+func TestBackupSets(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		infoExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			assert.DeepEqual(t, command,
+				[]string{"pgbackrest", "info", "--stanza=db", "--output=json"})
+			_, _ = stdout.Write([]byte(`[{"name":"db","backup":[
+	{"database":{"id":1,"repo-key":1},"info":{"delta":31457280,"size":31457280},
+		"label":"20240101-120000F",
+		"timestamp":{"start":1704110400,"stop":1704110460},"type":"full"},
+	{"database":{"id":1,"repo-key":2},"info":{"delta":8192,"size":31465472},
+		"label":"20240101-120000F_20240102-120000I",
+		"timestamp":{"start":1704196800,"stop":1704196805},"type":"incr"}],
+"repo":[{"key":1},{"key":2}]}]`))
+			return nil
+		}
+
+		sets, err := Executor(infoExec).BackupSets(ctx)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, sets, []BackupSet{{
+			Label:     "20240101-120000F",
+			RepoIndex: "1",
+			Start:     time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC),
+			Stop:      time.Date(2024, time.January, 1, 12, 1, 0, 0, time.UTC),
+			Size:      31457280,
+		}, {
+			Label:     "20240101-120000F_20240102-120000I",
+			RepoIndex: "2",
+			Start:     time.Date(2024, time.January, 2, 12, 0, 0, 0, time.UTC),
+			Stop:      time.Date(2024, time.January, 2, 12, 0, 5, 0, time.UTC),
+			Size:      8192,
+		}})
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		infoExec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			_, _ = stderr.Write([]byte("unable to find bucket"))
+			return errors.New("exit status 1")
+		}
+		_, err := Executor(infoExec).BackupSets(ctx)
+		assert.ErrorContains(t, err, "unable to find bucket")
+	})
+}
+
+func TestCommandError(t *testing.T) {
+
+	err := &CommandError{Err: errors.New("exit status 1"),
+		Stdout: "some output\n", Stderr: "ERROR: [055]: unable to load info file\n"}
+	assert.Equal(t, err.Error(), "exit status 1: ERROR: [055]: unable to load info file\n")
+	assert.Assert(t, errors.Is(err, err.Err))
+	assert.Equal(t, err.Tail(), "ERROR: [055]: unable to load info file")
+
+	// stdout is used when there is nothing in stderr
+	err.Stderr = ""
+	assert.Equal(t, err.Tail(), "some output")
+
+	// long output is truncated to its last lines
+	err.Stderr = strings.Repeat("INFO: archive-push command begin\n", 100) + "ERROR: done"
+	tail := err.Tail()
+	assert.Assert(t, len(tail) <= maxOutputTail)
+	assert.Assert(t, strings.HasPrefix(tail, "INFO: archive-push command begin\n"))
+	assert.Assert(t, strings.HasSuffix(tail, "\nERROR: done"))
+
+	// returned by an Executor
+	_, execErr := Executor(func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+		command ...string) error {
+		_, _ = stdout.Write([]byte("partial"))
+		_, _ = stderr.Write([]byte("unable to find bucket"))
+		return errors.New("exit status 1")
+	}).BackupCounts(context.Background())
+	var commandErr *CommandError
+	assert.Assert(t, errors.As(execErr, &commandErr))
+	assert.Equal(t, commandErr.Stdout, "partial")
+	assert.Equal(t, commandErr.Tail(), "unable to find bucket")
+}
@@ -80,6 +80,14 @@ func AddConfigsToPod(postgresCluster *v1beta1.PostgresCluster, template *v1.PodT
 
 	// grab user provided configs
 	pgBackRestConfigs := postgresCluster.Spec.Backups.PGBackRest.Configuration
+	// add any custom configuration files from the referenced ConfigMap
+	if customConfig := postgresCluster.Spec.Backups.PGBackRest.ConfigMap; customConfig != nil {
+		pgBackRestConfigs = append(pgBackRestConfigs, v1.VolumeProjection{
+			ConfigMap: &v1.ConfigMapProjection{
+				LocalObjectReference: *customConfig,
+			},
+		})
+	}
 	// add default pgbackrest configs
 	defaultConfig := v1.VolumeProjection{
 		ConfigMap: &v1.ConfigMapProjection{
@@ -181,15 +189,9 @@ func AddSSHToPod(postgresCluster *v1beta1.PostgresCluster, template *v1.PodTempl
 	// not necessary to run a full SSHD server, but the various SSH configs are still needed.
 	if enableSSHD {
 		container := v1.Container{
-			Command: []string{"/usr/sbin/sshd", "-D", "-e"},
-			Image:   postgresCluster.Spec.Backups.PGBackRest.Image,
-			LivenessProbe: &v1.Probe{
-				Handler: v1.Handler{
-					TCPSocket: &v1.TCPSocketAction{
-						Port: intstr.FromInt(2022),
-					},
-				},
-			},
+			Command:         []string{"/usr/sbin/sshd", "-D", "-e"},
+			Image:           postgresCluster.Spec.Backups.PGBackRest.Image,
+			LivenessProbe:   sshdProbe(postgresCluster),
 			Name:            naming.PGBackRestRepoContainerName,
 			VolumeMounts:    []v1.VolumeMount{sshVolumeMount},
 			SecurityContext: initialize.RestrictedSecurityContext(),
@@ -230,6 +232,40 @@ func AddSSHToPod(postgresCluster *v1beta1.PostgresCluster, template *v1.PodTempl
 	return nil
 }
 
+// sshdProbe returns the liveness probe of the SSH server, using the timing defined for the
+// repository host in the spec.  Any timing not defined in the spec is set to the Kubernetes
+// default, which keeps the probe the same as one defaulted by the API server.
+func sshdProbe(postgresCluster *v1beta1.PostgresCluster) *v1.Probe {
+	probe := &v1.Probe{
+		Handler: v1.Handler{
+			TCPSocket: &v1.TCPSocketAction{
+				Port: intstr.FromInt(int(sshPort(postgresCluster))),
+			},
+		},
+		TimeoutSeconds:   1,
+		PeriodSeconds:    10,
+		SuccessThreshold: 1,
+		FailureThreshold: 3,
+	}
+
+	if timing := postgresCluster.Spec.Backups.PGBackRest.RepoHost.Probes; timing != nil {
+		if timing.InitialDelaySeconds != nil {
+			probe.InitialDelaySeconds = *timing.InitialDelaySeconds
+		}
+		if timing.PeriodSeconds != nil {
+			probe.PeriodSeconds = *timing.PeriodSeconds
+		}
+		if timing.TimeoutSeconds != nil {
+			probe.TimeoutSeconds = *timing.TimeoutSeconds
+		}
+		if timing.FailureThreshold != nil {
+			probe.FailureThreshold = *timing.FailureThreshold
+		}
+	}
+
+	return probe
+}
+
 // ReplicaCreateCommand returns the command that can initialize the PostgreSQL
 // data directory on an instance from one of cluster's repositories. It returns
 // nil when no repository is available.
@@ -193,6 +193,28 @@ func TestAddConfigsToPod(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("custom config ConfigMap", func(t *testing.T) {
+		cluster := postgresCluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Configuration = nil
+		cluster.Spec.Backups.PGBackRest.ConfigMap = &v1.LocalObjectReference{Name: "custom"}
+		template := &v1.PodTemplateSpec{
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "pgbackrest"}},
+			},
+		}
+
+		assert.NilError(t, AddConfigsToPod(cluster, template, confFile, "pgbackrest"))
+		assert.Equal(t, len(template.Spec.Volumes), 1)
+
+		// the custom ConfigMap is projected in full before the generated configuration
+		sources := template.Spec.Volumes[0].Projected.Sources
+		assert.Equal(t, len(sources), 2)
+		assert.Assert(t, sources[0].ConfigMap != nil)
+		assert.Equal(t, sources[0].ConfigMap.Name, "custom")
+		assert.Equal(t, len(sources[0].ConfigMap.Items), 0)
+		assert.Equal(t, sources[1].ConfigMap.Name, naming.PGBackRestConfig(cluster).Name)
+	})
 }
 
 func TestAddSSHToPod(t *testing.T) {
@@ -323,6 +345,36 @@ func getContainerNames(containers []v1.Container) []string {
 	return names
 }
 
+func TestSSHDProbe(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{}
+
+	probe := sshdProbe(cluster)
+	assert.Equal(t, probe.TCPSocket.Port.IntValue(), 2022)
+	assert.Equal(t, probe.InitialDelaySeconds, int32(0))
+	assert.Equal(t, probe.PeriodSeconds, int32(10))
+	assert.Equal(t, probe.TimeoutSeconds, int32(1))
+	assert.Equal(t, probe.FailureThreshold, int32(3))
+
+	initialDelay, failureThreshold := int32(120), int32(6)
+	cluster.Spec.Backups.PGBackRest.RepoHost.Probes = &v1beta1.RepoHostProbes{
+		InitialDelaySeconds: &initialDelay,
+		FailureThreshold:    &failureThreshold,
+	}
+
+	probe = sshdProbe(cluster)
+	assert.Equal(t, probe.InitialDelaySeconds, int32(120))
+	assert.Equal(t, probe.PeriodSeconds, int32(10))
+	assert.Equal(t, probe.TimeoutSeconds, int32(1))
+	assert.Equal(t, probe.FailureThreshold, int32(6))
+
+	port := int32(2222)
+	cluster.Spec.Backups.PGBackRest.RepoHost.SSHPort = &port
+
+	probe = sshdProbe(cluster)
+	assert.Equal(t, probe.TCPSocket.Port.IntValue(), 2222)
+}
+
 func TestReplicaCreateCommand(t *testing.T) {
 	cluster := new(v1beta1.PostgresCluster)
 	instance := new(v1beta1.PostgresInstanceSetSpec)
@@ -21,6 +21,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"fmt"
+	"strings"
 
 	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/naming"
@@ -35,6 +36,9 @@ const (
 
 	// knownHostsKey is the name of the 'known_hosts' file
 	knownHostsKey = "ssh_known_hosts"
+	// additionalKnownHostsKey is the name of the 'known_hosts' file containing any additional
+	// entries defined in the spec.  SSH clients read this file after knownHostsKey by default.
+	additionalKnownHostsKey = "ssh_known_hosts2"
 
 	// mount path for SSH configuration
 	sshConfigPath = "/etc/ssh"
@@ -50,6 +54,10 @@ const (
 	publicKey = "id_ecdsa.pub"
 	// SSH configuration volume
 	sshConfigVol = "sshd"
+
+	// defaultSSHPort is the port used by the SSH server and client when no port is defined for
+	// the repository host in the spec
+	defaultSSHPort int32 = 2022
 )
 
 // sshKey stores byte slices that represent private and public ssh keys
@@ -85,12 +93,18 @@ func CreateSSHConfigMapIntent(postgresCluster *v1beta1.PostgresCluster) v1.Confi
 
 	// if the SSH config data map is not ok, populate with the configuration string
 	if _, ok := cm.Data[sshConfig]; !ok {
-		cm.Data[sshConfig] = getSSHConfigString()
+		cm.Data[sshConfig] = getSSHConfigString(sshPort(postgresCluster))
 	}
 
 	// if the SSHD config data map is not ok, populate with the configuration string
 	if _, ok := cm.Data[sshdConfig]; !ok {
-		cm.Data[sshdConfig] = getSSHDConfigString()
+		cm.Data[sshdConfig] = getSSHDConfigString(sshPort(postgresCluster))
+	}
+
+	// add any additional known hosts entries defined in the spec
+	if repoHost := postgresCluster.Spec.Backups.PGBackRest.RepoHost; repoHost != nil &&
+		len(repoHost.SSHKnownHosts) > 0 {
+		cm.Data[additionalKnownHostsKey] = strings.Join(repoHost.SSHKnownHosts, "\n") + "\n"
 	}
 
 	return cm
@@ -221,9 +235,19 @@ func SSHConfigVolumeAndMount(sshConfigMap *v1.ConfigMap, sshSecret *v1.Secret, p
 	container.VolumeMounts = mergeVolumeMounts(container.VolumeMounts, mount)
 }
 
+// sshPort returns the port used by the SSH server and client, as defined for the repository host
+// in the spec.  Defaults to 2022.
+func sshPort(postgresCluster *v1beta1.PostgresCluster) int32 {
+	if repoHost := postgresCluster.Spec.Backups.PGBackRest.RepoHost; repoHost != nil &&
+		repoHost.SSHPort != nil {
+		return *repoHost.SSHPort
+	}
+	return defaultSSHPort
+}
+
 // getSSHDConfigString returns a string consisting of the basic required configuration
-// for the SSHD service
-func getSSHDConfigString() string {
+// for the SSHD service, listening on the port provided
+func getSSHDConfigString(port int32) string {
 
 	// please note that the ForceCommand setting ensures nss_wrapper env vars are set when
 	// executing commands as required for OpenShift compatibility:
@@ -234,7 +258,7 @@ HostKey /etc/ssh/id_ecdsa
 PasswordAuthentication no
 PermitRootLogin no
 PidFile /tmp/sshd.pid
-Port 2022
+Port ` + fmt.Sprint(port) + `
 PubkeyAuthentication yes
 StrictModes no
 `
@@ -242,13 +266,13 @@ StrictModes no
 }
 
 // getSSHDConfigString returns a string consisting of the basic required configuration
-// for the SSH client
-func getSSHConfigString() string {
+// for the SSH client, connecting to the port provided
+func getSSHConfigString(port int32) string {
 
 	configString := `Host *
 StrictHostKeyChecking yes
 IdentityFile /etc/ssh/id_ecdsa
-Port 2022
+Port ` + fmt.Sprint(port) + `
 User postgres
 `
 	return configString
@@ -240,3 +240,42 @@ StrictModes no
 		`)+"\n"))
 	})
 }
+
+func TestSSHPort(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+
+	cm := CreateSSHConfigMapIntent(cluster)
+	assert.Assert(t, strings.Contains(cm.Data[sshConfig], "\nPort 2022\n"))
+	assert.Assert(t, strings.Contains(cm.Data[sshdConfig], "\nPort 2022\n"))
+
+	port := int32(2222)
+	cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{SSHPort: &port}
+
+	cm = CreateSSHConfigMapIntent(cluster)
+	assert.Assert(t, strings.Contains(cm.Data[sshConfig], "\nPort 2222\n"))
+	assert.Assert(t, strings.Contains(cm.Data[sshdConfig], "\nPort 2222\n"))
+}
+
+func TestSSHKnownHosts(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hippo", Namespace: "ns"},
+	}
+
+	cm := CreateSSHConfigMapIntent(cluster)
+	_, ok := cm.Data[additionalKnownHostsKey]
+	assert.Assert(t, !ok)
+
+	cluster.Spec.Backups.PGBackRest.RepoHost = &v1beta1.PGBackRestRepoHost{
+		SSHKnownHosts: []string{
+			"*.hippo-pods.ns.svc.cluster.local ecdsa-sha2-nistp521 AAAA",
+			"@cert-authority *.ns.svc.cluster.local ssh-ed25519 BBBB",
+		},
+	}
+
+	cm = CreateSSHConfigMapIntent(cluster)
+	assert.Equal(t, cm.Data[additionalKnownHostsKey], ""+
+		"*.hippo-pods.ns.svc.cluster.local ecdsa-sha2-nistp521 AAAA\n"+
+		"@cert-authority *.ns.svc.cluster.local ssh-ed25519 BBBB\n")
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"hash/fnv"
 	"io"
+	"strings"
 
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 	"github.com/pkg/errors"
@@ -44,9 +45,10 @@ func DedicatedRepoHostEnabled(postgresCluster *v1beta1.PostgresCluster) bool {
 
 // CalculateConfigHashes calculates hashes for any external pgBackRest repository configuration
 // present in the PostgresCluster spec (e.g. configuration for Azure, GCR and/or S3 repositories).
-// Additionally it returns a hash of the hashes for each external repository.
-func CalculateConfigHashes(
-	postgresCluster *v1beta1.PostgresCluster) (map[string]string, string, error) {
+// Additionally it returns a hash of the hashes for each external repository, which also includes
+// a hash of any custom configuration files provided via the ConfigMap referenced in the spec.
+func CalculateConfigHashes(postgresCluster *v1beta1.PostgresCluster,
+	customConfig map[string]string) (map[string]string, string, error) {
 
 	hashFunc := func(repoOpts []string) (string, error) {
 		return safeHash32(func(w io.Writer) (err error) {
@@ -65,24 +67,27 @@ func CalculateConfigHashes(
 			continue
 		}
 
-		var hash, name string
+		var repoOpts []string
 		switch {
 		case repo.Azure != nil:
-			hash, err = hashFunc([]string{repo.Azure.Container})
-			name = repo.Name
+			repoOpts = []string{repo.Azure.Container}
 		case repo.GCS != nil:
-			hash, err = hashFunc([]string{repo.GCS.Bucket})
-			name = repo.Name
+			repoOpts = []string{repo.GCS.Bucket}
 		case repo.S3 != nil:
-			hash, err = hashFunc([]string{repo.S3.Bucket, repo.S3.Endpoint, repo.S3.Region})
-			name = repo.Name
+			repoOpts = []string{repo.S3.Bucket, repo.S3.Endpoint, repo.S3.Region}
 		default:
 			return map[string]string{}, "", errors.New("found unexpected repo type")
 		}
+		// the repo path is only included when configured, so that the hash is unchanged for
+		// repos using the default path
+		if repo.Path != "" {
+			repoOpts = append(repoOpts, repo.Path)
+		}
+		hash, err := hashFunc(repoOpts)
 		if err != nil {
 			return map[string]string{}, "", errors.WithStack(err)
 		}
-		repoConfigHashes[name] = hash
+		repoConfigHashes[repo.Name] = hash
 	}
 
 	configHashes := []string{}
@@ -93,6 +98,43 @@ func CalculateConfigHashes(
 			configHashes = append(configHashes, repoConfigHashes[configName])
 		}
 	}
+	// retention settings are only included for repos that define them, so that the hash is
+	// unchanged for clusters that do not configure retention in the spec
+	retentionConfigs := make(map[string]string)
+	for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+		for option, val := range getRetentionConfigs(repo) {
+			retentionConfigs[option] = val
+		}
+	}
+	if len(retentionConfigs) > 0 {
+		retentionOpts := make([]string, 0, 2*len(retentionConfigs))
+		for _, option := range sortedKeys(retentionConfigs) {
+			retentionOpts = append(retentionOpts, option, retentionConfigs[option])
+		}
+		retentionHash, err := hashFunc(retentionOpts)
+		if err != nil {
+			return map[string]string{}, "", errors.WithStack(err)
+		}
+		configHashes = append(configHashes, retentionHash)
+	}
+	// the path of the PostgreSQL data directory is only included when configured, so that the
+	// hash is unchanged for clusters using the default path
+	if pgPath := postgresCluster.Spec.Backups.PGBackRest.PGPath; pgPath != "" {
+		pgPathHash, err := hashFunc([]string{pgPath})
+		if err != nil {
+			return map[string]string{}, "", errors.WithStack(err)
+		}
+		configHashes = append(configHashes, pgPathHash)
+	}
+	// custom configuration files are only included when present, so that the hash is unchanged
+	// for clusters that do not reference a custom configuration ConfigMap
+	if len(customConfig) > 0 {
+		customConfigHash, err := hashFunc(customConfigOptions(customConfig))
+		if err != nil {
+			return map[string]string{}, "", errors.WithStack(err)
+		}
+		configHashes = append(configHashes, customConfigHash)
+	}
 	configHash, err := hashFunc(configHashes)
 	if err != nil {
 		return map[string]string{}, "", errors.WithStack(err)
@@ -101,6 +143,128 @@ func CalculateConfigHashes(
 	return repoConfigHashes, configHash, nil
 }
 
+// customConfigOptions returns the file names and contents of the provided custom pgBackRest
+// configuration files, sorted by file name to match the order in which pgBackRest loads them
+func customConfigOptions(customConfig map[string]string) []string {
+	options := make([]string, 0, 2*len(customConfig))
+	for _, name := range sortedKeys(customConfig) {
+		options = append(options, name, customConfig[name])
+	}
+	return options
+}
+
+// ConfigOptions returns the options set in the provided pgBackRest configuration files, keyed by
+// option name.  Only files with the ".conf" extension are considered, since those are the only
+// files pgBackRest loads from its configuration include path.  Sections are ignored.
+func ConfigOptions(files map[string]string) map[string]string {
+	options := make(map[string]string)
+	for _, name := range sortedKeys(files) {
+		if !strings.HasSuffix(name, ".conf") {
+			continue
+		}
+		for _, line := range strings.Split(files[name], "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
+				continue
+			}
+			if kv := strings.SplitN(line, "=", 2); len(kv) == 2 {
+				options[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+			}
+		}
+	}
+	return options
+}
+
+// ValidateExternalRepo validates the configuration of an external (i.e. Azure, GCS or S3)
+// repository using the spec of the repository and the provided pgBackRest configuration
+// options, e.g. to ensure the credentials needed to access the repository have been provided.
+// An error describing the first problem found is returned if the configuration is invalid.
+func ValidateExternalRepo(repo v1beta1.PGBackRestRepo, options map[string]string) error {
+	missing := func(option string) error {
+		return errors.Errorf("%s is missing from the pgBackRest configuration", option)
+	}
+
+	switch {
+	case repo.Azure != nil:
+		if repo.Azure.Container == "" {
+			return errors.New("an Azure container is required")
+		}
+		for _, option := range []string{"-azure-account", "-azure-key"} {
+			if _, ok := options[repo.Name+option]; !ok {
+				return missing(repo.Name + option)
+			}
+		}
+	case repo.GCS != nil:
+		if repo.GCS.Bucket == "" {
+			return errors.New("a GCS bucket is required")
+		}
+		// a key is not needed when pgBackRest retrieves credentials automatically
+		if options[repo.Name+"-gcs-key-type"] == "auto" {
+			return nil
+		}
+		if _, ok := options[repo.Name+"-gcs-key"]; !ok {
+			return missing(repo.Name + "-gcs-key")
+		}
+	case repo.S3 != nil:
+		if repo.S3.Bucket == "" || repo.S3.Endpoint == "" || repo.S3.Region == "" {
+			return errors.New("an S3 bucket, endpoint and region are required")
+		}
+		// keys are not needed when pgBackRest retrieves credentials automatically
+		if keyType := options[repo.Name+"-s3-key-type"]; keyType == "auto" || keyType == "web-id" {
+			return nil
+		}
+		for _, option := range []string{"-s3-key", "-s3-key-secret"} {
+			if _, ok := options[repo.Name+option]; !ok {
+				return missing(repo.Name + option)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateRetention validates the retention settings defined in the spec of a repository, using
+// the provided pgBackRest configuration options to ensure those settings do not conflict with
+// retention options configured elsewhere (e.g. a full retention type of "time" in the spec and
+// "count" within the global configuration).  An error describing the first problem found is
+// returned if the retention settings are invalid.
+func ValidateRetention(repo v1beta1.PGBackRestRepo, options map[string]string) error {
+	retention := repo.Retention
+	if retention == nil {
+		return nil
+	}
+
+	for _, count := range []struct {
+		option string
+		value  *int32
+	}{
+		{option: "-retention-full", value: retention.Full},
+		{option: "-retention-diff", value: retention.Diff},
+		{option: "-retention-archive", value: retention.Archive},
+	} {
+		if count.value != nil && *count.value < 1 {
+			return errors.Errorf("%s%s must be a positive number", repo.Name, count.option)
+		}
+	}
+	if retention.FullType != "" && retention.Full == nil {
+		return errors.Errorf("%s-retention-full is required when a full retention type is set",
+			repo.Name)
+	}
+
+	retentionConfigs := getRetentionConfigs(repo)
+	for _, option := range sortedKeys(retentionConfigs) {
+		if _, ok := options[option]; ok {
+			return errors.Errorf(
+				"%s is set in both the spec and the pgBackRest configuration", option)
+		}
+	}
+	// a full retention type set elsewhere changes the meaning of the full retention in the spec
+	if _, ok := options[repo.Name+"-retention-full-type"]; ok && retention.Full != nil {
+		return errors.Errorf("%s-retention-full-type is set in the pgBackRest configuration, "+
+			"conflicting with the full retention in the spec", repo.Name)
+	}
+	return nil
+}
+
 // safeHash32 runs content and returns a short alphanumeric string that
 // represents everything written to w. The string is unlikely to have bad words
 // and is safe to store in the Kubernetes API. This is the same algorithm used
@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 	"gotest.tools/v3/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -82,7 +83,7 @@ func TestCalculateConfigHashes(t *testing.T) {
 		},
 	}
 
-	configHashMap, configHash, err := CalculateConfigHashes(postgresCluster)
+	configHashMap, configHash, err := CalculateConfigHashes(postgresCluster, nil)
 	assert.NilError(t, err)
 	assert.Equal(t, preCalculatedConfigHash, configHash)
 	assert.Equal(t, preCalculatedRepo1AzureHash, configHashMap["repo1"])
@@ -91,7 +92,7 @@ func TestCalculateConfigHashes(t *testing.T) {
 
 	// call CalculateConfigHashes multiple times to ensure consistent results
 	for i := 0; i < 10; i++ {
-		hashMap, hash, err := CalculateConfigHashes(postgresCluster)
+		hashMap, hash, err := CalculateConfigHashes(postgresCluster, nil)
 		assert.NilError(t, err)
 		assert.Equal(t, configHash, hash)
 		assert.Equal(t, configHashMap["repo1"], hashMap["repo1"])
@@ -107,7 +108,7 @@ func TestCalculateConfigHashes(t *testing.T) {
 		rand.Shuffle(len(repos), func(i, j int) {
 			repos[i], repos[j] = repos[j], repos[i]
 		})
-		_, hash, err := CalculateConfigHashes(shuffleCluster)
+		_, hash, err := CalculateConfigHashes(shuffleCluster, nil)
 		assert.NilError(t, err)
 		assert.Equal(t, configHash, hash)
 	}
@@ -123,11 +124,202 @@ func TestCalculateConfigHashes(t *testing.T) {
 		case 2:
 			modCluster.Spec.Backups.PGBackRest.Repos[i].S3.Bucket = "modified-bucket"
 		}
-		hashMap, hash, err := CalculateConfigHashes(modCluster)
+		hashMap, hash, err := CalculateConfigHashes(modCluster, nil)
 		assert.NilError(t, err)
 		assert.Assert(t, configHash != hash)
 		assert.NilError(t, err)
 		repo := "repo" + strconv.Itoa(i+1)
 		assert.Assert(t, hashMap[repo] != configHashMap[repo])
 	}
+
+	// custom configuration files change the overall hash, but not the repo hashes
+	customConfig := map[string]string{
+		"custom.conf": "[global]\nrepo1-retention-full=2\n",
+		"extra.conf":  "[db]\narchive-push-queue-max=1GiB\n",
+	}
+	hashMap, customHash, err := CalculateConfigHashes(postgresCluster, customConfig)
+	assert.NilError(t, err)
+	assert.Assert(t, customHash != configHash)
+	assert.DeepEqual(t, hashMap, configHashMap)
+
+	// the same custom configuration always produces the same hash
+	for i := 0; i < 10; i++ {
+		_, hash, err := CalculateConfigHashes(postgresCluster, customConfig)
+		assert.NilError(t, err)
+		assert.Equal(t, customHash, hash)
+	}
+
+	// modifying the contents of a custom configuration file changes the hash
+	customConfig["extra.conf"] = "[db]\narchive-push-queue-max=2GiB\n"
+	_, hash, err := CalculateConfigHashes(postgresCluster, customConfig)
+	assert.NilError(t, err)
+	assert.Assert(t, hash != customHash)
+
+	// configuring a repo path changes the hash for that repo only
+	pathCluster := postgresCluster.DeepCopy()
+	pathCluster.Spec.Backups.PGBackRest.Repos[2].Path = "/hippo/repo3"
+	hashMap, pathHash, err := CalculateConfigHashes(pathCluster, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, pathHash != configHash)
+	assert.Equal(t, hashMap["repo1"], configHashMap["repo1"])
+	assert.Equal(t, hashMap["repo2"], configHashMap["repo2"])
+	assert.Assert(t, hashMap["repo3"] != configHashMap["repo3"])
+
+	// configuring the path of the PostgreSQL data directory changes the overall hash only
+	pgPathCluster := postgresCluster.DeepCopy()
+	pgPathCluster.Spec.Backups.PGBackRest.PGPath = "/var/lib/postgresql/data"
+	hashMap, pgPathHash, err := CalculateConfigHashes(pgPathCluster, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, pgPathHash != configHash)
+	assert.DeepEqual(t, hashMap, configHashMap)
+
+	// configuring retention changes the overall hash only, and changes it again whenever the
+	// retention is modified
+	retentionCluster := postgresCluster.DeepCopy()
+	retentionCluster.Spec.Backups.PGBackRest.Repos[0].Retention = &v1beta1.RepoRetention{
+		Full: initialize.Int32(2),
+	}
+	hashMap, retentionHash, err := CalculateConfigHashes(retentionCluster, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, retentionHash != configHash)
+	assert.DeepEqual(t, hashMap, configHashMap)
+
+	retentionCluster.Spec.Backups.PGBackRest.Repos[0].Retention.Full = initialize.Int32(3)
+	_, hash, err = CalculateConfigHashes(retentionCluster, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, hash != retentionHash)
+	assert.Assert(t, hash != configHash)
+}
+
+func TestConfigOptions(t *testing.T) {
+	options := ConfigOptions(map[string]string{
+		"s3.conf":      "[global]\n# credentials\nrepo1-s3-key=key\nrepo1-s3-key-secret = secret\n",
+		"gcs.conf":     "[db]\nrepo2-gcs-key=/etc/pgbackrest/conf.d/gcs-key.json\n",
+		"gcs-key.json": "{\"type\": \"service_account\"}",
+	})
+	assert.DeepEqual(t, options, map[string]string{
+		"repo1-s3-key":        "key",
+		"repo1-s3-key-secret": "secret",
+		"repo2-gcs-key":       "/etc/pgbackrest/conf.d/gcs-key.json",
+	})
+
+	assert.DeepEqual(t, ConfigOptions(nil), map[string]string{})
+}
+
+func TestValidateExternalRepo(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		repo    v1beta1.PGBackRestRepo
+		options map[string]string
+		invalid string
+	}{{
+		desc:    "volume",
+		repo:    v1beta1.PGBackRestRepo{Name: "repo1", Volume: &v1beta1.RepoPVC{}},
+		options: map[string]string{},
+	}, {
+		desc: "azure",
+		repo: v1beta1.PGBackRestRepo{Name: "repo1",
+			Azure: &v1beta1.RepoAzure{Container: "container"}},
+		options: map[string]string{"repo1-azure-account": "account", "repo1-azure-key": "key"},
+	}, {
+		desc: "azure missing key",
+		repo: v1beta1.PGBackRestRepo{Name: "repo1",
+			Azure: &v1beta1.RepoAzure{Container: "container"}},
+		options: map[string]string{"repo1-azure-account": "account"},
+		invalid: "repo1-azure-key",
+	}, {
+		desc:    "gcs missing bucket",
+		repo:    v1beta1.PGBackRestRepo{Name: "repo2", GCS: &v1beta1.RepoGCS{}},
+		options: map[string]string{"repo2-gcs-key": "key.json"},
+		invalid: "bucket",
+	}, {
+		desc:    "gcs automatic credentials",
+		repo:    v1beta1.PGBackRestRepo{Name: "repo2", GCS: &v1beta1.RepoGCS{Bucket: "bucket"}},
+		options: map[string]string{"repo2-gcs-key-type": "auto"},
+	}, {
+		desc: "s3 missing key secret",
+		repo: v1beta1.PGBackRestRepo{Name: "repo3", S3: &v1beta1.RepoS3{
+			Bucket: "bucket", Endpoint: "endpoint", Region: "region"}},
+		options: map[string]string{"repo3-s3-key": "key", "repo1-s3-key-secret": "secret"},
+		invalid: "repo3-s3-key-secret",
+	}, {
+		desc: "s3 missing region",
+		repo: v1beta1.PGBackRestRepo{Name: "repo3", S3: &v1beta1.RepoS3{
+			Bucket: "bucket", Endpoint: "endpoint"}},
+		options: map[string]string{"repo3-s3-key": "key", "repo3-s3-key-secret": "secret"},
+		invalid: "region",
+	}, {
+		desc: "s3 web identity",
+		repo: v1beta1.PGBackRestRepo{Name: "repo3", S3: &v1beta1.RepoS3{
+			Bucket: "bucket", Endpoint: "endpoint", Region: "region"}},
+		options: map[string]string{"repo3-s3-key-type": "web-id"},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateExternalRepo(tc.repo, tc.options)
+			if tc.invalid == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.invalid)
+			}
+		})
+	}
+}
+
+func TestValidateRetention(t *testing.T) {
+	for _, tc := range []struct {
+		desc      string
+		retention *v1beta1.RepoRetention
+		options   map[string]string
+		invalid   string
+	}{{
+		desc:    "none",
+		options: map[string]string{"repo1-retention-full": "2"},
+	}, {
+		desc: "count",
+		retention: &v1beta1.RepoRetention{
+			Full: initialize.Int32(2), Diff: initialize.Int32(4), Archive: initialize.Int32(1),
+		},
+		options: map[string]string{"repo2-retention-full": "2"},
+	}, {
+		desc:      "time",
+		retention: &v1beta1.RepoRetention{Full: initialize.Int32(14), FullType: "time"},
+		options:   map[string]string{},
+	}, {
+		desc:      "zero diff",
+		retention: &v1beta1.RepoRetention{Diff: initialize.Int32(0)},
+		options:   map[string]string{},
+		invalid:   "repo1-retention-diff must be a positive number",
+	}, {
+		desc:      "type without full",
+		retention: &v1beta1.RepoRetention{FullType: "time"},
+		options:   map[string]string{},
+		invalid:   "repo1-retention-full is required",
+	}, {
+		desc:      "set in configuration",
+		retention: &v1beta1.RepoRetention{Full: initialize.Int32(2)},
+		options:   map[string]string{"repo1-retention-full": "4"},
+		invalid:   "repo1-retention-full is set in both",
+	}, {
+		desc:      "type conflicts with configuration",
+		retention: &v1beta1.RepoRetention{Full: initialize.Int32(14), FullType: "time"},
+		options:   map[string]string{"repo1-retention-full-type": "count"},
+		invalid:   "repo1-retention-full-type",
+	}, {
+		desc:      "time in configuration",
+		retention: &v1beta1.RepoRetention{Full: initialize.Int32(2)},
+		options:   map[string]string{"repo1-retention-full-type": "time"},
+		invalid:   "repo1-retention-full-type",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := v1beta1.PGBackRestRepo{
+				Name: "repo1", Volume: &v1beta1.RepoPVC{}, Retention: tc.retention,
+			}
+			err := ValidateRetention(repo, tc.options)
+			if tc.invalid == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.invalid)
+			}
+		})
+	}
 }
@@ -16,6 +16,7 @@
 package v1beta1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -84,4 +85,9 @@ type PatroniStatus struct {
 	// The PostgreSQL system identifier reported by Patroni.
 	// +optional
 	SystemIdentifier string `json:"systemIdentifier,omitempty"`
+
+	// The time the PostgreSQL cluster was first observed to be bootstrapped with the current
+	// system identifier.  It is represented in RFC3339 form and is in UTC.
+	// +optional
+	BootstrappedAt *metav1.Time `json:"bootstrappedAt,omitempty"`
 }
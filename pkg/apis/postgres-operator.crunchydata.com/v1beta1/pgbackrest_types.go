@@ -17,6 +17,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -56,6 +57,81 @@ type PGBackRestJobStatus struct {
 	Failed int32 `json:"failed,omitempty"`
 }
 
+// PGBackRestRestoreStatus defines the status of a pgBackRest restore Job
+type PGBackRestRestoreStatus struct {
+	PGBackRestJobStatus `json:",inline"`
+
+	// The progress of a running restore as reported by pgBackRest, e.g. "42.17%" while files
+	// are being restored, or "Recovering" once all files have been restored and PostgreSQL is
+	// replaying WAL.  This field is not set when progress cannot be determined.
+	// +optional
+	Progress string `json:"progress,omitempty"`
+}
+
+// PGBackRestLatestBackupStatus describes the most recent pgBackRest backup Job of a given type
+type PGBackRestLatestBackupStatus struct {
+
+	// The type of backup Job, i.e. "manual", "replica-create", "final" or "scheduled"
+	// +kubebuilder:validation:Required
+	Type string `json:"type"`
+
+	// The name of the backup Job
+	// +kubebuilder:validation:Required
+	JobName string `json:"jobName"`
+
+	// The name of the associated pgBackRest repository
+	// +optional
+	RepoName string `json:"repo,omitempty"`
+
+	// Specifies whether or not the Job is finished executing (does not indicate success or
+	// failure).
+	// +optional
+	Finished bool `json:"finished,omitempty"`
+
+	// Represents the time the backup Job was acknowledged by the Job controller.
+	// It is represented in RFC3339 form and is in UTC.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Represents the time the backup Job was determined by the Job controller to be
+	// completed.  This field is only set if the backup completed successfully.
+	// Additionally, it is represented in RFC3339 form and is in UTC.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// The number of actively running backup Pods.
+	// +optional
+	Active int32 `json:"active,omitempty"`
+
+	// The number of Pods for the backup Job that reached the "Succeeded" phase.
+	// +optional
+	Succeeded int32 `json:"succeeded,omitempty"`
+
+	// The number of Pods for the backup Job that reached the "Failed" phase.
+	// +optional
+	Failed int32 `json:"failed,omitempty"`
+
+	// The label of the pgBackRest backup set created by the backup Job, e.g. "20240101-120000F",
+	// which identifies the exact backup to use when restoring.  This field is only set once the
+	// backup has completed successfully.
+	// +optional
+	BackupSet string `json:"backupSet,omitempty"`
+
+	// The number of seconds the backup Job ran before completing successfully.
+	// +optional
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+
+	// The amount of data (in bytes) backed up from the database by the backup Job, as reported
+	// by "pgbackrest info".  This field is only set once the backup set is known.
+	// +optional
+	BackupSizeBytes int64 `json:"backupSizeBytes,omitempty"`
+
+	// The effective throughput of the backup Job in bytes per second, i.e. the size of the backup
+	// divided by its duration.  This field is only set once the backup set is known.
+	// +optional
+	ThroughputBytesPerSecond int64 `json:"throughputBytesPerSecond,omitempty"`
+}
+
 type PGBackRestScheduledBackupStatus struct {
 
 	// The name of the associated pgBackRest scheduled backup CronJob
@@ -106,6 +182,16 @@ type PGBackRestArchive struct {
 	// +optional
 	Configuration []corev1.VolumeProjection `json:"configuration,omitempty"`
 
+	// A ConfigMap containing complete pgBackRest configuration files, such as an externally
+	// managed pgbackrest.conf.  Each key of the ConfigMap is mounted as a file under
+	// "/etc/pgbackrest/conf.d" and must end in ".conf" to be loaded by pgBackRest.  pgBackRest
+	// reads the files in that directory in alphabetical order after its main configuration file,
+	// so these files are merged with the configuration generated by the PostgreSQL Operator and
+	// any files provided via "configuration".  Changes to the contents of this ConfigMap are
+	// included in the pgBackRest configuration hash.
+	// +optional
+	ConfigMap *corev1.LocalObjectReference `json:"configMap,omitempty"`
+
 	// Global pgBackRest configuration settings.  These settings are included in the "global"
 	// section of the pgBackRest configuration generated by the PostgreSQL Operator, and then
 	// mounted under "/etc/pgbackrest/conf.d":
@@ -113,28 +199,234 @@ type PGBackRestArchive struct {
 	// +optional
 	Global map[string]string `json:"global,omitempty"`
 
+	// The path of the PostgreSQL data directory, as provided to pgBackRest using the "pg-path"
+	// option of each PostgreSQL host (e.g. "pg1-path").  Only needed when the data directory of
+	// a custom image is not at the path assumed by the PostgreSQL Operator.  Defaults to the
+	// data directory of the PostgreSQL version defined in the spec.
+	// https://pgbackrest.org/configuration.html#section-stanza/option-pg-path
+	// +optional
+	// +kubebuilder:validation:Pattern=`^/`
+	PGPath string `json:"pgPath,omitempty"`
+
 	// The image name to use for pgBackRest containers.  Utilized to run pgBackRest repository
 	// hosts and backups.
 	// +kubebuilder:validation:Required
 	Image string `json:"image"`
 
-	// Defines a pgBackRest repository
+	// The image name to use for pgBackRest init containers, e.g. the container that sets up
+	// nss_wrapper.  Defaults to the pgBackRest image when not set, and is typically only needed
+	// when distinct images (or image digests) must be specified for each container.
+	// +optional
+	InitImage string `json:"initImage,omitempty"`
+
+	// The image pull secrets attached to the ServiceAccount used by pgBackRest Pods, e.g. the
+	// repository host and backup Jobs.  Each Pod using the ServiceAccount inherits these
+	// secrets, allowing images to be pulled from a private registry without defining
+	// "imagePullSecrets" for each Pod.
+	// https://k8s.io/docs/tasks/configure-pod-container/configure-service-account/#add-imagepullsecrets-to-a-service-account
+	// +optional
+	ServiceAccountImagePullSecrets []corev1.LocalObjectReference `json:"serviceAccountImagePullSecrets,omitempty"`
+
+	// Defines a pgBackRest repository.  At least one repository is required, which prevents
+	// every repository (and its backups) from being removed at once.
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
 	// +listType=map
 	// +listMapKey=name
 	Repos []PGBackRestRepo `json:"repos,omitempty"`
 
+	// The number of seconds to wait after the PostgreSQL cluster is bootstrapped before
+	// creating or enabling the CronJobs for scheduled backups, e.g. to allow the cluster to
+	// stabilize following its creation.  By default scheduled backups are enabled as soon as
+	// the cluster is ready for them.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ScheduledBackupDelaySeconds *int32 `json:"scheduledBackupDelaySeconds,omitempty"`
+
+	// The times of day during which scheduled backups are allowed to run.  When defined, the
+	// CronJobs for scheduled backups are suspended outside of these windows, and any backup
+	// scheduled while suspended is skipped rather than run once a window opens.  By default
+	// scheduled backups are allowed at any time.
+	// +optional
+	// +listType=atomic
+	ScheduledBackupWindows []BackupWindow `json:"scheduledBackupWindows,omitempty"`
+
+	// Whether or not existing stanzas are adopted rather than created, e.g. when reusing the
+	// repositories of another PostgresCluster for the same PostgreSQL cluster.  When enabled,
+	// the pgBackRest "info" command is run prior to stanza creation, and if every repository
+	// already contains a valid stanza for the PostgreSQL cluster, stanza creation is skipped
+	// and the existing backups are reused.  Defaults to false.
+	// https://pgbackrest.org/command.html#command-info
+	// +optional
+	AdoptExistingStanzas *bool `json:"adoptExistingStanzas,omitempty"`
+
+	// Whether or not the PostgreSQL Operator manages the stanzas of the pgBackRest repositories.
+	// When disabled, the pgBackRest "stanza-create" command is never run, and the stanzas of
+	// every repository are instead assumed to be created and maintained externally (e.g. by an
+	// existing pgBackRest management system).  Defaults to true.
+	// +optional
+	ManageStanza *bool `json:"manageStanza,omitempty"`
+
 	// Defines a pgBackRest repository host
 	// +optional
 	RepoHost *PGBackRestRepoHost `json:"repoHost,omitempty"`
 
+	// Defines configuration for all pgBackRest backup Jobs, i.e. replica create, manual,
+	// scheduled and final backups
+	// +optional
+	Jobs *BackupJobs `json:"jobs,omitempty"`
+
 	// Defines details for manual pgBackRest backup Jobs
 	// +optional
 	Manual *PGBackRestManualBackup `json:"manual,omitempty"`
 
+	// Defines details for a final pgBackRest backup, e.g. as taken prior to retiring the
+	// PostgresCluster.  A final backup is initiated via annotation, and once it completes
+	// successfully all scheduled backups for the PostgresCluster are suspended.
+	// +optional
+	FinalBackup *PGBackRestFinalBackup `json:"finalBackup,omitempty"`
+
+	// Defines details for expiring pgBackRest backups without taking a new backup, e.g. to
+	// reclaim space immediately after lowering retention.  An expire is initiated via annotation.
+	// +optional
+	Expire *PGBackRestExpire `json:"expire,omitempty"`
+
+	// Defines a scheduled run of the pgBackRest "check" command, which validates the
+	// configuration of pgBackRest and its connectivity to every repository.  The result of the
+	// most recent check is reported using the "PGBackRestCheck" condition.
+	// +optional
+	Check *PGBackRestCheck `json:"check,omitempty"`
+
 	// Defines details for performing an in-place restore using pgBackRest
 	// +optional
 	Restore *PGBackRestRestore `json:"restore,omitempty"`
+
+	// Defines the emptyDir volume mounted at "/tmp" within the pgBackRest repository host and
+	// PostgreSQL instance Pods.  This volume can be used as the pgBackRest spool path for
+	// asynchronous archiving (e.g. by setting "spool-path" within "global"), in which case its
+	// size limit might need to be increased.
+	// +optional
+	TmpVolume *TmpVolumeSpec `json:"tmpVolume,omitempty"`
+
+	// Defines how pgBackRest resources that are no longer needed (e.g. the volumes of
+	// repositories removed from the spec) are deleted
+	// +optional
+	Cleanup *PGBackRestCleanup `json:"cleanup,omitempty"`
+}
+
+// PGBackRestCleanup defines the propagation policies used when deleting pgBackRest resources
+// that are no longer needed.  Each policy defaults to "Background".
+// https://k8s.io/docs/concepts/architecture/garbage-collection/#cascading-deletion
+type PGBackRestCleanup struct {
+	// The propagation policy used when deleting repository volumes (PVCs).  "Foreground" waits
+	// for any dependents of a volume to be deleted before the volume itself is removed.
+	// +optional
+	// +kubebuilder:validation:Enum={Background,Foreground,Orphan}
+	RepoVolumes metav1.DeletionPropagation `json:"repoVolumes,omitempty"`
+
+	// The propagation policy used when deleting repository host resources, e.g. the repository
+	// host StatefulSet and its Pods.
+	// +optional
+	// +kubebuilder:validation:Enum={Background,Foreground,Orphan}
+	RepoHost metav1.DeletionPropagation `json:"repoHost,omitempty"`
+
+	// The propagation policy used when deleting backup and restore Jobs and the CronJobs for
+	// scheduled backups.
+	// +optional
+	// +kubebuilder:validation:Enum={Background,Foreground,Orphan}
+	Jobs metav1.DeletionPropagation `json:"jobs,omitempty"`
+
+	// Whether or not the volumes (PVCs) of repositories removed from the spec are retained
+	// rather than deleted, e.g. to preserve backups that may still be needed.  A retained volume
+	// is no longer owned by the PostgresCluster, and must be deleted manually once it is no
+	// longer needed.  Defaults to false.
+	// +optional
+	RetainRepoVolumes *bool `json:"retainRepoVolumes,omitempty"`
+
+	// Whether or not the volumes (PVCs) of repositories removed from the spec are archived rather
+	// than deleted.  An archived volume is retained in the same way as with retainRepoVolumes, and
+	// is also labeled "postgres-operator.crunchydata.com/pgbackrest-archived" with annotations
+	// identifying the PostgresCluster and time it was archived from.  Archived volumes are no
+	// longer selected by the PostgresCluster, and can be recovered or garbage collected manually.
+	// Takes precedence over retainRepoVolumes.  Defaults to false.
+	// +optional
+	ArchiveRepoVolumes *bool `json:"archiveRepoVolumes,omitempty"`
+}
+
+// TmpVolumeSpec defines the emptyDir volume used for temporary files
+type TmpVolumeSpec struct {
+	// The maximum amount of storage for the volume.  Defaults to 16Mi.
+	// https://k8s.io/docs/concepts/storage/volumes/#emptydir
+	// +optional
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
+
+	// The type of storage medium that backs the volume, e.g. "Memory" for a tmpfs.  Defaults
+	// to the storage medium of the node.
+	// +optional
+	// +kubebuilder:validation:Enum=Memory
+	Medium corev1.StorageMedium `json:"medium,omitempty"`
+}
+
+// BackupJobs defines the configuration for pgBackRest backup Jobs
+type BackupJobs struct {
+	// Resource requirements for the backup Job containers, including any ephemeral-storage
+	// requests and limits.  Since the backup itself runs within the pgBackRest repository host
+	// (or database container), the resource requirements for the repository host should be set
+	// accordingly as well, e.g. to account for any local scratch space used during compression.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Paths to exclude from all backups, relative to the PostgreSQL data directory.  Each path
+	// is passed to the pgBackRest backup command using the "--exclude" option.
+	// https://pgbackrest.org/command.html#command-backup/category-command/option-exclude
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+
+	// The size of the data backed up between saves of the backup manifest, e.g. "5GiB".  Each
+	// save of the manifest allows an interrupted backup to be resumed, but is expensive for
+	// databases with many files.  Passed to the pgBackRest backup command using the
+	// "--manifest-save-threshold" option.
+	// https://pgbackrest.org/command.html#command-backup/category-command/option-manifest-save-threshold
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+[A-Za-z]*$`
+	ManifestSaveThreshold string `json:"manifestSaveThreshold,omitempty"`
+
+	// Whether or not the ServiceAccount token is mounted within backup Job pods.  The backup
+	// Job uses this token to run pgBackRest within the repository host (or database container)
+	// via the Kubernetes API, so it should only be set to false when credentials are otherwise
+	// provided to the Job.  Defaults to the Kubernetes default of true.
+	// More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/#opt-out-of-api-credential-automounting
+	// +optional
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+
+	// The name of the PriorityClass of backup Job pods, e.g. a lower priority than restore Jobs
+	// so that a restore can preempt any running backups.
+	// More info: https://kubernetes.io/docs/concepts/scheduling-eviction/pod-priority-preemption/
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// The maximum duration of backup Jobs, after which a Job (along with its backup) is
+	// terminated.  The deadline is scaled according to the size of the database, so that it
+	// grows along with the cluster.  By default backup Jobs have no deadline.
+	// More info: https://kubernetes.io/docs/concepts/workloads/controllers/job/#job-termination-and-cleanup
+	// +optional
+	ActiveDeadline *BackupJobDeadline `json:"activeDeadline,omitempty"`
+}
+
+// BackupJobDeadline defines the "activeDeadlineSeconds" of backup Jobs as a base number of
+// seconds, plus an allowance for each GiB of the database.  The size of the database is estimated
+// using the largest backup reported in the status of the latest backups, since a full backup
+// includes every file of the database.
+type BackupJobDeadline struct {
+	// The number of seconds allowed for any backup, regardless of the size of the database.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	BaseSeconds int64 `json:"baseSeconds"`
+
+	// The number of additional seconds allowed for each GiB (or part thereof) of the database.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SecondsPerGiB int64 `json:"secondsPerGiB,omitempty"`
 }
 
 type PGBackRestManualBackup struct {
@@ -143,10 +435,75 @@ type PGBackRestManualBackup struct {
 	// +kubebuilder:validation:Pattern=^repo[1-4]
 	RepoName string `json:"repoName"`
 
+	// The type of backup to take, i.e. "full", "diff" or "incr".  Passed to the pgBackRest backup
+	// command using the "--type" option, which must then not be included in "options".  By
+	// default pgBackRest determines the type of backup.
+	// https://pgbackrest.org/command.html#command-backup/category-command/option-type
+	// +optional
+	// +kubebuilder:validation:Enum={full,diff,incr}
+	Type string `json:"type,omitempty"`
+
 	// Command line options to include when running the pgBackRest backup command.
 	// https://pgbackrest.org/command.html#command-backup
 	// +optional
 	Options []string `json:"options,omitempty"`
+
+	// Whether or not the backup is taken while PostgreSQL is running.  Set this to false to take
+	// an offline (cold) backup using the pgBackRest "--no-online" option.  An offline backup is
+	// only started once Patroni is paused (i.e. "pause" is set to true in the Patroni dynamic
+	// configuration), and PostgreSQL must then be stopped for the backup to succeed.  Defaults
+	// to true.
+	// https://pgbackrest.org/command.html#command-backup/category-command/option-online
+	// +optional
+	Online *bool `json:"online,omitempty"`
+}
+
+// PGBackRestFinalBackup defines a final full pgBackRest backup, after which all scheduled
+// backups are suspended
+type PGBackRestFinalBackup struct {
+	// The name of the pgBackRest repo to run the final full backup against.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=^repo[1-4]
+	RepoName string `json:"repoName"`
+}
+
+// PGBackRestExpire defines a run of the pgBackRest "expire" command, which removes backups (and
+// archived WAL) according to the retention configured for a repo
+type PGBackRestExpire struct {
+	// The name of the pgBackRest repo to run the expire command against.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=^repo[1-4]
+	RepoName string `json:"repoName"`
+
+	// Command line options to include when running the pgBackRest expire command.
+	// https://pgbackrest.org/command.html#command-expire
+	// +optional
+	Options []string `json:"options,omitempty"`
+}
+
+// BackupWindow defines a time range, within a single day, during which scheduled backups are
+// allowed to run.  A window that ends before it starts spans midnight, e.g. from "22:00" to
+// "06:00".
+type BackupWindow struct {
+	// The time of day, in UTC, at which the window opens, formatted as "HH:MM".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// The time of day, in UTC, at which the window closes, formatted as "HH:MM".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+}
+
+// PGBackRestCheck defines a scheduled run of the pgBackRest "check" command against all repos
+type PGBackRestCheck struct {
+	// Defines the Cron schedule for the pgBackRest check command.
+	// Follows the standard Cron schedule syntax:
+	// https://k8s.io/docs/concepts/workloads/controllers/cron-jobs/#cron-schedule-syntax
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=6
+	Schedule string `json:"schedule"`
 }
 
 // PGBackRestRepoHost represents a pgBackRest dedicated repository host
@@ -167,6 +524,56 @@ type PGBackRestRepoHost struct {
 	// Secret containing custom SSH keys
 	// +optional
 	SSHSecret *corev1.SecretProjection `json:"sshSecret,omitempty"`
+
+	// The port on which the SSH server run alongside pgBackRest listens, and to which pgBackRest
+	// connects from other Pods.  Defaults to 2022.  Any custom SSH configuration provided using
+	// sshConfigMap must use this same port.
+	// +optional
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=65535
+	SSHPort *int32 `json:"sshPort,omitempty"`
+
+	// Additional entries trusted by SSH clients connecting to the repository host, in the
+	// OpenSSH "ssh_known_hosts" format.  Useful for trusting the next (or previous) host key
+	// while keys are rotated, or for trusting host certificates using a "@cert-authority" entry.
+	// These entries are trusted alongside the host key generated by the PostgreSQL Operator, and
+	// changes take effect without restarting any Pods.  Ignored when custom SSH configuration is
+	// provided using sshConfigMap.
+	// More info: https://man.openbsd.org/sshd.8#SSH_KNOWN_HOSTS_FILE_FORMAT
+	// +optional
+	SSHKnownHosts []string `json:"sshKnownHosts,omitempty"`
+
+	// Timing of the liveness probe of the SSH server run alongside pgBackRest, e.g. to allow
+	// additional time for slow storage to be mounted before the container is restarted
+	// +optional
+	Probes *RepoHostProbes `json:"probes,omitempty"`
+}
+
+// RepoHostProbes defines the timing of the liveness probe of the SSH server run alongside
+// pgBackRest.  Any values not set use the Kubernetes defaults.
+// More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/#configure-probes
+type RepoHostProbes struct {
+	// Number of seconds after the container has started before the probe is initiated.
+	// Defaults to 0 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// How often (in seconds) to perform the probe.  Defaults to 10 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// Number of seconds after which the probe times out.  Defaults to 1 second.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// Minimum consecutive failures for the probe to be considered failed after having
+	// succeeded, after which the container is restarted.  Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
 }
 
 // PGBackRestRestore defines an in-place restore for the PostgresCluster.
@@ -212,10 +619,25 @@ type PGBackRestStatus struct {
 	// +optional
 	ManualBackup *PGBackRestJobStatus `json:"manualBackup,omitempty"`
 
+	// Status information for the final backup
+	// +optional
+	FinalBackup *PGBackRestJobStatus `json:"finalBackup,omitempty"`
+
+	// Status information for expiring backups
+	// +optional
+	Expire *PGBackRestJobStatus `json:"expire,omitempty"`
+
 	// Status information for scheduled backups
 	// +optional
 	ScheduledBackups []PGBackRestScheduledBackupStatus `json:"scheduledBackups,omitempty"`
 
+	// Status information for the most recent backup Job of each type, e.g. to identify
+	// whether the latest backup was taken manually, on a schedule or for replica creation
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	LatestBackups []PGBackRestLatestBackupStatus `json:"latestBackups,omitempty"`
+
 	// Status information for the pgBackRest dedicated repository host
 	// +optional
 	RepoHost *RepoHostStatus `json:"repoHost,omitempty"`
@@ -226,9 +648,30 @@ type PGBackRestStatus struct {
 	// +listMapKey=name
 	Repos []RepoStatus `json:"repos,omitempty"`
 
+	// The pgBackRest configuration hash for which stanzas were last created successfully.
+	// Stanza creation is skipped while this hash matches the current configuration hash.
+	// +optional
+	StanzaConfigHash string `json:"stanzaConfigHash,omitempty"`
+
+	// The value of the "pgbackrest-stanza-create" annotation for which stanzas were last
+	// created successfully.  Stanza creation is forced while the annotation has a different
+	// value.
+	// +optional
+	StanzaCreateID string `json:"stanzaCreateID,omitempty"`
+
 	// Status information for in-place restores
 	// +optional
-	Restore *PGBackRestJobStatus `json:"restore,omitempty"`
+	Restore *PGBackRestRestoreStatus `json:"restore,omitempty"`
+
+	// The time the backup counts of each repository were last updated.  Backup counts are
+	// updated periodically, as well as after each backup completes.
+	// +optional
+	BackupCountsUpdateTime *metav1.Time `json:"backupCountsUpdateTime,omitempty"`
+
+	// A hash of the container images within which the installed versions of pgBackRest were
+	// last compared.  Versions are compared again once these images change.
+	// +optional
+	VersionCheckHash string `json:"versionCheckHash,omitempty"`
 }
 
 // PGBackRestRepo represents a pgBackRest repository.  Only one of its members may be specified.
@@ -248,6 +691,15 @@ type PGBackRestRepo struct {
 	// +optional
 	BackupSchedules *PGBackRestBackupSchedules `json:"schedules,omitempty"`
 
+	// The name of another repository whose scheduled backups are also taken in this repository.
+	// Once each scheduled backup of the other repository completes, a backup of the same type is
+	// taken in this repository, e.g. so that a repository in another region used for disaster
+	// recovery receives the same backups as the primary repository.  The status of these
+	// backups is included in the status of scheduled backups.
+	// +optional
+	// +kubebuilder:validation:Pattern=^repo[1-4]
+	FollowSchedulesOf string `json:"followSchedulesOf,omitempty"`
+
 	// Represents a pgBackRest repository that is created using Azure storage
 	// +optional
 	Azure *RepoAzure `json:"azure,omitempty"`
@@ -264,6 +716,44 @@ type PGBackRestRepo struct {
 	// Represents a pgBackRest repository that is created using a PersistentVolumeClaim
 	// +optional
 	Volume *RepoPVC `json:"volume,omitempty"`
+
+	// The path where backups and archive are stored within the repository, e.g. to store the
+	// backups for multiple clusters within a single bucket.  Only applicable to Azure, GCS and
+	// S3 repositories.  Defaults to "/pgbackrest/" followed by the name of the repository.
+	// https://pgbackrest.org/configuration.html#section-repository/option-repo-path
+	// +optional
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
+
+	// Resource requirements for the containers of backup Jobs that target this repository,
+	// e.g. to provide additional CPU for compressing backups stored in a cloud repository.
+	// When set, these override the resource requirements defined for all backup Jobs.
+	// +optional
+	BackupJobResources *corev1.ResourceRequirements `json:"backupJobResources,omitempty"`
+
+	// The type of the backup taken in this repository as needed to create replicas, when this
+	// is the first repository defined.  When not set, a full backup is taken unless the
+	// repository already contains one, in which case an incremental backup is taken instead.
+	// +optional
+	// +kubebuilder:validation:Enum={full,diff,incr}
+	ReplicaCreateBackupType string `json:"replicaCreateBackupType,omitempty"`
+
+	// The retention of backups and archive within the repository.  Backups and archive beyond
+	// this retention are removed by pgBackRest once each backup completes, and whenever an expire
+	// is initiated for the repository.
+	// https://pgbackrest.org/configuration.html#section-repository/option-repo-retention-full
+	// +optional
+	Retention *RepoRetention `json:"retention,omitempty"`
+
+	// Whether or not to run the pgBackRest verify command once each backup of this repository
+	// completes.  When enabled, the backup Job fails if verification fails.  Note that the
+	// entire repository is verified, i.e. every backup and all archive it contains, since the
+	// backup set created by the Job is not known until it completes.  Verification therefore
+	// reads (and for cloud repositories, downloads) the whole repository after every backup.
+	// Defaults to false.
+	// https://pgbackrest.org/command.html#command-verify
+	// +optional
+	Verify *bool `json:"verify,omitempty"`
 }
 
 // RepoHostStatus defines the status of a pgBackRest repository host
@@ -273,11 +763,33 @@ type RepoHostStatus struct {
 	// Whether or not the pgBackRest repository host is ready for use
 	// +optional
 	Ready bool `json:"ready"`
+
+	// The pgBackRest configuration hash currently mounted within the repository host, as last
+	// read by the PostgreSQL Operator.  This differs from the desired hash while configuration
+	// changes are propagating to the repository host.
+	// +optional
+	ActiveConfigHash string `json:"activeConfigHash,omitempty"`
+
+	// The pgBackRest configuration hash for the current spec, i.e. the hash expected to be
+	// mounted within the repository host
+	// +optional
+	DesiredConfigHash string `json:"desiredConfigHash,omitempty"`
+
+	// The time the pgBackRest "check" command last ran within the repository host to determine
+	// its readiness.  While the repository host remains ready, the check runs again only once
+	// this time is sufficiently old.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
 }
 
 // RepoPVC represents a pgBackRest repository that is created using a PersistentVolumeClaim
 type RepoPVC struct {
 
+	// Labels and annotations for the PersistentVolumeClaim of the repository only, e.g. the
+	// annotations required by a storage provider.  These are not applied to any other resource.
+	// +optional
+	Metadata *Metadata `json:"metadata,omitempty"`
+
 	// Defines a PersistentVolumeClaim spec used to create and/or bind a volume
 	// +kubebuilder:validation:Required
 	VolumeClaimSpec corev1.PersistentVolumeClaimSpec `json:"volumeClaimSpec"`
@@ -316,6 +828,37 @@ type RepoS3 struct {
 	Region string `json:"region"`
 }
 
+// RepoRetention defines the number of backups (or days of backups) and archive retained within a
+// pgBackRest repository.  Each field corresponds to one of the "repo-retention-*" options of the
+// repository.
+type RepoRetention struct {
+	// The number of full backups to retain, or the number of days of full backups to retain when
+	// the full retention type is "time".
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=9999999
+	Full *int32 `json:"full,omitempty"`
+
+	// Whether full retention is a number of backups ("count") or a number of days ("time").
+	// Defaults to "count".
+	// +optional
+	// +kubebuilder:validation:Enum={count,time}
+	FullType string `json:"fullType,omitempty"`
+
+	// The number of differential backups to retain.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=9999999
+	Diff *int32 `json:"diff,omitempty"`
+
+	// The number of backups worth of continuous WAL archive to retain.  When not set, the WAL
+	// archive needed by the backups retained is kept.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=9999999
+	Archive *int32 `json:"archive,omitempty"`
+}
+
 // RepoVolumeStatus the status of a pgBackRest repository
 type RepoStatus struct {
 
@@ -331,10 +874,33 @@ type RepoStatus struct {
 	// +optional
 	VolumeName string `json:"volume,omitempty"`
 
+	// The name of the StorageClass of the volume containing the pgBackRest repository
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// The storage capacity requested for the volume containing the pgBackRest repository
+	// +optional
+	RequestedCapacity string `json:"requestedCapacity,omitempty"`
+
+	// The actual storage capacity of the volume containing the pgBackRest repository, as
+	// reported once the volume is bound
+	// +optional
+	ActualCapacity string `json:"actualCapacity,omitempty"`
+
 	// Specifies whether or not a stanza has been successfully created for the repository
 	// +optional
 	StanzaCreated bool `json:"stanzaCreated"`
 
+	// The time the stanza was last created successfully for the repository.  It is represented
+	// in RFC3339 form and is in UTC.
+	// +optional
+	StanzaCreatedAt *metav1.Time `json:"stanzaCreatedAt,omitempty"`
+
+	// The pgBackRest configuration hash for which the stanza was last created successfully for
+	// the repository
+	// +optional
+	StanzaConfigHash string `json:"stanzaConfigHash,omitempty"`
+
 	// ReplicaCreateBackupReady indicates whether a backup exists in the repository as needed
 	// to bootstrap replicas.
 	ReplicaCreateBackupComplete bool `json:"replicaCreateBackupComplete,omitempty"`
@@ -344,4 +910,54 @@ type RepoStatus struct {
 	// commands accordingly.
 	// +optional
 	RepoOptionsHash string `json:"repoOptionsHash,omitempty"`
+
+	// Whether or not the configuration of an Azure, GCS or S3 repository is valid, e.g. whether
+	// the credentials needed to access the repository have been provided.  Only set for Azure,
+	// GCS and S3 repositories, and for other repositories that define retention settings.
+	// +optional
+	ConfigValid *bool `json:"configValid,omitempty"`
+
+	// A description of the problem found with the configuration of an Azure, GCS or S3
+	// repository, or with the retention settings of any repository, if any
+	// +optional
+	ConfigError string `json:"configError,omitempty"`
+
+	// The pgBackRest configuration hash for which the bucket or container of an Azure, GCS or S3
+	// repository was last found to be accessible.  Access is checked again whenever the
+	// configuration changes.
+	// +optional
+	AccessConfigHash string `json:"accessConfigHash,omitempty"`
+
+	// A description of the problem found when accessing the bucket or container of an Azure,
+	// GCS or S3 repository, if any, e.g. because it does not exist or access was denied
+	// +optional
+	AccessError string `json:"accessError,omitempty"`
+
+	// The number of backups of each type within the repository, as last reported by the
+	// pgBackRest "info" command
+	// +optional
+	BackupCounts *RepoBackupCounts `json:"backupCounts,omitempty"`
+
+	// The time the most recent successful backup Job for the repository completed.  This is
+	// retained once the Job has been removed.
+	// +optional
+	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
+
+	// The type of the most recent successful backup in the repository, i.e. "full", "diff" or
+	// "incr".  Not set when the type of that backup could not be determined.
+	// +optional
+	LastBackupType string `json:"lastBackupType,omitempty"`
+}
+
+// RepoBackupCounts defines the number of backups of each type within a pgBackRest repository
+type RepoBackupCounts struct {
+
+	// The number of full backups within the repository
+	Full int32 `json:"full"`
+
+	// The number of differential backups within the repository
+	Differential int32 `json:"differential"`
+
+	// The number of incremental backups within the repository
+	Incremental int32 `json:"incremental"`
 }
@@ -25,7 +25,8 @@ import (
 // DedicatedRepo defines a pgBackRest dedicated repository host
 type DedicatedRepo struct {
 
-	// Resource requirements for the dedicated repository host
+	// Resource requirements for the dedicated repository host, including any ephemeral-storage
+	// requests and limits needed for backups that stage files locally
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 
@@ -39,6 +40,74 @@ type DedicatedRepo struct {
 	// More info: https://kubernetes.io/docs/concepts/scheduling-eviction/taint-and-toleration
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Whether or not the readiness of the dedicated repository host should also require a
+	// successful run of the pgBackRest "check" command, verifying that the repositories can
+	// actually be reached.  The check is only run once stanzas have been created for all
+	// repositories.
+	// +optional
+	CheckReadiness *bool `json:"checkReadiness,omitempty"`
+
+	// The minimum number of seconds the dedicated repository host pod must be ready, without
+	// any of its containers restarting, before the repository host is considered ready.  This
+	// prevents backups from starting when the pod only becomes ready briefly, e.g. during
+	// storage issues.  Defaults to 0, i.e. the repository host is ready as soon as its pod is.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+
+	// Additional containers to run within the dedicated repository host pod, e.g. a metrics
+	// exporter for pgBackRest.  Sidecars share the pod with the pgBackRest container but do
+	// not have the pgBackRest configuration or repository volumes mounted.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+
+	// Defines the behavior of changing the ownership and permissions of the repository volume
+	// before it is exposed inside the dedicated repository host pod.  Defaults to
+	// "OnRootMismatch", which avoids recursively changing the ownership of large repository
+	// volumes each time the pod starts.  Not applicable when deployed to OpenShift.
+	// More info: https://k8s.io/docs/tasks/configure-pod-container/security-context/#configure-volume-permission-and-ownership-change-policy-for-pods
+	// +optional
+	// +kubebuilder:validation:Enum={OnRootMismatch,Always}
+	FSGroupChangePolicy *corev1.PodFSGroupChangePolicy `json:"fsGroupChangePolicy,omitempty"`
+
+	// Exposes the metrics served by a sidecar within the dedicated repository host pod, e.g. a
+	// metrics exporter for pgBackRest, using a Service.  When the Prometheus Operator is
+	// installed, a ServiceMonitor targeting the Service is created as well.
+	// +optional
+	Metrics *DedicatedRepoMetrics `json:"metrics,omitempty"`
+
+	// The names of Secrets that must exist before the dedicated repository host is started,
+	// e.g. Secrets containing credentials that are synchronized from an external secret store.
+	// Until all of these Secrets exist, the repository host pod remains pending rather than
+	// starting and failing.
+	// +optional
+	// +listType=set
+	WaitForSecrets []string `json:"waitForSecrets,omitempty"`
+
+	// Whether or not the ServiceAccount token is mounted within the dedicated repository host
+	// pod.  pgBackRest does not make any Kubernetes API calls from the repository host, so this
+	// can be set to false unless a sidecar or cloud credentials (e.g. IRSA) require the token.
+	// Defaults to the Kubernetes default of true.
+	// More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/#opt-out-of-api-credential-automounting
+	// +optional
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+}
+
+// DedicatedRepoMetrics defines how metrics are exposed for the dedicated repository host
+type DedicatedRepoMetrics struct {
+	// The port on which metrics are served within the dedicated repository host pod
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// The HTTP path on which metrics are served.  Defaults to "/metrics".
+	// +optional
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
 }
 
 // PostgresClusterSpec defines the desired state of PostgresCluster
@@ -93,6 +162,17 @@ type PostgresClusterSpec struct {
 	// +optional
 	OpenShift *bool `json:"openshift,omitempty"`
 
+	// The FSGroup of the pods of the PostgreSQL cluster, i.e. PostgreSQL instances, the
+	// pgBackRest repository host and restore Jobs.  Defaults to the "postgres" group (26), except
+	// when deploying to OpenShift, where no FSGroup is set by default so that it is assigned
+	// according to the SecurityContextConstraints of each pod.  Set this when the
+	// SecurityContextConstraints require or allow an explicit FSGroup.
+	// Changing this value causes PostgreSQL and the pgBackRest repository host to restart.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2147483647
+	FSGroup *int64 `json:"fsGroup,omitempty"`
+
 	// +optional
 	Patroni *PatroniSpec `json:"patroni,omitempty"`
 
@@ -126,6 +206,16 @@ type PostgresClusterSpec struct {
 	// Run this cluster as a read-only copy of an existing cluster or archive.
 	// +optional
 	Standby *PostgresStandbySpec `json:"standby,omitempty"`
+
+	// Supplemental groups added to the pods of the PostgreSQL cluster, i.e. PostgreSQL
+	// instances, the pgBackRest repository host and restore Jobs.  Each group must be a
+	// non-root group ID, i.e. between 1 and 2147483647.  Defaults to the "nobody" group
+	// (65534) when not set, while an empty list adds no supplemental groups.
+	// Changing this value causes PostgreSQL and the pgBackRest repository host to restart.
+	// +optional
+	// +kubebuilder:validation:items:Minimum=1
+	// +kubebuilder:validation:items:Maximum=2147483647
+	SupplementalGroups []int64 `json:"supplementalGroups,omitempty"`
 }
 
 // DataSource defines the source of the PostgreSQL data directory for a new PostgresCluster.
@@ -162,9 +252,44 @@ type PostgresClusterDataSource struct {
 	// +optional
 	Options []string `json:"options,omitempty"`
 
+	// The label of the backup to restore, e.g. "20210609-141511F" for a full backup.  When not
+	// set, pgBackRest restores the latest backup, or the backup needed to reach the recovery
+	// target.
+	// https://pgbackrest.org/command.html#command-restore/category-command/option-set
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]{8}-[0-9]{6}F(_[0-9]{8}-[0-9]{6}[DI])?$`
+	Set string `json:"set,omitempty"`
+
+	// The type of the recovery target, i.e. a point-in-time recovery to a specific time, LSN,
+	// transaction ID or named restore point.  Requires a target.
+	// https://pgbackrest.org/command.html#command-restore/category-command/option-type
+	// +optional
+	// +kubebuilder:validation:Enum={time,lsn,xid,name}
+	Type string `json:"type,omitempty"`
+
+	// The recovery target, e.g. "2021-06-09 14:15:11-04" when the type is "time".  Requires a
+	// type.  The cluster is promoted once the target is reached.
+	// https://pgbackrest.org/command.html#command-restore/category-command/option-target
+	// +optional
+	Target string `json:"target,omitempty"`
+
+	// The recovery target timeline to use when running the pgBackRest restore command, i.e.
+	// a positive integer, "current" or "latest".  When not set, the timeline is determined by
+	// PostgreSQL.
+	// https://pgbackrest.org/command.html#command-restore/category-command/option-target-timeline
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([1-9][0-9]*|current|latest)$`
+	TargetTimeline string `json:"targetTimeline,omitempty"`
+
 	// Resource requirements for the pgBackRest restore Job.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// The name of the PriorityClass of the pgBackRest restore Job pod, e.g. a higher priority
+	// than backup Jobs so that a restore can preempt any running backups.
+	// More info: https://kubernetes.io/docs/concepts/scheduling-eviction/pod-priority-preemption/
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
 }
 
 func (s *PostgresClusterSpec) Default() {
@@ -25,6 +25,67 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupJobDeadline) DeepCopyInto(out *BackupJobDeadline) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupJobDeadline.
+func (in *BackupJobDeadline) DeepCopy() *BackupJobDeadline {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupJobDeadline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupJobs) DeepCopyInto(out *BackupJobs) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ActiveDeadline != nil {
+		in, out := &in.ActiveDeadline, &out.ActiveDeadline
+		*out = new(BackupJobDeadline)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupJobs.
+func (in *BackupJobs) DeepCopy() *BackupJobs {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupJobs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupWindow) DeepCopyInto(out *BackupWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupWindow.
+func (in *BackupWindow) DeepCopy() *BackupWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Backups) DeepCopyInto(out *Backups) {
 	*out = *in
@@ -77,6 +138,43 @@ func (in *DedicatedRepo) DeepCopyInto(out *DedicatedRepo) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CheckReadiness != nil {
+		in, out := &in.CheckReadiness, &out.CheckReadiness
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinReadySeconds != nil {
+		in, out := &in.MinReadySeconds, &out.MinReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FSGroupChangePolicy != nil {
+		in, out := &in.FSGroupChangePolicy, &out.FSGroupChangePolicy
+		*out = new(v1.PodFSGroupChangePolicy)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(DedicatedRepoMetrics)
+		**out = **in
+	}
+	if in.WaitForSecrets != nil {
+		in, out := &in.WaitForSecrets, &out.WaitForSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedRepo.
@@ -89,6 +187,21 @@ func (in *DedicatedRepo) DeepCopy() *DedicatedRepo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DedicatedRepoMetrics) DeepCopyInto(out *DedicatedRepoMetrics) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedRepoMetrics.
+func (in *DedicatedRepoMetrics) DeepCopy() *DedicatedRepoMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(DedicatedRepoMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExporterSpec) DeepCopyInto(out *ExporterSpec) {
 	*out = *in
@@ -191,6 +304,11 @@ func (in *PGBackRestArchive) DeepCopyInto(out *PGBackRestArchive) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
 	if in.Global != nil {
 		in, out := &in.Global, &out.Global
 		*out = make(map[string]string, len(*in))
@@ -198,6 +316,11 @@ func (in *PGBackRestArchive) DeepCopyInto(out *PGBackRestArchive) {
 			(*out)[key] = val
 		}
 	}
+	if in.ServiceAccountImagePullSecrets != nil {
+		in, out := &in.ServiceAccountImagePullSecrets, &out.ServiceAccountImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.Repos != nil {
 		in, out := &in.Repos, &out.Repos
 		*out = make([]PGBackRestRepo, len(*in))
@@ -205,21 +328,71 @@ func (in *PGBackRestArchive) DeepCopyInto(out *PGBackRestArchive) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ScheduledBackupDelaySeconds != nil {
+		in, out := &in.ScheduledBackupDelaySeconds, &out.ScheduledBackupDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScheduledBackupWindows != nil {
+		in, out := &in.ScheduledBackupWindows, &out.ScheduledBackupWindows
+		*out = make([]BackupWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdoptExistingStanzas != nil {
+		in, out := &in.AdoptExistingStanzas, &out.AdoptExistingStanzas
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManageStanza != nil {
+		in, out := &in.ManageStanza, &out.ManageStanza
+		*out = new(bool)
+		**out = **in
+	}
 	if in.RepoHost != nil {
 		in, out := &in.RepoHost, &out.RepoHost
 		*out = new(PGBackRestRepoHost)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = new(BackupJobs)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Manual != nil {
 		in, out := &in.Manual, &out.Manual
 		*out = new(PGBackRestManualBackup)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FinalBackup != nil {
+		in, out := &in.FinalBackup, &out.FinalBackup
+		*out = new(PGBackRestFinalBackup)
+		**out = **in
+	}
+	if in.Expire != nil {
+		in, out := &in.Expire, &out.Expire
+		*out = new(PGBackRestExpire)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Check != nil {
+		in, out := &in.Check, &out.Check
+		*out = new(PGBackRestCheck)
+		**out = **in
+	}
 	if in.Restore != nil {
 		in, out := &in.Restore, &out.Restore
 		*out = new(PGBackRestRestore)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TmpVolume != nil {
+		in, out := &in.TmpVolume, &out.TmpVolume
+		*out = new(TmpVolumeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cleanup != nil {
+		in, out := &in.Cleanup, &out.Cleanup
+		*out = new(PGBackRestCleanup)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestArchive.
@@ -262,6 +435,81 @@ func (in *PGBackRestBackupSchedules) DeepCopy() *PGBackRestBackupSchedules {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestCheck) DeepCopyInto(out *PGBackRestCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestCheck.
+func (in *PGBackRestCheck) DeepCopy() *PGBackRestCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestCleanup) DeepCopyInto(out *PGBackRestCleanup) {
+	*out = *in
+	if in.RetainRepoVolumes != nil {
+		in, out := &in.RetainRepoVolumes, &out.RetainRepoVolumes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ArchiveRepoVolumes != nil {
+		in, out := &in.ArchiveRepoVolumes, &out.ArchiveRepoVolumes
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestCleanup.
+func (in *PGBackRestCleanup) DeepCopy() *PGBackRestCleanup {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestCleanup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestExpire) DeepCopyInto(out *PGBackRestExpire) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestExpire.
+func (in *PGBackRestExpire) DeepCopy() *PGBackRestExpire {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestExpire)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestFinalBackup) DeepCopyInto(out *PGBackRestFinalBackup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestFinalBackup.
+func (in *PGBackRestFinalBackup) DeepCopy() *PGBackRestFinalBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestFinalBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBackRestJobStatus) DeepCopyInto(out *PGBackRestJobStatus) {
 	*out = *in
@@ -285,6 +533,29 @@ func (in *PGBackRestJobStatus) DeepCopy() *PGBackRestJobStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestLatestBackupStatus) DeepCopyInto(out *PGBackRestLatestBackupStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestLatestBackupStatus.
+func (in *PGBackRestLatestBackupStatus) DeepCopy() *PGBackRestLatestBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestLatestBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBackRestManualBackup) DeepCopyInto(out *PGBackRestManualBackup) {
 	*out = *in
@@ -293,6 +564,11 @@ func (in *PGBackRestManualBackup) DeepCopyInto(out *PGBackRestManualBackup) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Online != nil {
+		in, out := &in.Online, &out.Online
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestManualBackup.
@@ -333,6 +609,21 @@ func (in *PGBackRestRepo) DeepCopyInto(out *PGBackRestRepo) {
 		*out = new(RepoPVC)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BackupJobResources != nil {
+		in, out := &in.BackupJobResources, &out.BackupJobResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(RepoRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestRepo.
@@ -364,6 +655,21 @@ func (in *PGBackRestRepoHost) DeepCopyInto(out *PGBackRestRepoHost) {
 		*out = new(v1.SecretProjection)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SSHPort != nil {
+		in, out := &in.SSHPort, &out.SSHPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SSHKnownHosts != nil {
+		in, out := &in.SSHKnownHosts, &out.SSHKnownHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(RepoHostProbes)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestRepoHost.
@@ -401,6 +707,22 @@ func (in *PGBackRestRestore) DeepCopy() *PGBackRestRestore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestRestoreStatus) DeepCopyInto(out *PGBackRestRestoreStatus) {
+	*out = *in
+	in.PGBackRestJobStatus.DeepCopyInto(&out.PGBackRestJobStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestRestoreStatus.
+func (in *PGBackRestRestoreStatus) DeepCopy() *PGBackRestRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBackRestScheduledBackupStatus) DeepCopyInto(out *PGBackRestScheduledBackupStatus) {
 	*out = *in
@@ -432,6 +754,16 @@ func (in *PGBackRestStatus) DeepCopyInto(out *PGBackRestStatus) {
 		*out = new(PGBackRestJobStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FinalBackup != nil {
+		in, out := &in.FinalBackup, &out.FinalBackup
+		*out = new(PGBackRestJobStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expire != nil {
+		in, out := &in.Expire, &out.Expire
+		*out = new(PGBackRestJobStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ScheduledBackups != nil {
 		in, out := &in.ScheduledBackups, &out.ScheduledBackups
 		*out = make([]PGBackRestScheduledBackupStatus, len(*in))
@@ -439,21 +771,34 @@ func (in *PGBackRestStatus) DeepCopyInto(out *PGBackRestStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LatestBackups != nil {
+		in, out := &in.LatestBackups, &out.LatestBackups
+		*out = make([]PGBackRestLatestBackupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.RepoHost != nil {
 		in, out := &in.RepoHost, &out.RepoHost
 		*out = new(RepoHostStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Repos != nil {
 		in, out := &in.Repos, &out.Repos
 		*out = make([]RepoStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Restore != nil {
 		in, out := &in.Restore, &out.Restore
-		*out = new(PGBackRestJobStatus)
+		*out = new(PGBackRestRestoreStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BackupCountsUpdateTime != nil {
+		in, out := &in.BackupCountsUpdateTime, &out.BackupCountsUpdateTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestStatus.
@@ -627,6 +972,10 @@ func (in *PatroniSpec) DeepCopy() *PatroniSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PatroniStatus) DeepCopyInto(out *PatroniStatus) {
 	*out = *in
+	if in.BootstrappedAt != nil {
+		in, out := &in.BootstrappedAt, &out.BootstrappedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatroniStatus.
@@ -760,6 +1109,11 @@ func (in *PostgresClusterSpec) DeepCopyInto(out *PostgresClusterSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.FSGroup != nil {
+		in, out := &in.FSGroup, &out.FSGroup
+		*out = new(int64)
+		**out = **in
+	}
 	if in.Patroni != nil {
 		in, out := &in.Patroni, &out.Patroni
 		*out = new(PatroniSpec)
@@ -790,6 +1144,11 @@ func (in *PostgresClusterSpec) DeepCopyInto(out *PostgresClusterSpec) {
 		*out = new(PostgresStandbySpec)
 		**out = **in
 	}
+	if in.SupplementalGroups != nil {
+		in, out := &in.SupplementalGroups, &out.SupplementalGroups
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresClusterSpec.
@@ -813,7 +1172,7 @@ func (in *PostgresClusterStatus) DeepCopyInto(out *PostgresClusterStatus) {
 	if in.Patroni != nil {
 		in, out := &in.Patroni, &out.Patroni
 		*out = new(PatroniStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.PGBackRest != nil {
 		in, out := &in.PGBackRest, &out.PGBackRest
@@ -966,6 +1325,21 @@ func (in *RepoAzure) DeepCopy() *RepoAzure {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepoBackupCounts) DeepCopyInto(out *RepoBackupCounts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoBackupCounts.
+func (in *RepoBackupCounts) DeepCopy() *RepoBackupCounts {
+	if in == nil {
+		return nil
+	}
+	out := new(RepoBackupCounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepoGCS) DeepCopyInto(out *RepoGCS) {
 	*out = *in
@@ -981,10 +1355,49 @@ func (in *RepoGCS) DeepCopy() *RepoGCS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepoHostProbes) DeepCopyInto(out *RepoHostProbes) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoHostProbes.
+func (in *RepoHostProbes) DeepCopy() *RepoHostProbes {
+	if in == nil {
+		return nil
+	}
+	out := new(RepoHostProbes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepoHostStatus) DeepCopyInto(out *RepoHostStatus) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoHostStatus.
@@ -1000,6 +1413,11 @@ func (in *RepoHostStatus) DeepCopy() *RepoHostStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepoPVC) DeepCopyInto(out *RepoPVC) {
 	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(Metadata)
+		(*in).DeepCopyInto(*out)
+	}
 	in.VolumeClaimSpec.DeepCopyInto(&out.VolumeClaimSpec)
 }
 
@@ -1013,6 +1431,36 @@ func (in *RepoPVC) DeepCopy() *RepoPVC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepoRetention) DeepCopyInto(out *RepoRetention) {
+	*out = *in
+	if in.Full != nil {
+		in, out := &in.Full, &out.Full
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoRetention.
+func (in *RepoRetention) DeepCopy() *RepoRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(RepoRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepoS3) DeepCopyInto(out *RepoS3) {
 	*out = *in
@@ -1031,6 +1479,24 @@ func (in *RepoS3) DeepCopy() *RepoS3 {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepoStatus) DeepCopyInto(out *RepoStatus) {
 	*out = *in
+	if in.StanzaCreatedAt != nil {
+		in, out := &in.StanzaCreatedAt, &out.StanzaCreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ConfigValid != nil {
+		in, out := &in.ConfigValid, &out.ConfigValid
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BackupCounts != nil {
+		in, out := &in.BackupCounts, &out.BackupCounts
+		*out = new(RepoBackupCounts)
+		**out = **in
+	}
+	if in.LastBackupTime != nil {
+		in, out := &in.LastBackupTime, &out.LastBackupTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoStatus.
@@ -1042,3 +1508,23 @@ func (in *RepoStatus) DeepCopy() *RepoStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TmpVolumeSpec) DeepCopyInto(out *TmpVolumeSpec) {
+	*out = *in
+	if in.SizeLimit != nil {
+		in, out := &in.SizeLimit, &out.SizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TmpVolumeSpec.
+func (in *TmpVolumeSpec) DeepCopy() *TmpVolumeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TmpVolumeSpec)
+	in.DeepCopyInto(out)
+	return out
+}